@@ -17,6 +17,7 @@ limitations under the License.
 package analyze
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"slices"
@@ -33,10 +34,6 @@ import (
 
 	"sigs.k8s.io/gwctl/pkg/common"
 	"sigs.k8s.io/gwctl/pkg/extension"
-	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
-	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
-	"sigs.k8s.io/gwctl/pkg/extension/notfoundrefvalidator"
-	"sigs.k8s.io/gwctl/pkg/extension/refgrantvalidator"
 	extensionutils "sigs.k8s.io/gwctl/pkg/extension/utils"
 	"sigs.k8s.io/gwctl/pkg/policymanager"
 	"sigs.k8s.io/gwctl/pkg/topology"
@@ -59,6 +56,11 @@ func NewCmd(factory common.Factory, iostreams genericiooptions.IOStreams) *cobra
 			}
 
 			err = o.Run()
+			var issuesErr *issuesFoundError
+			if errors.As(err, &issuesErr) {
+				fmt.Fprintf(os.Stderr, "%v\n", issuesErr)
+				os.Exit(issuesErr.exitCode)
+			}
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%v", err)
 				os.Exit(1)
@@ -67,21 +69,46 @@ func NewCmd(factory common.Factory, iostreams genericiooptions.IOStreams) *cobra
 	}
 
 	flags.fileNameFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVarP(&flags.outputFormat, "output", "o", "", fmt.Sprintf("Output format. Must be one of: %v", strings.Join([]string{outputFormatJSON, outputFormatYAML}, ",")))
+	cmd.Flags().StringVar(&flags.failOn, "fail-on", failOnNone, fmt.Sprintf("Exit with a non-zero code if issues are found. One of: %v (exit code %v if the changes introduce issues), %v (additionally exit code %v if issues remain which already existed), %v.", failOnNew, exitCodeNewIssues, failOnAny, exitCodeExistingIssues, failOnNone))
+	cmd.Flags().StringVar(&flags.failOnSeverity, "fail-on-severity", string(common.SeverityError), fmt.Sprintf("Minimum severity of the issues considered by --fail-on. One of: %v, %v, %v.", common.SeverityError, common.SeverityWarning, common.SeverityInfo))
 	return cmd
 }
 
 // analyzeFlags contains the flags used with analyze command.
 type analyzeFlags struct {
-	fileNameFlags *genericclioptions.FileNameFlags
+	fileNameFlags  *genericclioptions.FileNameFlags
+	outputFormat   string
+	failOn         string
+	failOnSeverity string
 }
 
 func (f *analyzeFlags) ToOptions(_ []string, factory common.Factory, iostreams genericiooptions.IOStreams) (*analyzeOptions, error) {
 	namespace, _, _ := factory.KubeConfigNamespace()
 
+	switch f.outputFormat {
+	case "", outputFormatJSON, outputFormatYAML:
+	default:
+		return nil, fmt.Errorf("invalid output format %q, must be one of: %v", f.outputFormat, strings.Join([]string{outputFormatJSON, outputFormatYAML}, ","))
+	}
+
+	switch f.failOn {
+	case failOnNew, failOnAny, failOnNone:
+	default:
+		return nil, fmt.Errorf("invalid value %q for --fail-on, must be one of: %v", f.failOn, strings.Join([]string{failOnNew, failOnAny, failOnNone}, ","))
+	}
+	failOnSeverity, err := common.ParseSeverity(f.failOnSeverity)
+	if err != nil {
+		return nil, err
+	}
+
 	return &analyzeOptions{
 		fileNameOptions: f.fileNameFlags.ToOptions(),
 		factory:         factory,
 		namespace:       namespace,
+		outputFormat:    f.outputFormat,
+		failOn:          f.failOn,
+		failOnSeverity:  failOnSeverity,
 		IOStreams:       iostreams,
 	}, nil
 }
@@ -90,14 +117,20 @@ type analyzeOptions struct {
 	fileNameOptions resource.FilenameOptions
 	factory         common.Factory
 	namespace       string
+	// outputFormat is empty for the human readable report.
+	outputFormat   string
+	failOn         string
+	failOnSeverity common.Severity
 
 	genericclioptions.IOStreams
 }
 
 func (o *analyzeOptions) Run() error {
-	fmt.Fprintf(o.Out, "\n")
-	fmt.Fprintf(o.Out, "Analyzing %v...\n", strings.Join(o.fileNameOptions.Filenames, ","))
-	fmt.Fprintf(o.Out, "\n")
+	if o.outputFormat == "" {
+		fmt.Fprintf(o.Out, "\n")
+		fmt.Fprintf(o.Out, "Analyzing %v...\n", strings.Join(o.fileNameOptions.Filenames, ","))
+		fmt.Fprintf(o.Out, "\n")
+	}
 
 	// Step 1: Parse the files and extract the objects from the files.
 	infos, err := o.factory.NewBuilder().
@@ -145,35 +178,43 @@ func (o *analyzeOptions) Run() error {
 		u := &unstructured.Unstructured{Object: o}
 		sources = append(sources, u)
 	}
-	graph, err := topology.NewBuilder(common.NewDefaultGroupKindFetcher(o.factory, common.WithAdditionalResources(sources))).
-		StartFrom(sources).
-		UseRelationships(topologygw.AllRelations).
-		WithMaxDepth(4).
-		Build()
+	// Both the state after and before the changes are fetched through a
+	// shared cache, so that each GroupKind is listed at most once.
+	cache := common.NewFetchCache()
+	defer cache.LogMetrics()
+	serverFetcher := common.NewDefaultGroupKindFetcher(o.factory, common.WithCache(cache))
+	fetcher := common.NewDefaultGroupKindFetcher(o.factory, common.WithCache(cache), common.WithAdditionalResources(sources))
+
+	policyManager := policymanager.New(fetcher)
+	if err := policyManager.Init(); err != nil { //nolint:govet
+		return err
+	}
+	// Policies are not related to any other objects in the graph, so the
+	// objects targeted by the policies in the files are used as sources as
+	// well. This allows analyzing the effects of the policies on those objects.
+	targets, err := policyTargets(fetcher, policyManager, sources)
 	if err != nil {
 		return err
 	}
 
-	policyManager := policymanager.New(common.NewDefaultGroupKindFetcher(o.factory, common.WithAdditionalResources(sources)))
-	if err := policyManager.Init(); err != nil { //nolint:govet
+	graph, err := topology.NewBuilder(fetcher).
+		StartFrom(append(sources, targets...)).
+		UseRelationships(topologygw.DefaultRegistry.Relations()).
+		WithMaxDepth(4).
+		Build()
+	if err != nil {
 		return err
 	}
+
 	// Execute extensions.
-	err = extension.ExecuteAll(graph,
-		directlyattachedpolicy.NewExtension(policyManager),
-		gatewayeffectivepolicy.NewExtension(),
-		refgrantvalidator.NewExtension(
-			refgrantvalidator.NewDefaultReferenceGrantFetcher(o.factory, refgrantvalidator.WithAdditionalResources(sources)),
-		),
-		notfoundrefvalidator.NewExtension(),
-	)
+	err = extension.ExecuteAll(graph, extension.DefaultExtensions(fetcher, policyManager)...)
 	if err != nil {
 		return err
 	}
 
 	// Step 4: Collect errors from the graph. These are the collective set of
 	// errors which will be observed after the new changes are applied.
-	errorsAfterChanges, err := collectErrors(graph)
+	issuesAfterChanges, err := collectIssues(graph)
 	if err != nil {
 		return err
 	}
@@ -182,38 +223,17 @@ func (o *analyzeOptions) Run() error {
 	// or revert them to their state before creation. The resulting graph should
 	// represent a state which currently exists in the server (before applying
 	// the newer changes.)
-	for info, existingObject := range existingObjects {
-		gknn := common.GKNN{
-			Group:     info.Mapping.GroupVersionKind.Group,
-			Kind:      info.Mapping.GroupVersionKind.Kind,
-			Namespace: info.Namespace,
-			Name:      info.Name,
-		}
-		if existingObject == nil {
-			// This means the object would have been newly created, and thus we
-			// need to delete it to revert the graph back to it's original
-			// state.
-			graph.DeleteNodeUsingGKNN(gknn)
-		} else if !graph.HasNode(gknn) {
-			node := graph.Nodes[gknn.GroupKind()][gknn.NamespacedName()]
-			node.Object = existingObject // Revert object back to it's original state which exists in the server.
-		}
+	if err := revertChanges(graph, existingObjects); err != nil { //nolint:govet
+		return err
 	}
 
 	// Step 6: Build new graph by running extensions
-	policyManager = policymanager.New(common.NewDefaultGroupKindFetcher(o.factory))
+	policyManager = policymanager.New(serverFetcher)
 	if err := policyManager.Init(); err != nil { //nolint:govet
 		return err
 	}
 	// Execute extensions.
-	err = extension.ExecuteAll(graph,
-		directlyattachedpolicy.NewExtension(policyManager),
-		gatewayeffectivepolicy.NewExtension(),
-		refgrantvalidator.NewExtension(
-			refgrantvalidator.NewDefaultReferenceGrantFetcher(o.factory),
-		),
-		notfoundrefvalidator.NewExtension(),
-	)
+	err = extension.ExecuteAll(graph, extension.DefaultExtensions(serverFetcher, policyManager)...)
 	if err != nil {
 		return err
 	}
@@ -221,16 +241,26 @@ func (o *analyzeOptions) Run() error {
 	// Step 6: Collect errors from the graph. These are the collective set of
 	// errors which will be observed in the server before the new changes are
 	// applied.
-	errorsBeforeChanges, err := collectErrors(graph)
+	issuesBeforeChanges, err := collectIssues(graph)
 	if err != nil {
 		return err
 	}
 
 	// Step 7: Report analysis
 
+	created, updated := generateSummary(existingObjects)
+	newIssues, fixedIssues, unchangedIssues := classifyErrors(issueKeys(issuesBeforeChanges), issueKeys(issuesAfterChanges))
+
+	report := newAnalysisReport(created, updated, newIssues, fixedIssues, unchangedIssues, issuesBeforeChanges, issuesAfterChanges)
+	if o.outputFormat != "" {
+		if err := printReport(o.Out, report, o.outputFormat); err != nil { //nolint:govet
+			return err
+		}
+		return checkIssues(o.failOn, o.failOnSeverity, report)
+	}
+
 	fmt.Fprintf(o.Out, "Summary:\n")
 	fmt.Fprintf(o.Out, "\n")
-	created, updated := generateSummary(existingObjects)
 	for _, info := range created {
 		fmt.Fprintf(o.Out, "\t- Created %v", info.ObjectName())
 		if info.Namespaced() {
@@ -247,8 +277,6 @@ func (o *analyzeOptions) Run() error {
 	}
 	fmt.Fprintf(o.Out, "\n")
 
-	newIssues, fixedIssues, unchangedIssues := classifyErrors(errorsBeforeChanges, errorsAfterChanges)
-
 	fmt.Fprintf(o.Out, "Potential Issues Introduced\n")
 	fmt.Fprintf(o.Out, "(These issues will arise after applying the changes in the analyzed file.):\n")
 	fmt.Fprintf(o.Out, "\n")
@@ -282,25 +310,244 @@ func (o *analyzeOptions) Run() error {
 	}
 	fmt.Fprintf(o.Out, "\n")
 
+	return checkIssues(o.failOn, o.failOnSeverity, report)
+}
+
+const (
+	// failOnNew fails when the changes introduce new issues.
+	failOnNew = "new"
+	// failOnAny fails when any issues exist after the changes, irrespective of
+	// whether they are new or not.
+	failOnAny = "any"
+	// failOnNone never fails because of issues.
+	failOnNone = "none"
+
+	// exitCodeNewIssues is used when the changes introduce new issues.
+	exitCodeNewIssues = 2
+	// exitCodeExistingIssues is used when the changes do not introduce new
+	// issues, but issues which existed before the changes remain.
+	exitCodeExistingIssues = 3
+)
+
+// issuesFoundError is returned when issues are found which should make the
+// command fail, as per --fail-on.
+type issuesFoundError struct {
+	exitCode int
+	message  string
+}
+
+func (e *issuesFoundError) Error() string {
+	return e.message
+}
+
+// checkIssues returns an issuesFoundError if the report contains issues of at
+// least the given severity which should fail the command as per failOn.
+func checkIssues(failOn string, minSeverity common.Severity, report *analysisReport) error {
+	if failOn == failOnNone {
+		return nil
+	}
+	countIssues := func(issues []analysisIssue) int {
+		var count int
+		for _, issue := range issues {
+			if issue.Severity.AtLeast(minSeverity) {
+				count++
+			}
+		}
+		return count
+	}
+
+	if count := countIssues(report.NewIssues); count != 0 {
+		return &issuesFoundError{
+			exitCode: exitCodeNewIssues,
+			message:  fmt.Sprintf("found %v new issue(s) with severity %v or higher", count, minSeverity),
+		}
+	}
+	if failOn != failOnAny {
+		return nil
+	}
+	if count := countIssues(report.UnchangedIssues); count != 0 {
+		return &issuesFoundError{
+			exitCode: exitCodeExistingIssues,
+			message:  fmt.Sprintf("found %v existing issue(s) with severity %v or higher", count, minSeverity),
+		}
+	}
 	return nil
 }
 
-func collectErrors(graph *topology.Graph) (map[string]bool, error) {
-	errors := map[string]bool{}
+// collectIssues returns the issues reported by the extensions for all nodes in
+// the graph, keyed by a human readable description of the issue.
+func collectIssues(graph *topology.Graph) (map[string]analysisIssue, error) {
+	issues := map[string]analysisIssue{}
 	for i := range graph.Nodes {
 		for j := range graph.Nodes[i] {
 			node := graph.Nodes[i][j]
-			aggregateAnalysisErrors, err := extensionutils.AggregateAnalysisErrors(node)
+			analysisErrors, err := extensionutils.CollectAnalysisErrors(node)
 			if err != nil {
 				return nil, err
 			}
-			for _, err := range aggregateAnalysisErrors {
-				s := fmt.Sprintf("%v: %v", node.GKNN(), err)
-				errors[s] = true
+			for _, analysisErr := range analysisErrors {
+				s := fmt.Sprintf("%v: %v", node.GKNN(), analysisErr.Err)
+				issues[s] = newAnalysisIssue(node.GKNN(), analysisErr)
 			}
 		}
 	}
-	return errors, nil
+	return issues, nil
+}
+
+// policyTargets returns the objects targeted by the policies among the
+// sources, excluding those which are sources themselves. Targets which do not
+// exist are skipped.
+func policyTargets(fetcher common.GroupKindFetcher, policyManager *policymanager.PolicyManager, sources []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	isSource := make(map[common.GKNN]bool)
+	for _, source := range sources {
+		isSource[common.GKNNFromUnstructured(source)] = true
+	}
+
+	var result []*unstructured.Unstructured
+	for _, policy := range policyManager.GetPolicies() {
+		if !isSource[policy.GKNN()] {
+			continue
+		}
+		for _, targetRef := range policy.TargetRefs {
+			if isSource[targetRef] {
+				continue
+			}
+			resources, err := common.FetchInNamespace(fetcher, targetRef.GroupKind(), targetRef.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			for _, resource := range resources {
+				gknn := common.GKNNFromUnstructured(resource)
+				if gknn.Name != targetRef.Name || (gknn.Namespace != targetRef.Namespace && gknn.Namespace != "") {
+					continue
+				}
+				result = append(result, resource)
+				isSource[targetRef] = true
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func issueKeys(issues map[string]analysisIssue) map[string]bool {
+	result := make(map[string]bool, len(issues))
+	for s := range issues {
+		result[s] = true
+	}
+	return result
+}
+
+// revertChanges reverts the graph to the state which exists in the server, by
+// deleting the nodes of objects which would be newly created and reverting the
+// objects which would be updated.
+func revertChanges(graph *topology.Graph, existingObjects map[*resource.Info]*unstructured.Unstructured) error {
+	var gateways []common.GKNN
+	for info, existingObject := range existingObjects {
+		gknn := common.GKNN{
+			Group:     info.Mapping.GroupVersionKind.Group,
+			Kind:      info.Mapping.GroupVersionKind.Kind,
+			Namespace: info.Namespace,
+			Name:      info.Name,
+		}
+		if gknn.GroupKind() == common.GatewayGK {
+			gateways = append(gateways, gknn)
+		}
+		if existingObject == nil {
+			// This means the object would have been newly created, and thus we
+			// need to delete it to revert the graph back to it's original
+			// state.
+			graph.DeleteNodeUsingGKNN(gknn)
+		} else if graph.HasNode(gknn) {
+			node := graph.Nodes[gknn.GroupKind()][gknn.NamespacedName()]
+			node.Object = existingObject // Revert object back to it's original state which exists in the server.
+		}
+	}
+
+	if !hasListenerRelations(graph) {
+		return nil
+	}
+	// Listeners are derived from the Gateway, so they need to be rebuilt from
+	// the reverted Gateways. The routes attached to the listeners depend on
+	// both the Gateways and the routes, so they are reconnected afterwards.
+	for _, gatewayGKNN := range gateways {
+		if err := revertListeners(graph, gatewayGKNN); err != nil {
+			return err
+		}
+	}
+	reconnectListeners(graph)
+	return nil
+}
+
+// revertListeners replaces the Listener nodes of the Gateway with the
+// listeners of the Gateway in the graph, which has already been reverted to
+// its existing state. If the Gateway does not exist, its Listener nodes are
+// deleted.
+func revertListeners(graph *topology.Graph, gatewayGKNN common.GKNN) error {
+	depths := map[string]int{}
+	for nn, listenerNode := range graph.Nodes[common.ListenerGK] {
+		gatewayName, _ := common.SplitListenerName(nn.Name)
+		if nn.Namespace != gatewayGKNN.Namespace || gatewayName != gatewayGKNN.Name {
+			continue
+		}
+		depths[nn.Name] = listenerNode.Depth
+		graph.DeleteNode(listenerNode)
+	}
+
+	if !graph.HasNode(gatewayGKNN) {
+		return nil
+	}
+	gatewayNode := graph.Nodes[common.GatewayGK][gatewayGKNN.NamespacedName()]
+	listeners, err := common.ListenersFromGateway(gatewayNode.Object)
+	if err != nil {
+		return err
+	}
+	for _, listener := range listeners {
+		depth, ok := depths[listener.GetName()]
+		if !ok {
+			depth = gatewayNode.Depth + 1
+		}
+		listenerNode := &topology.Node{Object: listener, Depth: depth}
+		graph.AddNode(listenerNode)
+		for _, relation := range graph.Relations {
+			if relation.From == common.ListenerGK {
+				connect(graph, listenerNode, relation)
+			}
+		}
+	}
+	return nil
+}
+
+// reconnectListeners recomputes the edges of all the relations to Listeners,
+// such that they reflect the current objects in the graph.
+func reconnectListeners(graph *topology.Graph) {
+	for _, relation := range graph.Relations {
+		if relation.To != common.ListenerGK {
+			continue
+		}
+		for _, fromNode := range graph.Nodes[relation.From] {
+			for _, toNode := range fromNode.OutNeighbors[relation] {
+				graph.RemoveEdge(fromNode, toNode, relation)
+			}
+			connect(graph, fromNode, relation)
+		}
+	}
+}
+
+// connect adds the edges of the relation from the node to all its neighbors
+// which exist in the graph.
+func connect(graph *topology.Graph, fromNode *topology.Node, relation *topology.Relation) {
+	for _, toNodeGKNN := range relation.Neighbors(fromNode.Object, graph) {
+		if toNode := graph.Nodes[toNodeGKNN.GroupKind()][toNodeGKNN.NamespacedName()]; toNode != nil {
+			graph.AddEdge(fromNode, toNode, relation)
+		}
+	}
+}
+
+func hasListenerRelations(graph *topology.Graph) bool {
+	return slices.ContainsFunc(graph.Relations, func(relation *topology.Relation) bool {
+		return relation.From == common.ListenerGK || relation.To == common.ListenerGK
+	})
 }
 
 func generateSummary(objects map[*resource.Info]*unstructured.Unstructured) (created, updated []*resource.Info) {
@@ -17,9 +17,25 @@ limitations under the License.
 package analyze
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	extensionutils "sigs.k8s.io/gwctl/pkg/extension/utils"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func TestClassifyErrors(t *testing.T) {
@@ -82,3 +98,271 @@ func TestClassifyErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestRevertChanges(t *testing.T) {
+	gatewayInfo := &resource.Info{
+		Namespace: "default",
+		Name:      "gateway-1",
+		Mapping:   &meta.RESTMapping{GroupVersionKind: common.GatewayGK.WithVersion("v1")},
+	}
+	routeInfo := &resource.Info{
+		Namespace: "default",
+		Name:      "route-3",
+		Mapping:   &meta.RESTMapping{GroupVersionKind: common.HTTPRouteGK.WithVersion("v1")},
+	}
+
+	newGateway := mustToUnstructured(t, &gatewayv1.Gateway{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "gateway.networking.k8s.io/v1", Kind: "Gateway"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway-1"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "gatewayclass-1",
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+				{Name: "http-alt", Protocol: gatewayv1.HTTPProtocolType, Port: 8081},
+			},
+		},
+	})
+	existingGateway := mustToUnstructured(t, &gatewayv1.Gateway{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "gateway.networking.k8s.io/v1", Kind: "Gateway"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway-1"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "gatewayclass-1",
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 8080},
+				{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443},
+			},
+		},
+	})
+	// The changes remove the https listener and add the http-alt listener.
+	// route-1 is attached to the https listener, and route-2 is attached to all
+	// listeners of the Gateway.
+	route1 := newHTTPRoute(t, "route-1", ptr.To[gatewayv1.SectionName]("https"))
+	route2 := newHTTPRoute(t, "route-2", nil)
+	route3 := newHTTPRoute(t, "route-3", nil)
+
+	listeners, err := common.ListenersFromGateway(newGateway)
+	if err != nil {
+		t.Fatalf("ListenersFromGateway() failed: %v", err)
+	}
+	fetcher := &fakeGroupKindFetcher{data: map[schema.GroupKind][]*unstructured.Unstructured{
+		common.GatewayGK:   {newGateway},
+		common.ListenerGK:  listeners,
+		common.HTTPRouteGK: {route1, route2, route3},
+	}}
+	graph, err := topology.NewBuilder(fetcher).
+		StartFrom([]*unstructured.Unstructured{newGateway, route3}).
+		UseRelationships(topologygw.DefaultRegistry.Relations()).
+		WithMaxDepth(4).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	existingObjects := map[*resource.Info]*unstructured.Unstructured{
+		gatewayInfo: existingGateway,
+		routeInfo:   nil,
+	}
+	if err := revertChanges(graph, existingObjects); err != nil {
+		t.Fatalf("revertChanges() failed: %v", err)
+	}
+
+	gatewayGKNN := common.GKNN{Group: common.GatewayGK.Group, Kind: common.GatewayGK.Kind, Namespace: "default", Name: "gateway-1"}
+	if !graph.HasNode(gatewayGKNN) {
+		t.Fatalf("graph.HasNode(%v) = false, want true", gatewayGKNN)
+	}
+	gatewayNode := graph.Nodes[common.GatewayGK][gatewayGKNN.NamespacedName()]
+	assert.Equal(t, existingGateway, gatewayNode.Object, "Gateway should be reverted to the existing object")
+
+	routeGKNN := common.GKNN{Group: common.HTTPRouteGK.Group, Kind: common.HTTPRouteGK.Kind, Namespace: "default", Name: "route-3"}
+	assert.False(t, graph.HasNode(routeGKNN), "created HTTPRoute should be deleted")
+
+	gatewayNN := gatewayGKNN.NamespacedName()
+	httpGKNN := common.ListenerGKNN(gatewayNN, "http")
+	if !graph.HasNode(httpGKNN) {
+		t.Fatalf("graph.HasNode(%v) = false, want true", httpGKNN)
+	}
+	httpNode := graph.Nodes[common.ListenerGK][httpGKNN.NamespacedName()]
+	port, _, _ := unstructured.NestedInt64(httpNode.Object.Object, "spec", "port")
+	assert.Equal(t, int64(8080), port, "listener should be reverted to the existing listener")
+	assert.Contains(t, httpNode.OutNeighbors[topologygw.ListenerParentGatewayRelation], gatewayGKNN, "listener should be connected to its Gateway")
+	assert.False(t, graph.HasNode(common.ListenerGKNN(gatewayNN, "http-alt")), "listener which does not exist should be deleted")
+	httpsGKNN := common.ListenerGKNN(gatewayNN, "https")
+	assert.True(t, graph.HasNode(httpsGKNN), "listener which exists should be restored")
+
+	wantListeners := map[string][]common.GKNN{
+		"route-1": {httpsGKNN},
+		"route-2": {httpGKNN, httpsGKNN},
+	}
+	for name, want := range wantListeners {
+		routeNode := graph.Nodes[common.HTTPRouteGK][types.NamespacedName{Namespace: "default", Name: name}]
+		var got []common.GKNN
+		for gknn := range routeNode.OutNeighbors[topologygw.HTTPRouteParentListenersRelation] {
+			got = append(got, gknn)
+		}
+		assert.ElementsMatch(t, want, got, "listeners of %v", name)
+	}
+}
+
+func newHTTPRoute(t *testing.T, name string, sectionName *gatewayv1.SectionName) *unstructured.Unstructured {
+	t.Helper()
+	return mustToUnstructured(t, &gatewayv1.HTTPRoute{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "gateway.networking.k8s.io/v1", Kind: "HTTPRoute"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "gateway-1", SectionName: sectionName}},
+			},
+		},
+	})
+}
+
+func mustToUnstructured(t *testing.T, obj any) *unstructured.Unstructured {
+	t.Helper()
+	o, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatalf("Failed to convert %T to unstructured: %v", obj, err)
+	}
+	return &unstructured.Unstructured{Object: o}
+}
+
+func TestNewAnalysisIssue(t *testing.T) {
+	route := common.GKNN{Group: common.HTTPRouteGK.Group, Kind: common.HTTPRouteGK.Kind, Namespace: "ns1", Name: "route-1"}
+	service := common.GKNN{Kind: common.ServiceGK.Kind, Namespace: "ns2", Name: "svc-1"}
+
+	tests := []struct {
+		name        string
+		analysisErr extensionutils.AnalysisError
+		want        analysisIssue
+	}{
+		{
+			name: "reference error",
+			analysisErr: extensionutils.AnalysisError{
+				Extension: "refgrantvalidator",
+				Err: common.ReferenceNotPermittedError{
+					ReferenceFromTo: common.ReferenceFromTo{ReferringObject: route, ReferredObject: service},
+				},
+			},
+			want: analysisIssue{
+				Object:          objectReference{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "ns1", Name: "route-1"},
+				Type:            "ReferenceNotPermitted",
+				Severity:        common.SeverityError,
+				ReferringObject: &objectReference{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "ns1", Name: "route-1"},
+				ReferredObject:  &objectReference{Kind: "Service", Namespace: "ns2", Name: "svc-1"},
+				Extension:       "refgrantvalidator",
+				Message:         `HTTPRoute(.gateway.networking.k8s.io) "ns1/route-1" is not permitted to reference Service "ns2/svc-1"`,
+			},
+		},
+		{
+			name: "error without reference",
+			analysisErr: extensionutils.AnalysisError{
+				Extension: "someextension",
+				Err:       errors.New("something went wrong"),
+			},
+			want: analysisIssue{
+				Object:    objectReference{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "ns1", Name: "route-1"},
+				Type:      "Unknown",
+				Severity:  common.SeverityError,
+				Extension: "someextension",
+				Message:   "something went wrong",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newAnalysisIssue(route, tc.analysisErr)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCheckIssues(t *testing.T) {
+	errorIssue := analysisIssue{Type: "ReferenceNotPermitted", Severity: common.SeverityError}
+	warningIssue := analysisIssue{Type: "SomeWarning", Severity: common.SeverityWarning}
+
+	tests := []struct {
+		name         string
+		failOn       string
+		minSeverity  common.Severity
+		report       *analysisReport
+		wantExitCode int // Zero means no error is expected.
+	}{
+		{
+			name:        "none never fails",
+			failOn:      failOnNone,
+			minSeverity: common.SeverityInfo,
+			report: &analysisReport{
+				NewIssues:       []analysisIssue{errorIssue},
+				UnchangedIssues: []analysisIssue{errorIssue},
+			},
+		},
+		{
+			name:         "new fails on new issues",
+			failOn:       failOnNew,
+			minSeverity:  common.SeverityError,
+			report:       &analysisReport{NewIssues: []analysisIssue{errorIssue}},
+			wantExitCode: exitCodeNewIssues,
+		},
+		{
+			name:        "new ignores existing issues",
+			failOn:      failOnNew,
+			minSeverity: common.SeverityError,
+			report:      &analysisReport{UnchangedIssues: []analysisIssue{errorIssue}},
+		},
+		{
+			name:         "any fails on existing issues",
+			failOn:       failOnAny,
+			minSeverity:  common.SeverityError,
+			report:       &analysisReport{UnchangedIssues: []analysisIssue{errorIssue}},
+			wantExitCode: exitCodeExistingIssues,
+		},
+		{
+			name:        "any ignores fixed issues",
+			failOn:      failOnAny,
+			minSeverity: common.SeverityError,
+			report:      &analysisReport{FixedIssues: []analysisIssue{errorIssue}},
+		},
+		{
+			name:         "new issues take precedence over existing issues",
+			failOn:       failOnAny,
+			minSeverity:  common.SeverityError,
+			report:       &analysisReport{NewIssues: []analysisIssue{errorIssue}, UnchangedIssues: []analysisIssue{errorIssue}},
+			wantExitCode: exitCodeNewIssues,
+		},
+		{
+			name:        "issues below the severity are ignored",
+			failOn:      failOnAny,
+			minSeverity: common.SeverityError,
+			report:      &analysisReport{NewIssues: []analysisIssue{warningIssue}, UnchangedIssues: []analysisIssue{warningIssue}},
+		},
+		{
+			name:         "issues above the severity are considered",
+			failOn:       failOnNew,
+			minSeverity:  common.SeverityInfo,
+			report:       &analysisReport{NewIssues: []analysisIssue{warningIssue}},
+			wantExitCode: exitCodeNewIssues,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkIssues(tc.failOn, tc.minSeverity, tc.report)
+			if tc.wantExitCode == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var issuesErr *issuesFoundError
+			if assert.ErrorAs(t, err, &issuesErr) {
+				assert.Equal(t, tc.wantExitCode, issuesErr.exitCode)
+			}
+		})
+	}
+}
+
+type fakeGroupKindFetcher struct {
+	data map[schema.GroupKind][]*unstructured.Unstructured
+}
+
+func (f *fakeGroupKindFetcher) Fetch(gk schema.GroupKind) ([]*unstructured.Unstructured, error) {
+	return f.data[gk], nil
+}
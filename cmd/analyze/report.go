@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyze
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"reflect"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/resource"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	extensionutils "sigs.k8s.io/gwctl/pkg/extension/utils"
+)
+
+const (
+	outputFormatJSON = "json"
+	outputFormatYAML = "yaml"
+)
+
+// analysisReport is the result of the analysis in a machine readable format.
+type analysisReport struct {
+	// Created contains the objects which will be newly created.
+	Created []objectReference `json:"created"`
+	// Updated contains the objects which already exist and will be updated.
+	Updated []objectReference `json:"updated"`
+
+	// NewIssues will arise after applying the changes.
+	NewIssues []analysisIssue `json:"newIssues"`
+	// FixedIssues exist before the changes but will be resolved after
+	// applying them.
+	FixedIssues []analysisIssue `json:"fixedIssues"`
+	// UnchangedIssues exist before the changes and will remain after applying
+	// them.
+	UnchangedIssues []analysisIssue `json:"unchangedIssues"`
+}
+
+// newAnalysisReport builds the report from the created and updated objects, and
+// the keys of the classified issues.
+func newAnalysisReport(created, updated []*resource.Info, newIssues, fixedIssues, unchangedIssues []string, issuesBeforeChanges, issuesAfterChanges map[string]analysisIssue) *analysisReport {
+	report := &analysisReport{
+		Created:         make([]objectReference, 0, len(created)),
+		Updated:         make([]objectReference, 0, len(updated)),
+		NewIssues:       make([]analysisIssue, 0, len(newIssues)),
+		FixedIssues:     make([]analysisIssue, 0, len(fixedIssues)),
+		UnchangedIssues: make([]analysisIssue, 0, len(unchangedIssues)),
+	}
+	for _, info := range created {
+		report.Created = append(report.Created, objectReferenceFromInfo(info))
+	}
+	for _, info := range updated {
+		report.Updated = append(report.Updated, objectReferenceFromInfo(info))
+	}
+	for _, s := range newIssues {
+		report.NewIssues = append(report.NewIssues, issuesAfterChanges[s])
+	}
+	for _, s := range fixedIssues {
+		report.FixedIssues = append(report.FixedIssues, issuesBeforeChanges[s])
+	}
+	for _, s := range unchangedIssues {
+		report.UnchangedIssues = append(report.UnchangedIssues, issuesAfterChanges[s])
+	}
+	return report
+}
+
+type objectReference struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func objectReferenceFromGKNN(gknn common.GKNN) objectReference {
+	return objectReference{Group: gknn.Group, Kind: gknn.Kind, Namespace: gknn.Namespace, Name: gknn.Name}
+}
+
+func objectReferenceFromInfo(info *resource.Info) objectReference {
+	ref := objectReference{
+		Group: info.Mapping.GroupVersionKind.Group,
+		Kind:  info.Mapping.GroupVersionKind.Kind,
+		Name:  info.Name,
+	}
+	if info.Namespaced() {
+		ref.Namespace = info.Namespace
+	}
+	return ref
+}
+
+// analysisIssue is a single issue found on an object in the graph.
+type analysisIssue struct {
+	// Object is the object for which the issue was reported.
+	Object objectReference `json:"object"`
+	// Type is the type of the issue, like ReferenceNotPermitted.
+	Type string `json:"type"`
+	// Severity is one of error, warning or info.
+	Severity common.Severity `json:"severity"`
+	// ReferringObject and ReferredObject are set for issues which are caused by
+	// a reference from one object to another.
+	ReferringObject *objectReference `json:"referringObject,omitempty"`
+	ReferredObject  *objectReference `json:"referredObject,omitempty"`
+	// Extension is the name of the extension which reported the issue.
+	Extension string `json:"extension"`
+	// Message is the human readable description of the issue.
+	Message string `json:"message"`
+}
+
+func newAnalysisIssue(gknn common.GKNN, analysisErr extensionutils.AnalysisError) analysisIssue {
+	issue := analysisIssue{
+		Object:    objectReferenceFromGKNN(gknn),
+		Type:      issueType(analysisErr.Err),
+		Severity:  analysisErr.Severity(),
+		Extension: analysisErr.Extension,
+		Message:   analysisErr.Err.Error(),
+	}
+	if refErr, ok := analysisErr.Err.(common.ReferenceError); ok {
+		referringObject := objectReferenceFromGKNN(refErr.Reference().ReferringObject)
+		referredObject := objectReferenceFromGKNN(refErr.Reference().ReferredObject)
+		issue.ReferringObject, issue.ReferredObject = &referringObject, &referredObject
+	}
+	return issue
+}
+
+// issueType returns the type of the error without the "Error" suffix, for
+// example ReferenceNotPermitted for a common.ReferenceNotPermittedError. Errors
+// which are not of an exported type are reported as Unknown.
+func issueType(err error) string {
+	t := reflect.TypeOf(err)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if !token.IsExported(t.Name()) {
+		return "Unknown"
+	}
+	return strings.TrimSuffix(t.Name(), "Error")
+}
+
+func printReport(w io.Writer, report *analysisReport, outputFormat string) error {
+	var b []byte
+	var err error
+	switch outputFormat {
+	case outputFormatJSON:
+		b, err = json.MarshalIndent(report, "", "  ")
+		b = append(b, '\n')
+	case outputFormatYAML:
+		b, err = yaml.Marshal(report)
+	default:
+		return fmt.Errorf("unknown output format %q", outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explainpolicy
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/extension"
+	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
+	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
+	"sigs.k8s.io/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+)
+
+func NewCmd(factory common.Factory, iostreams genericiooptions.IOStreams) *cobra.Command {
+	flags := &explainPolicyFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "explain-policy TYPE/[NAMESPACE/]NAME[/RULE]",
+		Short: "Explain the effective policies of a Gateway, Route or Backend",
+		Long: `Explain the effective policies of a Gateway, Route or Backend, or of a named rule within a Route.
+
+For each Gateway through which the target is reached, and each type of policy, this shows the chain of inheritable policies which are merged to calculate the effective policy (ordered from the GatewayClass down to the target), the effective policy, and the policy which sets each of its fields.`,
+		Example: `  # Explain the effective policies of a Gateway in the current namespace
+  gwctl explain-policy gateway/my-gateway
+
+  # Explain the effective policies of a rule of an HTTPRoute in JSON format
+  gwctl explain-policy httproute/ns1/my-route/my-rule -o json`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			o, err := flags.ToOptions(args, factory, iostreams)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v", err)
+				os.Exit(1)
+			}
+
+			if err := o.Run(); err != nil {
+				fmt.Fprintf(os.Stderr, "%v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.outputFormat, "output", "o", "", fmt.Sprintf("Output format. Must be one of: %v", strings.Join([]string{outputFormatJSON, outputFormatYAML}, ",")))
+	return cmd
+}
+
+// explainPolicyFlags contains the flags used with explain-policy command.
+type explainPolicyFlags struct {
+	outputFormat string
+}
+
+func (f *explainPolicyFlags) ToOptions(args []string, factory common.Factory, iostreams genericiooptions.IOStreams) (*explainPolicyOptions, error) {
+	switch f.outputFormat {
+	case "", outputFormatJSON, outputFormatYAML:
+	default:
+		return nil, fmt.Errorf("invalid output format %q, must be one of: %v", f.outputFormat, strings.Join([]string{outputFormatJSON, outputFormatYAML}, ","))
+	}
+
+	namespace, _, err := factory.KubeConfigNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	o := &explainPolicyOptions{
+		factory:      factory,
+		namespace:    namespace,
+		outputFormat: f.outputFormat,
+		IOStreams:    iostreams,
+	}
+	o.resourceType, o.namespace, o.name, o.ruleName, err = parseTarget(args[0], namespace)
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// parseTarget parses a target of the form TYPE/[NAMESPACE/]NAME[/RULE]. The
+// namespace defaults to defaultNamespace.
+func parseTarget(target, defaultNamespace string) (resourceType, namespace, name, ruleName string, err error) {
+	parts := strings.Split(target, "/")
+	if slices.Contains(parts, "") {
+		return "", "", "", "", fmt.Errorf("invalid target %q, must be of the form TYPE/[NAMESPACE/]NAME[/RULE]", target)
+	}
+	switch len(parts) {
+	case 2:
+		return parts[0], defaultNamespace, parts[1], "", nil
+	case 3:
+		return parts[0], parts[1], parts[2], "", nil
+	case 4:
+		return parts[0], parts[1], parts[2], parts[3], nil
+	default:
+		return "", "", "", "", fmt.Errorf("invalid target %q, must be of the form TYPE/[NAMESPACE/]NAME[/RULE]", target)
+	}
+}
+
+type explainPolicyOptions struct {
+	factory common.Factory
+
+	resourceType string
+	namespace    string
+	name         string
+	// ruleName is the name of the rule within the Route, if the target is a
+	// rule.
+	ruleName string
+
+	// outputFormat is empty for the human readable output.
+	outputFormat string
+
+	genericclioptions.IOStreams
+}
+
+func (o *explainPolicyOptions) Run() error {
+	infos, err := o.factory.NewBuilder().
+		Unstructured().
+		Flatten().
+		NamespaceParam(o.namespace).DefaultNamespace().
+		ResourceTypeOrNameArgs(true, o.resourceType, o.name).
+		Do().
+		Infos()
+	if err != nil {
+		return err
+	}
+	if len(infos) != 1 {
+		return fmt.Errorf("failed to find resource %v/%v", o.resourceType, o.name)
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(infos[0].Object)
+	if err != nil {
+		return err
+	}
+	target := &unstructured.Unstructured{Object: obj}
+
+	gk := target.GroupVersionKind().GroupKind()
+	if gk != common.GatewayGK && !topologygw.IsRoute(gk) && !topologygw.IsBackend(gk) {
+		return fmt.Errorf("effective policies are only calculated for Gateways, Routes and Backends, not %v", gk.Kind)
+	}
+	if o.ruleName != "" {
+		if !topologygw.IsRoute(gk) {
+			return fmt.Errorf("a rule can only be specified for Routes, not %v", gk.Kind)
+		}
+		if !hasRule(target, o.ruleName) {
+			return fmt.Errorf("%v %v/%v has no rule named %q", gk.Kind, target.GetNamespace(), target.GetName(), o.ruleName)
+		}
+	}
+
+	cache := common.NewFetchCache()
+	defer cache.LogMetrics()
+	fetcher := common.NewDefaultGroupKindFetcher(o.factory, common.WithCache(cache))
+
+	policyManager := policymanager.New(fetcher)
+	if err := policyManager.Init(); err != nil { //nolint:govet
+		return err
+	}
+
+	graph, err := topology.NewBuilder(fetcher).
+		StartFrom([]*unstructured.Unstructured{target}).
+		UseRelationships(topologygw.DefaultRegistry.Relations()).
+		Build()
+	if err != nil {
+		return err
+	}
+	err = extension.ExecuteAll(graph,
+		directlyattachedpolicy.NewExtension(policyManager),
+		gatewayeffectivepolicy.NewExtension(),
+	)
+	if err != nil {
+		return err
+	}
+
+	explanation, err := newPolicyExplanation(graph.Sources[0], o.ruleName)
+	if err != nil {
+		return err
+	}
+	if o.outputFormat != "" {
+		return printExplanation(o.Out, explanation, o.outputFormat)
+	}
+	return describeExplanation(o.Out, explanation)
+}
+
+// hasRule returns true if the Route has a rule with the given name.
+func hasRule(route *unstructured.Unstructured, ruleName string) bool {
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]any)
+		if !ok {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(ruleMap, "name"); name == ruleName {
+			return true
+		}
+	}
+	return false
+}
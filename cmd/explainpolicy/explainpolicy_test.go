@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explainpolicy
+
+import (
+	"testing"
+)
+
+func TestParseTarget(t *testing.T) {
+	testCases := []struct {
+		target        string
+		wantType      string
+		wantNamespace string
+		wantName      string
+		wantRule      string
+		wantErr       bool
+	}{
+		{target: "gateway/gw-1", wantType: "gateway", wantNamespace: "default", wantName: "gw-1"},
+		{target: "httproute/ns1/route-1", wantType: "httproute", wantNamespace: "ns1", wantName: "route-1"},
+		{target: "httproute/ns1/route-1/rule-a", wantType: "httproute", wantNamespace: "ns1", wantName: "route-1", wantRule: "rule-a"},
+		{target: "gateway", wantErr: true},
+		{target: "gateway//gw-1", wantErr: true},
+		{target: "httproute/ns1/route-1/rule-a/extra", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.target, func(t *testing.T) {
+			gotType, gotNamespace, gotName, gotRule, err := parseTarget(tc.target, "default")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseTarget(%q) error = %v, wantErr %v", tc.target, err, tc.wantErr)
+			}
+			if gotType != tc.wantType || gotNamespace != tc.wantNamespace || gotName != tc.wantName || gotRule != tc.wantRule {
+				t.Errorf("parseTarget(%q) = (%q, %q, %q, %q), want (%q, %q, %q, %q)", tc.target, gotType, gotNamespace, gotName, gotRule, tc.wantType, tc.wantNamespace, tc.wantName, tc.wantRule)
+			}
+		})
+	}
+}
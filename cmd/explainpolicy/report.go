@@ -0,0 +1,338 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explainpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
+	"sigs.k8s.io/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gwctl/pkg/printer"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+)
+
+const (
+	outputFormatJSON = "json"
+	outputFormatYAML = "yaml"
+)
+
+// policyExplanation explains how the effective policies of a target are
+// calculated.
+type policyExplanation struct {
+	Target objectReference `json:"target"`
+	// Rule is the name of the rule within the target Route, if any.
+	Rule string `json:"rule,omitempty"`
+	// Gateways contains the effective policies of the target for each Gateway
+	// through which it is reached. For a Gateway, this only contains the Gateway
+	// itself.
+	Gateways []gatewayPolicies `json:"gateways"`
+}
+
+type gatewayPolicies struct {
+	Gateway  objectReference   `json:"gateway"`
+	Policies []effectivePolicy `json:"policies"`
+}
+
+// effectivePolicy is the effective policy of a single type.
+type effectivePolicy struct {
+	// Type identifies the CRD of the policy, like TimeoutPolicy.foo.com.
+	Type string `json:"type"`
+	// Chain contains the policies which are merged to calculate the effective
+	// policy, ordered from the top of the hierarchy down to the target.
+	Chain []chainLink `json:"chain"`
+	// Spec is the effective spec resulting from the merge.
+	Spec map[string]any `json:"spec"`
+	// Fields contains the source of each field within Spec.
+	Fields []fieldSource `json:"fields"`
+}
+
+type chainLink struct {
+	Policy objectReference `json:"policy"`
+	// Target is the object to which the policy is attached, which determines
+	// its place in the hierarchy.
+	Target   objectReference `json:"target"`
+	Default  map[string]any  `json:"default,omitempty"`
+	Override map[string]any  `json:"override,omitempty"`
+}
+
+type fieldSource struct {
+	Field  string          `json:"field"`
+	Value  any             `json:"value"`
+	Policy objectReference `json:"policy"`
+	// Level is the Kind of the objects targeted by the policy.
+	Level string `json:"level"`
+	// From is either default or override.
+	From string `json:"from"`
+}
+
+type objectReference struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func objectReferenceFromGKNN(gknn common.GKNN) objectReference {
+	return objectReference{Group: gknn.Group, Kind: gknn.Kind, Namespace: gknn.Namespace, Name: gknn.Name}
+}
+
+func (r objectReference) String() string {
+	if r.Namespace == "" {
+		return r.Name
+	}
+	return r.Namespace + "/" + r.Name
+}
+
+// newPolicyExplanation explains the effective policies of the node, or of the
+// rule with the given name if the node is a Route. The gatewayeffectivepolicy
+// extension must have been executed on the graph.
+func newPolicyExplanation(node *topology.Node, ruleName string) (*policyExplanation, error) {
+	chains, err := gatewayeffectivepolicy.PolicyChain(node, ruleName)
+	if err != nil {
+		return nil, err
+	}
+	effectivePolicies, err := effectivePoliciesOf(node, ruleName)
+	if err != nil {
+		return nil, err
+	}
+
+	gateways := slices.Collect(maps.Keys(chains))
+	for gateway := range effectivePolicies {
+		if !slices.Contains(gateways, gateway) {
+			gateways = append(gateways, gateway)
+		}
+	}
+	slices.SortFunc(gateways, func(a, b common.GKNN) int { return strings.Compare(a.String(), b.String()) })
+
+	result := &policyExplanation{
+		Target:   objectReferenceFromGKNN(node.GKNN()),
+		Rule:     ruleName,
+		Gateways: make([]gatewayPolicies, 0, len(gateways)),
+	}
+	for _, gateway := range gateways {
+		chainsByType := make(map[policymanager.PolicyCrdID][]gatewayeffectivepolicy.PolicyChainLink)
+		for _, link := range chains[gateway] {
+			chainsByType[link.Policy.PolicyCrdID()] = append(chainsByType[link.Policy.PolicyCrdID()], link)
+		}
+		policyTypes := slices.Collect(maps.Keys(chainsByType))
+		for policyCrdID := range effectivePolicies[gateway] {
+			if !slices.Contains(policyTypes, policyCrdID) {
+				policyTypes = append(policyTypes, policyCrdID)
+			}
+		}
+		slices.Sort(policyTypes)
+
+		entry := gatewayPolicies{
+			Gateway:  objectReferenceFromGKNN(gateway),
+			Policies: make([]effectivePolicy, 0, len(policyTypes)),
+		}
+		for _, policyCrdID := range policyTypes {
+			policy, err := newEffectivePolicy(policyCrdID, chainsByType[policyCrdID], effectivePolicies[gateway][policyCrdID])
+			if err != nil {
+				return nil, err
+			}
+			entry.Policies = append(entry.Policies, policy)
+		}
+		result.Gateways = append(result.Gateways, entry)
+	}
+	return result, nil
+}
+
+// effectivePoliciesOf returns the effective policies of the node, partitioned
+// by Gateway.
+func effectivePoliciesOf(node *topology.Node, ruleName string) (map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy, error) {
+	gk := node.GKNN().GroupKind()
+	if topologygw.IsRoute(gk) && ruleName != "" {
+		return gatewayeffectivepolicy.RouteRuleEffectivePolicies(node, ruleName)
+	}
+
+	metadata, err := gatewayeffectivepolicy.Access(node)
+	if err != nil || metadata == nil {
+		return nil, err
+	}
+	switch {
+	case gk == common.GatewayGK:
+		return map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy{node.GKNN(): metadata.GatewayEffectivePolicies}, nil
+	case topologygw.IsRoute(gk):
+		return metadata.RouteEffectivePolicies, nil
+	default:
+		return metadata.BackendEffectivePolicies, nil
+	}
+}
+
+func newEffectivePolicy(policyCrdID policymanager.PolicyCrdID, chain []gatewayeffectivepolicy.PolicyChainLink, policy *policymanager.Policy) (effectivePolicy, error) {
+	result := effectivePolicy{
+		Type:   string(policyCrdID),
+		Chain:  make([]chainLink, 0, len(chain)),
+		Fields: make([]fieldSource, 0),
+	}
+	for _, link := range chain {
+		spec := link.Policy.Spec()
+		defaultSpec, _ := spec["default"].(map[string]any)
+		overrideSpec, _ := spec["override"].(map[string]any)
+		result.Chain = append(result.Chain, chainLink{
+			Policy:   objectReferenceFromGKNN(link.Policy.GKNN()),
+			Target:   objectReferenceFromGKNN(link.Target),
+			Default:  defaultSpec,
+			Override: overrideSpec,
+		})
+	}
+	if policy == nil {
+		return result, nil
+	}
+
+	spec, err := policy.EffectiveSpec()
+	if err != nil {
+		return effectivePolicy{}, err
+	}
+	result.Spec = spec
+	fieldSources := policy.FieldSources()
+	for _, field := range slices.Sorted(maps.Keys(fieldSources)) {
+		source := fieldSources[field]
+		value, _, err := unstructured.NestedFieldNoCopy(spec, strings.Split(field, ".")...)
+		if err != nil {
+			return effectivePolicy{}, err
+		}
+		from := "default"
+		if source.Override {
+			from = "override"
+		}
+		result.Fields = append(result.Fields, fieldSource{
+			Field:  field,
+			Value:  value,
+			Policy: objectReferenceFromGKNN(source.Policy),
+			Level:  source.Level,
+			From:   from,
+		})
+	}
+	return result, nil
+}
+
+func printExplanation(w io.Writer, explanation *policyExplanation, outputFormat string) error {
+	var b []byte
+	var err error
+	switch outputFormat {
+	case outputFormatJSON:
+		b, err = json.MarshalIndent(explanation, "", "  ")
+		b = append(b, '\n')
+	case outputFormatYAML:
+		b, err = yaml.Marshal(explanation)
+	default:
+		return fmt.Errorf("unknown output format %q", outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// describeExplanation writes the explanation in a human readable format.
+func describeExplanation(w io.Writer, explanation *policyExplanation) error {
+	target := fmt.Sprintf("%v %v", explanation.Target.Kind, explanation.Target)
+	if explanation.Rule != "" {
+		target = fmt.Sprintf("%v (rule %v)", target, explanation.Rule)
+	}
+	fmt.Fprintf(w, "Target: %v\n", target)
+	if len(explanation.Gateways) == 0 {
+		fmt.Fprintf(w, "Gateways: <none>\n")
+		return nil
+	}
+
+	for _, gateway := range explanation.Gateways {
+		fmt.Fprintf(w, "Gateway: %v\n", gateway.Gateway)
+		if len(gateway.Policies) == 0 {
+			fmt.Fprintf(w, "  Policies: <none>\n")
+			continue
+		}
+		for _, policy := range gateway.Policies {
+			fmt.Fprintf(w, "  %v:\n", policy.Type)
+
+			chainTable := &printer.Table{
+				ColumnNames:  []string{"Level", "Target", "Policy", "Default", "Override"},
+				UseSeparator: true,
+			}
+			for _, link := range policy.Chain {
+				chainTable.Rows = append(chainTable.Rows, []string{
+					link.Target.Kind,          // Level
+					link.Target.String(),      // Target
+					link.Policy.String(),      // Policy
+					specOutput(link.Default),  // Default
+					specOutput(link.Override), // Override
+				})
+			}
+			fmt.Fprintf(w, "    Chain:\n")
+			if err := chainTable.Write(w, 6); err != nil {
+				return err
+			}
+
+			if len(policy.Fields) == 0 {
+				fmt.Fprintf(w, "    Fields: <none>\n")
+				continue
+			}
+			fieldsTable := &printer.Table{
+				ColumnNames:  []string{"Field", "Value", "Policy", "Level", "From"},
+				UseSeparator: true,
+			}
+			for _, field := range policy.Fields {
+				fieldsTable.Rows = append(fieldsTable.Rows, []string{
+					field.Field,             // Field
+					specOutput(field.Value), // Value
+					field.Policy.String(),   // Policy
+					field.Level,             // Level
+					field.From,              // From
+				})
+			}
+			fmt.Fprintf(w, "    Fields:\n")
+			if err := fieldsTable.Write(w, 6); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// specOutput formats a value within the spec of a policy for the human
+// readable output. Lists and objects are formatted as JSON.
+func specOutput(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case map[string]any:
+		if v == nil {
+			return ""
+		}
+	case []any:
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
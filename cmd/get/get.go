@@ -18,6 +18,7 @@ package get
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -30,10 +31,7 @@ import (
 
 	"sigs.k8s.io/gwctl/pkg/common"
 	"sigs.k8s.io/gwctl/pkg/extension"
-	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
 	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
-	"sigs.k8s.io/gwctl/pkg/extension/notfoundrefvalidator"
-	"sigs.k8s.io/gwctl/pkg/extension/refgrantvalidator"
 	gwctlflags "sigs.k8s.io/gwctl/pkg/flags"
 	"sigs.k8s.io/gwctl/pkg/policymanager"
 	"sigs.k8s.io/gwctl/pkg/printer"
@@ -53,14 +51,18 @@ func NewCmd(factory common.Factory, iostreams genericiooptions.IOStreams, isDesc
 		Use:   fmt.Sprintf("%v TYPE [RESOURCE_NAME]", cmdName),
 		Short: "Display one or many resources",
 		Args:  cobra.RangeArgs(1, 2),
-		Run: func(_ *cobra.Command, args []string) {
+		Run: func(cmd *cobra.Command, args []string) {
 			o, err := flags.ToOptions(args, factory, iostreams, isDescribe)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%v", err)
 				os.Exit(1)
 			}
 
-			err = o.Run(args)
+			if o.watch {
+				err = o.Watch(cmd.Context(), args)
+			} else {
+				err = o.Run(args)
+			}
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "%v", err)
 				os.Exit(1)
@@ -70,11 +72,24 @@ func NewCmd(factory common.Factory, iostreams genericiooptions.IOStreams, isDesc
 
 	flags.resourceBuilderFlags.AddFlags(cmd.Flags())
 
+	watchUsage := "After printing the requested objects, watch for changes to them or to any related object, and print them again whenever they change"
+	if isDescribe {
+		cmd.Flags().BoolVar(&flags.watch, "watch", false, watchUsage)
+	} else {
+		cmd.Flags().BoolVarP(&flags.watch, "watch", "w", false, watchUsage)
+	}
+
 	if !isDescribe {
 		printableAllowedFormats := strings.Join(printer.AllowedOutputFormatsForHelp(), ",")
 		cmd.Flags().StringVarP(&flags.outputFormat, "output", "o", "", fmt.Sprintf("Output format. Must be one of: %v", printableAllowedFormats))
 
 		flags.forFlag.AddFlag(cmd.Flags())
+
+		cmd.Flags().BoolVar(&flags.showPolicyInheritance, "show-policy-inheritance", false, "When used with a graph output format, also draw dashed edges from inherited policies to the resources on which they take effect")
+	} else {
+		cmd.Flags().StringVarP(&flags.outputFormat, "output", "o", "", fmt.Sprintf("Output format. Must be one of: %v", printer.OutputFormatJSON))
+
+		cmd.Flags().BoolVar(&flags.routingTable, "routing-table", false, "Also show the HTTPRoute rules of Gateways in the order in which they are evaluated, grouped by listener and hostname")
 	}
 
 	return cmd
@@ -85,6 +100,10 @@ type getFlags struct {
 	resourceBuilderFlags *genericclioptions.ResourceBuilderFlags
 	outputFormat         string
 	forFlag              gwctlflags.ForFlag
+	watch                bool
+
+	showPolicyInheritance bool
+	routingTable          bool
 }
 
 func newGetFlags() *getFlags {
@@ -105,6 +124,10 @@ func (f *getFlags) ToOptions(args []string, factory common.Factory, iostreams ge
 		IOStreams:     iostreams,
 		allNamespaces: *f.resourceBuilderFlags.AllNamespaces,
 		labelSelector: *f.resourceBuilderFlags.LabelSelector,
+		watch:         f.watch,
+
+		showPolicyInheritance: f.showPolicyInheritance,
+		routingTable:          f.routingTable,
 	}
 
 	var err error
@@ -123,6 +146,14 @@ func (f *getFlags) ToOptions(args []string, factory common.Factory, iostreams ge
 	if err != nil {
 		return nil, err
 	}
+	if isDescribe && o.output != printer.OutputFormatTable && o.output != printer.OutputFormatJSON {
+		return nil, fmt.Errorf("unsupported format %v provided for describe, must be one of: %v", o.output, printer.OutputFormatJSON)
+	}
+
+	o.forObjRef, err = f.forFlag.ToOption()
+	if err != nil {
+		return nil, err
+	}
 
 	return o, nil
 }
@@ -141,21 +172,54 @@ type getOptions struct {
 	hasPolicy     bool
 	hasPolicyCRD  bool
 
+	// forObjRef is the object specified through the --for flag. A zero value
+	// means that no filtering needs to happen.
+	forObjRef common.GKNN
+
+	// showPolicyInheritance draws edges from inherited policies to the objects
+	// on which they take effect in the graph output.
+	showPolicyInheritance bool
+
+	// routingTable includes the routing table of Gateways in their
+	// descriptions.
+	routingTable bool
+
+	// watch keeps printing the objects whenever they, or objects related to
+	// them, change.
+	watch bool
+
 	genericclioptions.IOStreams
 }
 
 func (o *getOptions) Run(args []string) error {
-	needsExtensions := o.isDescribe || o.output == printer.OutputFormatWide || o.output == printer.OutputFormatGraph
+	needsExtensions := o.isDescribe || o.output == printer.OutputFormatWide || printer.IsGraphOutputFormat(o.output)
+
+	// All resources are fetched through a shared cache, so that each
+	// GroupKind is listed at most once.
+	cache := common.NewFetchCache()
+	defer cache.LogMetrics()
+	fetcher := common.NewDefaultGroupKindFetcher(o.factory, common.WithCache(cache))
 
 	// Initialize PolicyManager if needed (by either non-policy path extensions or policy path)
 	var pm *policymanager.PolicyManager
 	if o.hasPolicy || o.hasPolicyCRD || needsExtensions {
-		pm = policymanager.New(common.NewDefaultGroupKindFetcher(o.factory))
+		pm = policymanager.New(fetcher)
 		if err := pm.Init(); err != nil {
 			return err
 		}
 	}
 
+	// If the --for flag is specified, find all objects related to the
+	// referenced object. Only these objects will be shown in the output.
+	var relatedObjects map[common.GKNN]*topology.Node
+	if o.forObjRef != (common.GKNN{}) {
+		var err error
+		relatedObjects, err = o.findRelatedObjects(fetcher)
+		if err != nil {
+			return err
+		}
+	}
+
 	var allNodes []*topology.Node
 
 	// Process non-policy resource types through k8s resource builder
@@ -183,12 +247,16 @@ func (o *getOptions) Run(args []string) error {
 			if err != nil {
 				return err
 			}
-			sources = append(sources, &unstructured.Unstructured{Object: obj})
+			u := &unstructured.Unstructured{Object: obj}
+			if relatedObjects != nil && relatedObjects[common.GKNNFromUnstructured(u)] == nil {
+				continue
+			}
+			sources = append(sources, u)
 		}
 
-		builder := topology.NewBuilder(common.NewDefaultGroupKindFetcher(o.factory)).StartFrom(sources)
+		builder := topology.NewBuilder(fetcher).StartFrom(sources)
 		if needsExtensions {
-			builder = builder.UseRelationships(topologygw.AllRelations)
+			builder = builder.UseRelationships(topologygw.DefaultRegistry.Relations())
 		}
 		graph, err := builder.Build()
 		if err != nil {
@@ -196,27 +264,20 @@ func (o *getOptions) Run(args []string) error {
 		}
 
 		if needsExtensions {
-			err := extension.ExecuteAll(graph, //nolint:govet
-				directlyattachedpolicy.NewExtension(pm),
-				gatewayeffectivepolicy.NewExtension(),
-				refgrantvalidator.NewExtension(refgrantvalidator.NewDefaultReferenceGrantFetcher(o.factory)),
-				notfoundrefvalidator.NewExtension(),
-			)
-			if err != nil {
+			if err := o.executeExtensions(graph, pm, fetcher); err != nil { //nolint:govet
 				return err
 			}
 		}
 
-		if o.output == printer.OutputFormatGraph {
-			if o.hasPolicy || o.hasPolicyCRD {
-				fmt.Fprintf(o.ErrOut, "Warning: policy types are not shown in graph output\n")
-			}
-			toDotGraph, err := topologygw.ToDot(graph)
-			if err != nil {
-				return err
+		if printer.IsGraphOutputFormat(o.output) {
+			renderOptions := topologygw.RenderOptions{Policies: pm.GetPolicies()}
+			if o.showPolicyInheritance {
+				renderOptions.InheritedPolicyEffects, err = gatewayeffectivepolicy.InheritedPolicyEffects(graph)
+				if err != nil {
+					return err
+				}
 			}
-			fmt.Fprintf(o.Out, "%v\n", toDotGraph)
-			return nil
+			return o.printGraph(graph, renderOptions)
 		}
 
 		allNodes = append(allNodes, graph.Sources...)
@@ -224,22 +285,41 @@ func (o *getOptions) Run(args []string) error {
 
 	// Process policy types through PolicyManager
 	if o.hasPolicy || o.hasPolicyCRD {
-		nodes, err := o.collectPolicyNodes(pm, args)
+		nodes, err := o.collectPolicyNodes(pm, args, relatedObjects)
 		if err != nil {
 			return err
 		}
 		allNodes = append(allNodes, nodes...)
 	}
 
-	return o.printNodes(allNodes)
+	return o.printNodes(allNodes, o.Out)
+}
+
+// executeExtensions runs the extensions whose results are shown by the
+// printers.
+func (o *getOptions) executeExtensions(graph *topology.Graph, pm *policymanager.PolicyManager, fetcher common.GroupKindFetcher) error {
+	return extension.ExecuteAll(graph, extension.DefaultExtensions(fetcher, pm)...)
 }
 
-func (o *getOptions) collectPolicyNodes(pm *policymanager.PolicyManager, args []string) ([]*topology.Node, error) {
+// collectPolicyNodes returns the policies and policy CRDs which need to be
+// printed. If relatedObjects is non-nil, only policies targeting one of the
+// related objects (and CRDs of such policies) are returned.
+func (o *getOptions) collectPolicyNodes(pm *policymanager.PolicyManager, args []string, relatedObjects map[common.GKNN]*topology.Node) ([]*topology.Node, error) {
+	// relatedPolicyCRDs contains the IDs of CRDs for policies which target some
+	// related object.
+	relatedPolicyCRDs := map[policymanager.PolicyCrdID]bool{}
+	for _, policy := range pm.GetPolicies() {
+		if isPolicyRelated(policy, relatedObjects) {
+			relatedPolicyCRDs[policy.PolicyCrdID()] = true
+		}
+	}
+
 	nodes := []*topology.Node{}
 	if o.hasPolicy {
 		for _, policy := range pm.GetPolicies() {
 			shouldSkip := (!o.allNamespaces && o.namespace != policy.GKNN().Namespace) ||
-				(len(args) == 2 && args[1] != policy.GKNN().Name)
+				(len(args) == 2 && args[1] != policy.GKNN().Name) ||
+				!isPolicyRelated(policy, relatedObjects)
 			if shouldSkip {
 				continue
 			}
@@ -248,7 +328,8 @@ func (o *getOptions) collectPolicyNodes(pm *policymanager.PolicyManager, args []
 	}
 	if o.hasPolicyCRD {
 		for _, policyCRD := range pm.GetCRDs() {
-			shouldSkip := len(args) == 2 && (args[1] != policyCRD.CRD.GetName())
+			shouldSkip := (len(args) == 2 && (args[1] != policyCRD.CRD.GetName())) ||
+				(relatedObjects != nil && !relatedPolicyCRDs[policyCRD.ID()])
 			if shouldSkip {
 				continue
 			}
@@ -262,18 +343,97 @@ func (o *getOptions) collectPolicyNodes(pm *policymanager.PolicyManager, args []
 	return nodes, nil
 }
 
-func (o *getOptions) printNodes(nodes []*topology.Node) error {
+// findRelatedObjects builds a graph starting from the object referenced in the
+// --for flag, and returns all objects related to it.
+func (o *getOptions) findRelatedObjects(fetcher common.GroupKindFetcher) (map[common.GKNN]*topology.Node, error) {
+	forObj, err := o.fetchForObject()
+	if err != nil {
+		return nil, err
+	}
+	graph, err := buildForGraph(fetcher, forObj)
+	if err != nil {
+		return nil, err
+	}
+	return graph.RelatedNodes(graph.Sources[0]), nil
+}
+
+// fetchForObject returns the object referenced in the --for flag.
+func (o *getOptions) fetchForObject() (*unstructured.Unstructured, error) {
+	infos, err := o.factory.NewBuilder().
+		Unstructured().
+		Flatten().
+		NamespaceParam(o.forObjRef.Namespace).
+		ResourceTypeOrNameArgs(true, fmt.Sprintf("%v.%v", o.forObjRef.Kind, o.forObjRef.Group), o.forObjRef.Name).
+		Do().
+		Infos()
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) != 1 {
+		return nil, fmt.Errorf("failed to find resource referenced in --for flag: %v", o.forObjRef)
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(infos[0].Object)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// buildForGraph builds the graph of the object referenced in the --for flag,
+// in which the objects related to it are found.
+func buildForGraph(fetcher common.GroupKindFetcher, forObj *unstructured.Unstructured) (*topology.Graph, error) {
+	return topology.NewBuilder(fetcher).
+		StartFrom([]*unstructured.Unstructured{forObj}).
+		UseRelationships(topologygw.DefaultRegistry.Relations()).
+		Build()
+}
+
+// isPolicyRelated returns true if the policy targets any of the related
+// objects. A nil relatedObjects means no filtering is required.
+func isPolicyRelated(policy *policymanager.Policy, relatedObjects map[common.GKNN]*topology.Node) bool {
+	if relatedObjects == nil {
+		return true
+	}
+	for gknn := range relatedObjects {
+		if policy.IsAttachedTo(gknn) {
+			return true
+		}
+	}
+	return false
+}
+
+// printGraph renders the graph in the graph output format.
+func (o *getOptions) printGraph(graph *topology.Graph, renderOptions topologygw.RenderOptions) error {
+	render := topologygw.ToDot
+	switch o.output {
+	case printer.OutputFormatMermaid:
+		render = topologygw.ToMermaid
+	case printer.OutputFormatD2:
+		render = topologygw.ToD2
+	case printer.OutputFormatGraphJSON:
+		render = topologygw.ToGraphJSON
+	}
+	output, err := render(graph, renderOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "%v\n", output)
+	return nil
+}
+
+func (o *getOptions) printNodes(nodes []*topology.Node, w io.Writer) error {
 	printerOptions := printer.PrinterOptions{
 		OutputFormat:  o.output,
 		Clock:         clock.RealClock{},
 		Description:   o.isDescribe,
 		EventFetcher:  printer.NewDefaultEventFetcher(o.factory),
 		AllNamespaces: o.allNamespaces,
+		RoutingTable:  o.routingTable,
 	}
 	p := printer.NewPrinter(printerOptions)
-	defer p.Flush(o.Out)
+	defer p.Flush(w)
 	for _, node := range topology.SortedNodes(nodes) {
-		err := p.PrintNode(node, o.Out)
+		err := p.PrintNode(node, w)
 		if err != nil {
 			return err
 		}
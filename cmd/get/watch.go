@@ -0,0 +1,632 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
+	extensionutils "sigs.k8s.io/gwctl/pkg/extension/utils"
+	"sigs.k8s.io/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gwctl/pkg/printer"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+)
+
+var crdGK = schema.GroupKind{Group: apiextensionsv1.GroupName, Kind: "CustomResourceDefinition"}
+
+// watchState is the state of a watched object which is reported in JSON watch
+// events.
+type watchState struct {
+	Object   map[string]any `json:"object"`
+	Policies []common.GKNN  `json:"policies,omitempty"`
+	Analysis []string       `json:"analysis,omitempty"`
+}
+
+// watchEvent is printed for each change to the state of a watched object when
+// watching with the JSON output format.
+type watchEvent struct {
+	Type   watch.EventType `json:"type"`
+	Object common.GKNN     `json:"object"`
+	// Causes contains the changed objects which caused the event. This
+	// includes the watched object itself if it changed.
+	Causes []common.GKNN `json:"causes,omitempty"`
+	// State is the state of an added object.
+	State *watchState `json:"state,omitempty"`
+	// Patch is a JSON merge patch from the previous state of a modified object
+	// to its current state.
+	Patch json.RawMessage `json:"patch,omitempty"`
+}
+
+// watchedObject is the latest view of an object matching the query. Each
+// object is the only source of its own graph, so that its view can be
+// recomputed independently of the other objects.
+type watchedObject struct {
+	graph *topology.Graph
+	state *watchState
+	// description is the last description printed for the object.
+	description string
+}
+
+func (w *watchedObject) node() *topology.Node {
+	return w.graph.Sources[0]
+}
+
+// watcher keeps the views of the objects matching the query up to date with
+// the changes observed by the informers.
+type watcher struct {
+	*getOptions
+
+	fetcher       *common.InformerGroupKindFetcher
+	groupKinds    []schema.GroupKind
+	name          string
+	labelSelector labels.Selector
+	// forObject is the object in the --for flag. forGraph is its graph, and
+	// relatedObjects restricts the watched objects to those related to it.
+	// Both are computed again whenever a change affects forGraph.
+	forObject      common.GKNN
+	forGraph       *topology.Graph
+	relatedObjects map[common.GKNN]*topology.Node
+
+	mu      sync.Mutex
+	pending []common.ObjectChange
+	wakeup  chan struct{}
+	errs    chan error
+
+	pm      *policymanager.PolicyManager
+	objects map[common.GKNN]*watchedObject
+	// lastTable is the last table printed when not printing descriptions or
+	// JSON events.
+	lastTable string
+	// printedDescriptions is true if some description has been printed, in
+	// which case the next one needs to be separated from it.
+	printedDescriptions bool
+}
+
+// Watch prints the objects like Run, and then keeps printing them whenever
+// they, or any object related to them, change until ctx is cancelled. Objects
+// are read through informers, and after each change only the views of the
+// objects whose graphs are affected by the change are recomputed.
+//
+// Tables are printed again in their entirety, descriptions are printed again
+// for the objects whose description changed, and the JSON output format prints
+// a stream of events containing JSON merge patches of the state of the objects.
+func (o *getOptions) Watch(ctx context.Context, args []string) error {
+	if o.hasPolicy || o.hasPolicyCRD {
+		return fmt.Errorf("watching policies or policy CRDs is not supported")
+	}
+	if o.output == printer.OutputFormatYAML || printer.IsGraphOutputFormat(o.output) {
+		return fmt.Errorf("output format %q is not supported when watching", o.output)
+	}
+
+	w := &watcher{
+		getOptions: o,
+		wakeup:     make(chan struct{}, 1),
+		errs:       make(chan error, 1),
+		objects:    make(map[common.GKNN]*watchedObject),
+	}
+
+	var err error
+	w.groupKinds, w.name, err = o.resolveGroupKinds(args)
+	if err != nil {
+		return err
+	}
+	w.labelSelector, err = labels.Parse(o.labelSelector)
+	if err != nil {
+		return err
+	}
+	if o.forObjRef != (common.GKNN{}) {
+		forObj, err := o.fetchForObject()
+		if err != nil {
+			return err
+		}
+		w.forObject = common.GKNNFromUnstructured(forObj)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w.fetcher = common.NewInformerGroupKindFetcher(ctx, o.factory, w.enqueue, w.watchError)
+
+	// Errors caused by the cancellation of ctx only mean that the watch
+	// should stop.
+	if err := w.run(ctx); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// run prints the objects initially and after each batch of changes.
+func (w *watcher) run(ctx context.Context) error {
+	if err := w.refreshPolicies(); err != nil {
+		return err
+	}
+	if w.forObject != (common.GKNN{}) {
+		if err := w.refreshRelatedObjects(); err != nil {
+			return err
+		}
+	}
+	current, err := w.currentObjects()
+	if err != nil {
+		return err
+	}
+	var events []watchEvent
+	for _, gknn := range sortedGKNNs(current) {
+		obj, err := w.compute(current[gknn])
+		if err != nil {
+			return err
+		}
+		w.objects[gknn] = obj
+		events = append(events, watchEvent{Type: watch.Added, Object: gknn, State: obj.state})
+	}
+	if err := w.print(events, true); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-w.errs:
+			return err
+		case <-w.wakeup:
+			w.mu.Lock()
+			changes := w.pending
+			w.pending = nil
+			w.mu.Unlock()
+
+			if err := w.handle(changes); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// resolveGroupKinds returns the GroupKinds of the resource types in the
+// arguments, and the name of the object if one was specified.
+func (o *getOptions) resolveGroupKinds(args []string) ([]schema.GroupKind, string, error) {
+	restMapper, err := o.factory.ToRESTMapper()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var name string
+	if len(args) == 2 {
+		name = args[1]
+	}
+	var result []schema.GroupKind
+	for _, resourceType := range o.resourceTypes {
+		if t, n, ok := strings.Cut(resourceType, "/"); ok {
+			resourceType, name = t, n
+		}
+		gk, err := groupKindFor(restMapper, resourceType)
+		if err != nil {
+			return nil, "", err
+		}
+		if !slices.Contains(result, gk) {
+			result = append(result, gk)
+		}
+	}
+	return result, name, nil
+}
+
+// groupKindFor resolves a resource type argument, the same way as the resource
+// builder does.
+func groupKindFor(restMapper meta.RESTMapper, resourceArg string) (schema.GroupKind, error) {
+	fullySpecifiedGVR, groupResource := schema.ParseResourceArg(strings.ToLower(resourceArg))
+	if fullySpecifiedGVR != nil {
+		if gvk, err := restMapper.KindFor(*fullySpecifiedGVR); err == nil {
+			return gvk.GroupKind(), nil
+		}
+	}
+	if gvk, err := restMapper.KindFor(groupResource.WithVersion("")); err == nil {
+		return gvk.GroupKind(), nil
+	}
+
+	_, groupKind := schema.ParseKindArg(resourceArg)
+	if _, err := restMapper.RESTMapping(groupKind); err == nil {
+		return groupKind, nil
+	}
+	return schema.GroupKind{}, fmt.Errorf("the server doesn't have a resource type %q", resourceArg)
+}
+
+func (w *watcher) enqueue(change common.ObjectChange) {
+	w.mu.Lock()
+	w.pending = append(w.pending, change)
+	w.mu.Unlock()
+
+	select {
+	case w.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// watchError stops the watch for errors which will not go away by retrying.
+// Other errors are retried by the informers.
+func (w *watcher) watchError(gk schema.GroupKind, err error) {
+	if !apierrors.IsMethodNotSupported(err) && !apierrors.IsForbidden(err) && !apierrors.IsUnauthorized(err) {
+		klog.V(1).InfoS("Failed to watch, retrying", "groupKind", gk, "err", err)
+		return
+	}
+	select {
+	case w.errs <- fmt.Errorf("failed to watch %v: %w", gk, err):
+	default:
+	}
+}
+
+// matches returns true if the object matches the query.
+func (w *watcher) matches(u *unstructured.Unstructured) bool {
+	gknn := common.GKNNFromUnstructured(u)
+	switch {
+	case !slices.Contains(w.groupKinds, gknn.GroupKind()):
+		return false
+	case w.name != "" && gknn.Name != w.name:
+		return false
+	case !w.allNamespaces && gknn.Namespace != "" && gknn.Namespace != w.namespace:
+		return false
+	case !w.labelSelector.Matches(labels.Set(u.GetLabels())):
+		return false
+	case w.relatedObjects != nil && w.relatedObjects[gknn] == nil:
+		return false
+	}
+	return true
+}
+
+// currentObjects returns the objects which currently match the query.
+func (w *watcher) currentObjects() (map[common.GKNN]*unstructured.Unstructured, error) {
+	result := make(map[common.GKNN]*unstructured.Unstructured)
+	for _, gk := range w.groupKinds {
+		objects, err := w.fetcher.Fetch(gk)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range objects {
+			if w.matches(u) {
+				result[common.GKNNFromUnstructured(u)] = u
+			}
+		}
+	}
+	return result, nil
+}
+
+// refreshRelatedObjects builds the graph of the latest state of the object in
+// the --for flag, and finds the objects related to it.
+func (w *watcher) refreshRelatedObjects() error {
+	objects, err := w.fetcher.Fetch(w.forObject.GroupKind())
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(objects, func(u *unstructured.Unstructured) bool {
+		return common.GKNNFromUnstructured(u) == w.forObject
+	})
+	if i < 0 {
+		// No object is related to a deleted object. The previous graph is kept
+		// so that the object being created again is noticed.
+		w.relatedObjects = map[common.GKNN]*topology.Node{}
+		return nil
+	}
+	graph, err := buildForGraph(w.fetcher, objects[i])
+	if err != nil {
+		return err
+	}
+	w.forGraph = graph
+	w.relatedObjects = graph.RelatedNodes(graph.Sources[0])
+	return nil
+}
+
+func (w *watcher) refreshPolicies() error {
+	pm := policymanager.New(w.fetcher)
+	if err := pm.Init(); err != nil {
+		return err
+	}
+	w.pm = pm
+	return nil
+}
+
+// compute builds the graph for the object and runs the extensions on it.
+func (w *watcher) compute(u *unstructured.Unstructured) (*watchedObject, error) {
+	graph, err := topology.NewBuilder(w.fetcher).
+		StartFrom([]*unstructured.Unstructured{u}).
+		UseRelationships(topologygw.DefaultRegistry.Relations()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.executeExtensions(graph, w.pm, w.fetcher); err != nil {
+		return nil, err
+	}
+
+	obj := &watchedObject{graph: graph, state: &watchState{Object: u.DeepCopy().Object}}
+	unstructured.RemoveNestedField(obj.state.Object, "metadata", "managedFields")
+	policies, err := directlyattachedpolicy.Access(obj.node())
+	if err != nil {
+		return nil, err
+	}
+	obj.state.Policies = sortedGKNNs(policies)
+	analysisErrors, err := extensionutils.AggregateAnalysisErrors(obj.node())
+	if err != nil {
+		return nil, err
+	}
+	for _, analysisErr := range analysisErrors {
+		obj.state.Analysis = append(obj.state.Analysis, analysisErr.Error())
+	}
+	return obj, nil
+}
+
+// handle recomputes the views of the objects affected by the changes, and
+// prints the ones which changed.
+func (w *watcher) handle(changes []common.ObjectChange) error {
+	oldPM := w.pm
+	if err := w.refreshPolicies(); err != nil {
+		return err
+	}
+
+	causes := make(map[common.GKNN][]common.GKNN)
+	addCause := func(gknn, cause common.GKNN) {
+		if !slices.Contains(causes[gknn], cause) {
+			causes[gknn] = append(causes[gknn], cause)
+		}
+	}
+
+	// Objects may become related or unrelated to the object in the --for flag
+	// without changing themselves, for example when a route attaches to a
+	// Gateway, so their views are recomputed too.
+	var forCauses []common.GKNN
+	for _, change := range changes {
+		if w.forGraph != nil && w.affects(change, w.forGraph, oldPM) {
+			forCauses = append(forCauses, common.GKNNFromUnstructured(change.Object))
+		}
+	}
+	if len(forCauses) != 0 {
+		oldRelatedObjects := w.relatedObjects
+		if err := w.refreshRelatedObjects(); err != nil {
+			return err
+		}
+		for gknn, node := range w.relatedObjects {
+			if oldRelatedObjects[gknn] == nil && w.matches(node.Object) {
+				for _, cause := range forCauses {
+					addCause(gknn, cause)
+				}
+			}
+		}
+		for gknn := range oldRelatedObjects {
+			if w.relatedObjects[gknn] == nil && w.objects[gknn] != nil {
+				for _, cause := range forCauses {
+					addCause(gknn, cause)
+				}
+			}
+		}
+	}
+
+	for _, change := range changes {
+		changed := common.GKNNFromUnstructured(change.Object)
+		if w.matches(change.Object) || w.objects[changed] != nil {
+			addCause(changed, changed)
+		}
+		for gknn, obj := range w.objects {
+			if w.affects(change, obj.graph, oldPM) {
+				addCause(gknn, changed)
+			}
+		}
+	}
+	if len(causes) == 0 {
+		return nil
+	}
+	klog.V(3).InfoS("Recomputing watched objects", "changes", len(changes), "affected", len(causes), "total", len(w.objects))
+
+	current, err := w.currentObjects()
+	if err != nil {
+		return err
+	}
+	var events []watchEvent
+	for _, gknn := range sortedGKNNs(causes) {
+		event := watchEvent{Object: gknn, Causes: causes[gknn]}
+		slices.SortFunc(event.Causes, compareGKNNs)
+
+		oldObj := w.objects[gknn]
+		u, ok := current[gknn]
+		if !ok {
+			if oldObj != nil {
+				delete(w.objects, gknn)
+				event.Type = watch.Deleted
+				events = append(events, event)
+			}
+			continue
+		}
+
+		obj, err := w.compute(u)
+		if err != nil {
+			return err
+		}
+		w.objects[gknn] = obj
+		if oldObj == nil {
+			event.Type, event.State = watch.Added, obj.state
+			events = append(events, event)
+			continue
+		}
+
+		obj.description = oldObj.description
+		patch, err := mergePatch(oldObj.state, obj.state)
+		if err != nil {
+			return err
+		}
+		event.Type, event.Patch = watch.Modified, patch
+		events = append(events, event)
+	}
+	return w.print(events, false)
+}
+
+// affects returns true if the change can affect the view of an object whose
+// graph is the given graph, ie. if the changed object is part of the graph,
+// relates to some object in the graph, or is a policy attached to some object
+// in the graph.
+func (w *watcher) affects(change common.ObjectChange, graph *topology.Graph, oldPM *policymanager.PolicyManager) bool {
+	for _, u := range []*unstructured.Unstructured{change.Object, change.OldObject} {
+		if u == nil {
+			continue
+		}
+		gknn := common.GKNNFromUnstructured(u)
+		gk := gknn.GroupKind()
+		if gk == crdGK || graph.HasNode(gknn) {
+			return true
+		}
+
+		for _, relation := range topologygw.DefaultRegistry.Relations() {
+			if relation.From == gk {
+				for _, neighbor := range relation.Neighbors(u, graph) {
+					if graph.HasNode(neighbor) {
+						return true
+					}
+				}
+			}
+			if relation.To == gk {
+				for _, node := range graph.Nodes[relation.From] {
+					if slices.Contains(relation.Neighbors(node.Object, graph), gknn) {
+						return true
+					}
+				}
+			}
+		}
+
+		for _, pm := range []*policymanager.PolicyManager{oldPM, w.pm} {
+			if policyAttachedToGraph(pm, u, graph) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// policyAttachedToGraph returns true if the object is a policy known to the
+// PolicyManager which is attached to some object in the graph.
+func policyAttachedToGraph(pm *policymanager.PolicyManager, u *unstructured.Unstructured, graph *topology.Graph) bool {
+	gk := u.GroupVersionKind().GroupKind()
+	for _, policyCRD := range pm.GetCRDs() {
+		if policyCRD.CRD.Spec.Group != gk.Group || policyCRD.CRD.Spec.Names.Kind != gk.Kind {
+			continue
+		}
+		policy, err := policymanager.ConstructPolicy(u, policyCRD.IsInheritable())
+		if err != nil {
+			klog.V(3).InfoS("Failed to construct policy", "policy", common.GKNNFromUnstructured(u), "err", err)
+			return false
+		}
+		for _, nodes := range graph.Nodes {
+			for _, node := range nodes {
+				if policy.IsAttachedTo(node.GKNN()) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// print prints the events in the JSON output format, or the views of the
+// objects in the other output formats.
+func (w *watcher) print(events []watchEvent, initial bool) error {
+	switch {
+	case w.output == printer.OutputFormatJSON:
+		for _, event := range events {
+			if event.Type == watch.Modified && (len(event.Patch) == 0 || string(event.Patch) == "{}") {
+				continue
+			}
+			b, err := json.MarshalIndent(event, "", "    ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w.Out, "%s\n", b)
+		}
+
+	case w.isDescribe:
+		for _, event := range events {
+			obj := w.objects[event.Object]
+			if event.Type == watch.Deleted || obj == nil {
+				continue
+			}
+			out := &bytes.Buffer{}
+			if err := w.printNodes([]*topology.Node{obj.node()}, out); err != nil {
+				return err
+			}
+			if out.String() == obj.description {
+				continue
+			}
+			obj.description = out.String()
+			if w.printedDescriptions {
+				fmt.Fprintf(w.Out, "\n\n")
+			}
+			w.printedDescriptions = true
+			fmt.Fprint(w.Out, obj.description)
+		}
+
+	default:
+		var nodes []*topology.Node
+		for _, obj := range w.objects {
+			nodes = append(nodes, obj.node())
+		}
+		out := &bytes.Buffer{}
+		if err := w.printNodes(nodes, out); err != nil {
+			return err
+		}
+		if !initial && out.String() == w.lastTable {
+			return nil
+		}
+		if !initial {
+			fmt.Fprintf(w.Out, "\n")
+		}
+		w.lastTable = out.String()
+		fmt.Fprint(w.Out, w.lastTable)
+	}
+	return nil
+}
+
+// mergePatch returns the JSON merge patch from the old state to the new one.
+func mergePatch(oldState, newState *watchState) (json.RawMessage, error) {
+	oldJSON, err := json.Marshal(oldState)
+	if err != nil {
+		return nil, err
+	}
+	newJSON, err := json.Marshal(newState)
+	if err != nil {
+		return nil, err
+	}
+	return jsonpatch.CreateMergePatch(oldJSON, newJSON)
+}
+
+func compareGKNNs(a, b common.GKNN) int {
+	return strings.Compare(a.String(), b.String())
+}
+
+func sortedGKNNs[V any](m map[common.GKNN]V) []common.GKNN {
+	return slices.SortedFunc(maps.Keys(m), compareGKNNs)
+}
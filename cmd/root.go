@@ -22,19 +22,24 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 
 	cmdanalyze "sigs.k8s.io/gwctl/cmd/analyze"
 	cmdapply "sigs.k8s.io/gwctl/cmd/apply"
 	cmddelete "sigs.k8s.io/gwctl/cmd/delete"
+	cmdexplainpolicy "sigs.k8s.io/gwctl/cmd/explainpolicy"
 	cmdget "sigs.k8s.io/gwctl/cmd/get"
+	cmdtrace "sigs.k8s.io/gwctl/cmd/trace"
 	"sigs.k8s.io/gwctl/pkg/common"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
 	"sigs.k8s.io/gwctl/pkg/version"
 )
 
-func newRootCmd() *cobra.Command {
+func newRootCmd(ioStreams genericiooptions.IOStreams) *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "gwctl",
 		Short: "gwctl is a command-line tool for exploring Gateway API resources.",
@@ -60,20 +65,64 @@ func newRootCmd() *cobra.Command {
 		}
 	})
 
-	ioStreams := genericiooptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
-	factory := common.NewFactory(globalConfig)
+	// Allow reading resources from local manifests instead of the API server.
+	// Since the subcommands are constructed before the flags are parsed, they
+	// are given a proxy which switches to the local factory if requested.
+	var local bool
+	var fromDir []string
+	rootCmd.PersistentFlags().BoolVar(&local, "local", false, "If true, read resources from the manifests in --from-dir instead of the API server. Without --from-dir, the server is treated as empty.")
+	rootCmd.PersistentFlags().StringSliceVar(&fromDir, "from-dir", nil, "Files or directories (read recursively) containing the manifests to use instead of the API server. Implies --local.")
+
+	// Allow declaring additional backends and relations, so that resources
+	// unknown to gwctl join the graph.
+	var relationsConfig string
+	rootCmd.PersistentFlags().StringVar(&relationsConfig, "relations-config", "", "Path to a file declaring additional backend kinds, and relations between resources through JSONPath expressions.")
+
+	factory := &factoryProxy{Factory: common.NewFactory(globalConfig)}
+	rootCmd.PersistentPreRunE = func(*cobra.Command, []string) error {
+		if relationsConfig != "" {
+			if err := topologygw.LoadRelationsConfig(relationsConfig); err != nil {
+				return err
+			}
+		}
+		if !local && len(fromDir) == 0 {
+			return nil
+		}
+		namespace, _, err := globalConfig.ToRawKubeConfigLoader().Namespace()
+		if err != nil && !clientcmd.IsEmptyConfig(err) {
+			return err
+		}
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		localFactory, err := common.NewLocalFactory(fromDir, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to read local manifests: %v", err)
+		}
+		factory.Factory = localFactory
+		return nil
+	}
+
 	rootCmd.AddCommand(cmdapply.NewCmd(factory, ioStreams))
 	rootCmd.AddCommand(cmdget.NewCmd(factory, ioStreams, false))
 	rootCmd.AddCommand(cmdget.NewCmd(factory, ioStreams, true))
 	rootCmd.AddCommand(cmddelete.NewCmd(factory, ioStreams))
 	rootCmd.AddCommand(cmdanalyze.NewCmd(factory, ioStreams))
+	rootCmd.AddCommand(cmdexplainpolicy.NewCmd(factory, ioStreams))
+	rootCmd.AddCommand(cmdtrace.NewCmd(factory, ioStreams))
 	rootCmd.AddCommand(newVersionCommand())
 
 	return rootCmd
 }
 
+// factoryProxy delegates to the Factory selected after the flags have been
+// parsed.
+type factoryProxy struct {
+	common.Factory
+}
+
 func Execute() {
-	rootCmd := newRootCmd()
+	rootCmd := newRootCmd(genericiooptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
 	err := rootCmd.Execute()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to execute command: %v\n", err)
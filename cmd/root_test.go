@@ -0,0 +1,307 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+func TestRootCmdFromDir(t *testing.T) {
+	// Make sure that no kubeconfig from the environment is used.
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "kubeconfig"))
+
+	// The policy CRD is in a nested directory, so that the policies can only
+	// be recognized if the directories are read recursively.
+	manifestsDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(manifestsDir, "crds", "timeoutpolicies.yaml"), `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: timeoutpolicies.foo.com
+  labels:
+    gateway.networking.k8s.io/policy: inherited
+spec:
+  scope: Namespaced
+  group: foo.com
+  versions:
+  - name: v1
+  names:
+    plural: timeoutpolicies
+    kind: TimeoutPolicy
+`)
+	mustWriteFile(t, filepath.Join(manifestsDir, "resources.yaml"), `
+apiVersion: gateway.networking.k8s.io/v1
+kind: GatewayClass
+metadata:
+  name: foo-com-external-gateway-class
+spec:
+  controllerName: foo.com/external-gateway-class
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: gateway-1
+  namespace: infra
+spec:
+  gatewayClassName: foo-com-external-gateway-class
+  listeners:
+  - name: http
+    protocol: HTTP
+    port: 80
+    allowedRoutes:
+      namespaces:
+        from: All
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route-1
+  namespace: apps
+spec:
+  parentRefs:
+  - name: gateway-1
+    namespace: infra
+  rules:
+  - backendRefs:
+    - name: svc-1
+      port: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc-1
+  namespace: apps
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: foo.com/v1
+kind: TimeoutPolicy
+metadata:
+  name: gateway-timeouts
+  namespace: infra
+spec:
+  targetRef:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: gateway-1
+  default:
+    timeout:
+      request: 10s
+`)
+	changesFile := filepath.Join(t.TempDir(), "changes.yaml")
+	mustWriteFile(t, changesFile, `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route-2
+  namespace: apps
+spec:
+  parentRefs:
+  - name: gateway-1
+    namespace: infra
+  rules:
+  - backendRefs:
+    - name: svc-2
+      port: 80
+---
+apiVersion: foo.com/v1
+kind: TimeoutPolicy
+metadata:
+  name: route-timeouts
+  namespace: apps
+spec:
+  targetRef:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-2
+  default:
+    timeout:
+      request: 5s
+`)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		wantOut   string
+	}{
+		{
+			name:      "describe gateway",
+			inputArgs: []string{"--from-dir", manifestsDir, "describe", "gateway", "-n", "infra", "gateway-1"},
+			wantOut: `
+Name: gateway-1
+Namespace: infra
+Labels: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: Gateway
+Metadata: {}
+Spec:
+  gatewayClassName: foo-com-external-gateway-class
+  listeners:
+  - allowedRoutes:
+      namespaces:
+        from: All
+    name: http
+    port: 80
+    protocol: HTTP
+Status: {}
+AttachedRoutes:
+  Kind       Name
+  ----       ----
+  HTTPRoute  apps/route-1
+Backends:
+  Kind     Name
+  ----     ----
+  Service  apps/svc-1
+RoutesByListener:
+  Listener  Port  Protocol  Kind       Name
+  --------  ----  --------  ----       ----
+  http      80    HTTP      HTTPRoute  apps/route-1
+DirectlyAttachedPolicies:
+  Type                   Name
+  ----                   ----
+  TimeoutPolicy.foo.com  infra/gateway-timeouts
+InheritedPolicies: <none>
+EffectivePolicies:
+  TimeoutPolicy.foo.com:
+    timeout:
+      request: 10s
+EffectivePolicySources:
+  Type                   Field            Value  Policy                  Level    From
+  ----                   -----            -----  ------                  -----    ----
+  TimeoutPolicy.foo.com  timeout.request  10s    infra/gateway-timeouts  Gateway  default
+Events: <none>
+`,
+		},
+		{
+			name:      "analyze",
+			inputArgs: []string{"--from-dir", manifestsDir, "analyze", "-f", changesFile, "-o", "yaml"},
+			wantOut: `
+created:
+- group: gateway.networking.k8s.io
+  kind: HTTPRoute
+  name: route-2
+  namespace: apps
+- group: foo.com
+  kind: TimeoutPolicy
+  name: route-timeouts
+  namespace: apps
+fixedIssues: []
+newIssues:
+- extension: shadowedrulevalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-2" rule "0" duplicates
+    rule "0" of HTTPRoute(.gateway.networking.k8s.io) "apps/route-1" on listener "http"
+    of Gateway(.gateway.networking.k8s.io) "infra/gateway-1"; rule "0" of "apps/route-1"
+    takes precedence since it is alphabetically first
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-1
+    namespace: apps
+  severity: warning
+  type: ShadowedRouteRule
+- extension: notfoundrefvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-2" references a non-existent
+    Service "apps/svc-2"
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-2
+    namespace: apps
+  referredObject:
+    kind: Service
+    name: svc-2
+    namespace: apps
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-2
+    namespace: apps
+  severity: error
+  type: ReferenceToNonExistentResource
+- extension: shadowedrulevalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-2" rule "0" duplicates
+    rule "0" of HTTPRoute(.gateway.networking.k8s.io) "apps/route-1" on listener "http"
+    of Gateway(.gateway.networking.k8s.io) "infra/gateway-1"; rule "0" of "apps/route-1"
+    takes precedence since it is alphabetically first
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-2
+    namespace: apps
+  severity: warning
+  type: ShadowedRouteRule
+- extension: policyconflictvalidator
+  message: TimeoutPolicy(.foo.com) "apps/route-timeouts" conflicts with inherited
+    "infra/gateway-timeouts" on fields timeout.request; "apps/route-timeouts" takes
+    precedence since it is more specific
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-2
+    namespace: apps
+  severity: info
+  type: PolicyConflict
+unchangedIssues: []
+updated: []
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := newRootCmd(iostreams)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func mustWriteFile(t *testing.T, name, content string) {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(strings.TrimPrefix(content, "\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,351 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
+	"sigs.k8s.io/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gwctl/pkg/printer"
+	"sigs.k8s.io/gwctl/pkg/routing"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	outputFormatJSON = "json"
+	outputFormatYAML = "yaml"
+)
+
+// traceResult describes how a Gateway routes a request.
+type traceResult struct {
+	Gateway objectReference `json:"gateway"`
+	Request traceRequest    `json:"request"`
+	// Listeners contains the routing decision of each listener of the Gateway
+	// which receives the request.
+	Listeners []listenerTrace `json:"listeners"`
+}
+
+type traceRequest struct {
+	Host        string            `json:"host,omitempty"`
+	Path        string            `json:"path"`
+	Method      string            `json:"method"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	QueryParams map[string]string `json:"queryParams,omitempty"`
+}
+
+type listenerTrace struct {
+	Listener string `json:"listener"`
+	Port     int32  `json:"port"`
+	// Route is nil if no rule matches the request, in which case the other
+	// fields describing the winning rule are empty.
+	Route *objectReference `json:"route,omitempty"`
+	// Rule is the name of the winning rule, or its index if it has no name.
+	Rule              string                                              `json:"rule,omitempty"`
+	Match             string                                              `json:"match,omitempty"`
+	Filters           []filter                                            `json:"filters,omitempty"`
+	Backends          []backend                                           `json:"backends,omitempty"`
+	EffectivePolicies map[policymanager.PolicyCrdID]*policymanager.Policy `json:"effectivePolicies,omitempty"`
+	// Candidates contains all the matches of rules which match the request,
+	// ordered by precedence. The first one is the winning rule.
+	Candidates []candidate `json:"candidates"`
+}
+
+type filter struct {
+	Type string `json:"type"`
+	// Config is the configuration of the filter for its type, if any.
+	Config any `json:"config,omitempty"`
+}
+
+type backend struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Port      *int32 `json:"port,omitempty"`
+	Weight    int32  `json:"weight"`
+	// Share is the percentage of requests sent to the backend, as per the
+	// weights of all the backends of the rule.
+	Share   float64  `json:"share"`
+	Filters []filter `json:"filters,omitempty"`
+}
+
+type candidate struct {
+	Route objectReference `json:"route"`
+	Rule  string          `json:"rule"`
+	Match string          `json:"match"`
+}
+
+type objectReference struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (r objectReference) String() string {
+	if r.Namespace == "" {
+		return r.Name
+	}
+	return r.Namespace + "/" + r.Name
+}
+
+// newTraceResult traces the request through each of the listeners of the
+// Gateway. The gatewayeffectivepolicy extension must have been executed on the
+// graph.
+func newTraceResult(gatewayNode *topology.Node, listeners []gatewayv1.Listener, request routing.Request) (*traceResult, error) {
+	result := &traceResult{
+		Gateway: objectReference{Namespace: gatewayNode.GKNN().Namespace, Name: gatewayNode.GKNN().Name},
+		Request: traceRequest{
+			Host:        request.Host,
+			Path:        request.Path,
+			Method:      request.Method,
+			Headers:     request.Headers,
+			QueryParams: request.QueryParams,
+		},
+		Listeners: make([]listenerTrace, 0, len(listeners)),
+	}
+
+	listenerNodes := topologygw.GatewayNode(gatewayNode).Listeners()
+	for _, listener := range listeners {
+		entry := listenerTrace{
+			Listener:   string(listener.Name),
+			Port:       int32(listener.Port),
+			Candidates: make([]candidate, 0),
+		}
+		listenerNode := listenerNodes[common.ListenerGKNN(gatewayNode.GKNN().NamespacedName(), listener.Name)]
+		if listenerNode == nil {
+			result.Listeners = append(result.Listeners, entry)
+			continue
+		}
+
+		var matches []routing.RuleMatch
+		for _, m := range routing.ListenerRuleMatches(listenerNode) {
+			if request.Matches(m) {
+				matches = append(matches, m)
+			}
+		}
+		for _, m := range matches {
+			entry.Candidates = append(entry.Candidates, candidate{
+				Route: objectReference{Namespace: m.Route.Namespace, Name: m.Route.Name},
+				Rule:  m.RuleName(),
+				Match: m.String(),
+			})
+		}
+		if len(matches) != 0 {
+			if err := entry.setWinner(matches[0], gatewayNode.GKNN()); err != nil {
+				return nil, err
+			}
+		}
+		result.Listeners = append(result.Listeners, entry)
+	}
+	return result, nil
+}
+
+// setWinner describes the rule of the winning match, and its effective policies
+// for the Gateway.
+func (t *listenerTrace) setWinner(m routing.RuleMatch, gateway common.GKNN) error {
+	rule := m.Rule()
+	t.Route = &objectReference{Namespace: m.Route.Namespace, Name: m.Route.Name}
+	t.Rule = m.RuleName()
+	t.Match = m.String()
+
+	var err error
+	if t.Filters, err = convertFilters(rule.Filters); err != nil {
+		return err
+	}
+
+	for i, ruleBackend := range topologygw.HTTPRouteRules(m.Route)[m.RuleIndex].Backends {
+		b := backend{
+			Group:     ruleBackend.GKNN.Group,
+			Kind:      ruleBackend.GKNN.Kind,
+			Namespace: ruleBackend.GKNN.Namespace,
+			Name:      ruleBackend.GKNN.Name,
+			Weight:    ruleBackend.Weight,
+			Share:     topologygw.RoundPercent(ruleBackend.Percent),
+		}
+		if ruleBackend.Port != nil {
+			port := int32(*ruleBackend.Port)
+			b.Port = &port
+		}
+		if b.Filters, err = convertFilters(rule.BackendRefs[i].Filters); err != nil {
+			return err
+		}
+		t.Backends = append(t.Backends, b)
+	}
+
+	var effectivePolicies map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy
+	if rule.Name != nil && *rule.Name != "" {
+		effectivePolicies, err = gatewayeffectivepolicy.RouteRuleEffectivePolicies(m.RouteNode, string(*rule.Name))
+	} else {
+		var metadata *gatewayeffectivepolicy.NodeMetadata
+		metadata, err = gatewayeffectivepolicy.Access(m.RouteNode)
+		if metadata != nil {
+			effectivePolicies = metadata.RouteEffectivePolicies
+		}
+	}
+	if err != nil {
+		return err
+	}
+	t.EffectivePolicies = effectivePolicies[gateway]
+	return nil
+}
+
+// convertFilters converts the filters of a rule or backend, such that the
+// configuration of each filter is separated from its type.
+func convertFilters(filters []gatewayv1.HTTPRouteFilter) ([]filter, error) {
+	var result []filter
+	for _, f := range filters {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&f)
+		if err != nil {
+			return nil, err
+		}
+		delete(obj, "type")
+		entry := filter{Type: string(f.Type)}
+		// All that remains is the field containing the configuration for the
+		// type of filter.
+		for _, config := range obj {
+			entry.Config = config
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func printTraceResult(w io.Writer, result *traceResult, outputFormat string) error {
+	var b []byte
+	var err error
+	switch outputFormat {
+	case outputFormatJSON:
+		b, err = json.MarshalIndent(result, "", "  ")
+		b = append(b, '\n')
+	case outputFormatYAML:
+		b, err = yaml.Marshal(result)
+	default:
+		return fmt.Errorf("unknown output format %q", outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// describeTraceResult writes the result in a human readable format.
+func describeTraceResult(w io.Writer, result *traceResult) {
+	printer.Describe(w, []*printer.DescriberKV{
+		{Key: "Gateway", Value: result.Gateway.String()},
+		{Key: "Request", Value: result.Request},
+	})
+	if len(result.Listeners) == 0 {
+		fmt.Fprintf(w, "Listeners: <none>\n")
+		return
+	}
+
+	for _, listener := range result.Listeners {
+		fmt.Fprintf(w, "\n")
+		pairs := []*printer.DescriberKV{
+			{Key: "Listener", Value: fmt.Sprintf("%v (port %v)", listener.Listener, listener.Port)},
+		}
+		if listener.Route == nil {
+			pairs = append(pairs, &printer.DescriberKV{Key: "Route", Value: "<none>"})
+			printer.Describe(w, pairs)
+			continue
+		}
+		pairs = append(pairs,
+			&printer.DescriberKV{Key: "Route", Value: listener.Route.String()},
+			&printer.DescriberKV{Key: "Rule", Value: listener.Rule},
+			&printer.DescriberKV{Key: "Match", Value: listener.Match},
+		)
+
+		filtersTable := &printer.Table{
+			ColumnNames:  []string{"Type", "Config"},
+			UseSeparator: true,
+		}
+		for _, f := range listener.Filters {
+			filtersTable.Rows = append(filtersTable.Rows, []string{
+				f.Type,                 // Type
+				configOutput(f.Config), // Config
+			})
+		}
+		pairs = append(pairs, &printer.DescriberKV{Key: "Filters", Value: filtersTable})
+
+		backendsTable := &printer.Table{
+			ColumnNames:  []string{"Kind", "Name", "Port", "Weight", "Share", "Filters"},
+			UseSeparator: true,
+		}
+		for _, b := range listener.Backends {
+			port := ""
+			if b.Port != nil {
+				port = fmt.Sprintf("%d", *b.Port)
+			}
+			var filterTypes []string
+			for _, f := range b.Filters {
+				filterTypes = append(filterTypes, f.Type)
+			}
+			backendsTable.Rows = append(backendsTable.Rows, []string{
+				b.Kind, // Kind
+				objectReference{Namespace: b.Namespace, Name: b.Name}.String(), // Name
+				port,                              // Port
+				fmt.Sprintf("%d", b.Weight),       // Weight
+				topologygw.FormatPercent(b.Share), // Share
+				strings.Join(filterTypes, ","),    // Filters
+			})
+		}
+		pairs = append(pairs, &printer.DescriberKV{Key: "Backends", Value: backendsTable})
+
+		if len(listener.EffectivePolicies) != 0 {
+			pairs = append(pairs, &printer.DescriberKV{Key: "EffectivePolicies", Value: listener.EffectivePolicies})
+		} else {
+			pairs = append(pairs, &printer.DescriberKV{Key: "EffectivePolicies", Value: "<none>"})
+		}
+
+		candidatesTable := &printer.Table{
+			ColumnNames:  []string{"Route", "Rule", "Match"},
+			UseSeparator: true,
+		}
+		for _, c := range listener.Candidates {
+			candidatesTable.Rows = append(candidatesTable.Rows, []string{
+				c.Route.String(), // Route
+				c.Rule,           // Rule
+				c.Match,          // Match
+			})
+		}
+		pairs = append(pairs, &printer.DescriberKV{Key: "Candidates", Value: candidatesTable})
+		printer.Describe(w, pairs)
+	}
+}
+
+// configOutput formats the configuration of a filter as JSON.
+func configOutput(config any) string {
+	if config == nil {
+		return ""
+	}
+	b, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Sprintf("%v", config)
+	}
+	return string(b)
+}
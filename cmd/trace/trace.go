@@ -0,0 +1,263 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/extension"
+	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
+	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
+	"sigs.k8s.io/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gwctl/pkg/routing"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func NewCmd(factory common.Factory, iostreams genericiooptions.IOStreams) *cobra.Command {
+	flags := &traceFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "trace GATEWAY_NAME",
+		Short: "Simulate which HTTPRoute rule and backends serve a request",
+		Long: `Simulate which HTTPRoute rule and backends serve a request received by a Gateway.
+
+The request is matched against the rules of all HTTPRoutes attached to the HTTP and HTTPS listeners of the Gateway which receive it, using the match semantics and precedence rules of Gateway API. For each listener, this shows the winning rule, its filters, its weighted backends and the effective policies of the rule, followed by all the matching rules in order of precedence.`,
+		Example: `  # Trace a GET request for foo.example.com/api through my-gateway in the current namespace
+  gwctl trace my-gateway --host foo.example.com --path /api
+
+  # Trace a request with headers and query params through the "http" listener, in JSON format
+  gwctl trace my-gateway -n ns1 --listener http --host foo.example.com --method POST --path '/api?debug=true' -H 'X-Env: canary' -o json`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			o, err := flags.ToOptions(args, factory, iostreams)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v", err)
+				os.Exit(1)
+			}
+
+			if err := o.Run(); err != nil {
+				fmt.Fprintf(os.Stderr, "%v", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.listener, "listener", "", "Name of the listener which receives the request. Defaults to the listeners which match the host")
+	cmd.Flags().Int32Var(&flags.port, "port", 0, "Port on which the request is received. Defaults to all ports")
+	cmd.Flags().StringVar(&flags.host, "host", "", "Host of the request")
+	cmd.Flags().StringVar(&flags.path, "path", "/", "Path of the request, optionally followed by a query string")
+	cmd.Flags().StringVar(&flags.method, "method", http.MethodGet, "Method of the request")
+	cmd.Flags().StringArrayVarP(&flags.headers, "header", "H", nil, "Header of the request in the form 'NAME: VALUE'. Can be repeated")
+	cmd.Flags().StringArrayVar(&flags.queryParams, "query", nil, "Query param of the request in the form 'NAME=VALUE'. Can be repeated")
+	cmd.Flags().StringVarP(&flags.outputFormat, "output", "o", "", fmt.Sprintf("Output format. Must be one of: %v", strings.Join([]string{outputFormatJSON, outputFormatYAML}, ",")))
+	return cmd
+}
+
+// traceFlags contains the flags used with trace command.
+type traceFlags struct {
+	listener     string
+	port         int32
+	host         string
+	path         string
+	method       string
+	headers      []string
+	queryParams  []string
+	outputFormat string
+}
+
+func (f *traceFlags) ToOptions(args []string, factory common.Factory, iostreams genericiooptions.IOStreams) (*traceOptions, error) {
+	switch f.outputFormat {
+	case "", outputFormatJSON, outputFormatYAML:
+	default:
+		return nil, fmt.Errorf("invalid output format %q, must be one of: %v", f.outputFormat, strings.Join([]string{outputFormatJSON, outputFormatYAML}, ","))
+	}
+
+	namespace, _, err := factory.KubeConfigNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := f.request()
+	if err != nil {
+		return nil, err
+	}
+
+	return &traceOptions{
+		factory:      factory,
+		namespace:    namespace,
+		gatewayName:  args[0],
+		listener:     gatewayv1.SectionName(f.listener),
+		port:         gatewayv1.PortNumber(f.port),
+		request:      request,
+		outputFormat: f.outputFormat,
+		IOStreams:    iostreams,
+	}, nil
+}
+
+// request returns the request described by the flags.
+func (f *traceFlags) request() (routing.Request, error) {
+	host := f.host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	request := routing.Request{
+		Host:        strings.ToLower(host),
+		Method:      strings.ToUpper(f.method),
+		Headers:     make(map[string]string),
+		QueryParams: make(map[string]string),
+	}
+
+	path, rawQuery, _ := strings.Cut(f.path, "?")
+	if !strings.HasPrefix(path, "/") {
+		return routing.Request{}, fmt.Errorf("invalid path %q, must begin with '/'", f.path)
+	}
+	request.Path = path
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return routing.Request{}, fmt.Errorf("invalid query string in path %q: %v", f.path, err)
+	}
+	for name, values := range query {
+		request.QueryParams[name] = values[0]
+	}
+	for _, queryParam := range f.queryParams {
+		name, value, ok := strings.Cut(queryParam, "=")
+		if !ok || name == "" {
+			return routing.Request{}, fmt.Errorf("invalid query param %q, must be of the form 'NAME=VALUE'", queryParam)
+		}
+		request.QueryParams[name] = value
+	}
+
+	for _, header := range f.headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return routing.Request{}, fmt.Errorf("invalid header %q, must be of the form 'NAME: VALUE'", header)
+		}
+		request.Headers[http.CanonicalHeaderKey(strings.TrimSpace(name))] = strings.TrimSpace(value)
+	}
+	return request, nil
+}
+
+type traceOptions struct {
+	factory common.Factory
+
+	namespace   string
+	gatewayName string
+	// listener and port restrict the listeners which receive the request, if
+	// they are set.
+	listener gatewayv1.SectionName
+	port     gatewayv1.PortNumber
+	request  routing.Request
+
+	// outputFormat is empty for the human readable output.
+	outputFormat string
+
+	genericclioptions.IOStreams
+}
+
+func (o *traceOptions) Run() error {
+	infos, err := o.factory.NewBuilder().
+		Unstructured().
+		Flatten().
+		NamespaceParam(o.namespace).DefaultNamespace().
+		ResourceTypeOrNameArgs(true, "gateways", o.gatewayName).
+		Do().
+		Infos()
+	if err != nil {
+		return err
+	}
+	if len(infos) != 1 {
+		return fmt.Errorf("failed to find gateway %v", o.gatewayName)
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(infos[0].Object)
+	if err != nil {
+		return err
+	}
+	source := &unstructured.Unstructured{Object: obj}
+
+	cache := common.NewFetchCache()
+	defer cache.LogMetrics()
+	fetcher := common.NewDefaultGroupKindFetcher(o.factory, common.WithCache(cache))
+
+	policyManager := policymanager.New(fetcher)
+	if err := policyManager.Init(); err != nil { //nolint:govet
+		return err
+	}
+
+	graph, err := topology.NewBuilder(fetcher).
+		StartFrom([]*unstructured.Unstructured{source}).
+		UseRelationships(topologygw.DefaultRegistry.Relations()).
+		Build()
+	if err != nil {
+		return err
+	}
+	err = extension.ExecuteAll(graph,
+		directlyattachedpolicy.NewExtension(policyManager),
+		gatewayeffectivepolicy.NewExtension(),
+	)
+	if err != nil {
+		return err
+	}
+
+	gatewayNode := graph.Sources[0]
+	listeners, err := o.selectListeners(topology.MustAccessObject(gatewayNode, &gatewayv1.Gateway{}))
+	if err != nil {
+		return err
+	}
+
+	result, err := newTraceResult(gatewayNode, listeners, o.request)
+	if err != nil {
+		return err
+	}
+	if o.outputFormat != "" {
+		return printTraceResult(o.Out, result, o.outputFormat)
+	}
+	describeTraceResult(o.Out, result)
+	return nil
+}
+
+// selectListeners returns the listeners of the Gateway which receive the
+// request.
+func (o *traceOptions) selectListeners(gateway *gatewayv1.Gateway) ([]gatewayv1.Listener, error) {
+	listeners := gateway.Spec.Listeners
+	if o.listener != "" {
+		index := slices.IndexFunc(listeners, func(l gatewayv1.Listener) bool { return l.Name == o.listener })
+		if index < 0 {
+			return nil, fmt.Errorf("gateway %v/%v has no listener named %q", gateway.Namespace, gateway.Name, o.listener)
+		}
+		listeners = listeners[index : index+1]
+	}
+	if o.port != 0 {
+		listeners = slices.DeleteFunc(slices.Clone(listeners), func(l gatewayv1.Listener) bool { return l.Port != o.port })
+	}
+	return routing.MatchingListeners(listeners, o.request.Host), nil
+}
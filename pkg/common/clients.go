@@ -18,21 +18,87 @@ package common //nolint:revive
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 )
 
 type GroupKindFetcher interface {
 	Fetch(gk schema.GroupKind) ([]*unstructured.Unstructured, error)
 }
 
-var _ GroupKindFetcher = (*defaultGroupKindFetcher)(nil)
+// NamespacedGroupKindFetcher is a GroupKindFetcher which can also fetch the
+// resources of a GroupKind within a single namespace.
+type NamespacedGroupKindFetcher interface {
+	GroupKindFetcher
+	FetchInNamespace(gk schema.GroupKind, namespace string) ([]*unstructured.Unstructured, error)
+}
+
+// MultiGroupKindFetcher is a GroupKindFetcher which can fetch the resources of
+// multiple GroupKinds at once.
+type MultiGroupKindFetcher interface {
+	GroupKindFetcher
+	FetchAll(gks []schema.GroupKind) (map[schema.GroupKind][]*unstructured.Unstructured, error)
+}
+
+var (
+	_ NamespacedGroupKindFetcher = (*defaultGroupKindFetcher)(nil)
+	_ MultiGroupKindFetcher      = (*defaultGroupKindFetcher)(nil)
+)
+
+// maxConcurrentFetches is the maximum number of GroupKinds which are fetched
+// concurrently.
+const maxConcurrentFetches = 8
+
+// FetchInNamespace returns the resources of the GroupKind within the
+// namespace, along with any cluster scoped resources. Fetchers which cannot
+// fetch a single namespace fetch all resources, which are then filtered.
+func FetchInNamespace(fetcher GroupKindFetcher, gk schema.GroupKind, namespace string) ([]*unstructured.Unstructured, error) {
+	if f, ok := fetcher.(NamespacedGroupKindFetcher); ok {
+		return f.FetchInNamespace(gk, namespace)
+	}
+	resources, err := fetcher.Fetch(gk)
+	if err != nil {
+		return nil, err
+	}
+	var result []*unstructured.Unstructured
+	for _, resource := range resources {
+		if resource.GetNamespace() == namespace || resource.GetNamespace() == "" {
+			result = append(result, resource)
+		}
+	}
+	return result, nil
+}
+
+// FetchAll returns the resources of all the GroupKinds. Fetchers which cannot
+// fetch multiple GroupKinds at once fetch them one by one.
+func FetchAll(fetcher GroupKindFetcher, gks []schema.GroupKind) (map[schema.GroupKind][]*unstructured.Unstructured, error) {
+	if f, ok := fetcher.(MultiGroupKindFetcher); ok {
+		return f.FetchAll(gks)
+	}
+	result := make(map[schema.GroupKind][]*unstructured.Unstructured)
+	for _, gk := range gks {
+		resources, err := fetcher.Fetch(gk)
+		if err != nil {
+			return nil, err
+		}
+		result[gk] = resources
+	}
+	return result, nil
+}
 
 type defaultGroupKindFetcher struct {
 	factory                 Factory
 	additionalResourcesByGK map[schema.GroupKind][]*unstructured.Unstructured
+	cache                   *FetchCache
 }
 
 type groupKindFetcherOption func(*defaultGroupKindFetcher)
@@ -46,6 +112,15 @@ func WithAdditionalResources(resources []*unstructured.Unstructured) groupKindFe
 	}
 }
 
+// WithCache makes the fetcher list resources through the cache, so that
+// fetchers sharing the cache list each GroupKind only once. Additional
+// resources are not stored in the cache.
+func WithCache(cache *FetchCache) groupKindFetcherOption { //nolint:revive
+	return func(f *defaultGroupKindFetcher) {
+		f.cache = cache
+	}
+}
+
 func NewDefaultGroupKindFetcher(factory Factory, options ...groupKindFetcherOption) *defaultGroupKindFetcher { //nolint:revive
 	d := &defaultGroupKindFetcher{
 		factory:                 factory,
@@ -58,10 +133,88 @@ func NewDefaultGroupKindFetcher(factory Factory, options ...groupKindFetcherOpti
 }
 
 func (d defaultGroupKindFetcher) Fetch(gk schema.GroupKind) ([]*unstructured.Unstructured, error) {
+	return d.FetchInNamespace(gk, metav1.NamespaceAll)
+}
+
+// FetchInNamespace returns the resources of the GroupKind in the namespace, or
+// across all namespaces if the namespace is empty. Cluster scoped resources are
+// always returned in full.
+func (d defaultGroupKindFetcher) FetchInNamespace(gk schema.GroupKind, namespace string) ([]*unstructured.Unstructured, error) {
+	if gk == ListenerGK {
+		return d.fetchListeners(namespace)
+	}
+
+	var result []*unstructured.Unstructured
+	var err error
+	if d.cache != nil {
+		result, err = d.cache.get(gk, namespace, func() ([]*unstructured.Unstructured, error) {
+			return d.list(gk, namespace)
+		})
+	} else {
+		result, err = d.list(gk, namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Return any additional Resources if they have been provided.
+	for _, resource := range d.additionalResourcesByGK[gk] {
+		if namespace == metav1.NamespaceAll || resource.GetNamespace() == namespace || resource.GetNamespace() == "" {
+			result = append(result, resource)
+		}
+	}
+
+	return result, nil
+}
+
+// FetchAll fetches the resources of all the GroupKinds concurrently.
+func (d defaultGroupKindFetcher) FetchAll(gks []schema.GroupKind) (map[schema.GroupKind][]*unstructured.Unstructured, error) {
+	var mu sync.Mutex
+	result := make(map[schema.GroupKind][]*unstructured.Unstructured)
+
+	var g errgroup.Group
+	g.SetLimit(maxConcurrentFetches)
+	for _, gk := range gks {
+		g.Go(func() error {
+			resources, err := d.Fetch(gk)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			result[gk] = resources
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// list lists the resources of the GroupKind from the server.
+func (d defaultGroupKindFetcher) list(gk schema.GroupKind, namespace string) ([]*unstructured.Unstructured, error) {
+	// Not all kinds are guaranteed to be installed in the cluster (for
+	// example, CRDs from the experimental channel of Gateway API). Treat such
+	// kinds as having no resources instead of failing.
+	restMapper, err := d.factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	if _, mappingErr := restMapper.RESTMapping(gk); mappingErr != nil {
+		if !meta.IsNoMatchError(mappingErr) {
+			return nil, mappingErr
+		}
+		klog.V(3).InfoS("Resource type not found in the server, skipping fetch", "groupKind", gk)
+		return nil, nil
+	}
+
+	start := time.Now()
 	infos, err := d.factory.NewBuilder().
 		Unstructured().
 		Flatten().
-		AllNamespaces(true).
+		NamespaceParam(namespace).
+		AllNamespaces(namespace == metav1.NamespaceAll).
 		ResourceTypeOrNameArgs(true, []string{fmt.Sprintf("%v.%v", gk.Kind, gk.Group)}...).
 		ContinueOnError().
 		Do().
@@ -78,9 +231,41 @@ func (d defaultGroupKindFetcher) Fetch(gk schema.GroupKind) ([]*unstructured.Uns
 		}
 		result = append(result, &unstructured.Unstructured{Object: o})
 	}
+	klog.V(2).InfoS("Listed resources", "groupKind", gk, "namespace", namespace, "count", len(result), "duration", time.Since(start))
+	return result, nil
+}
 
-	// Return any additional Resources if they have been provided.
-	result = append(result, d.additionalResourcesByGK[gk]...)
+// fetchListeners derives the Listener objects from the Gateways. If a Gateway
+// has also been provided as an additional resource, the additional version
+// takes precedence over the one in the server.
+func (d defaultGroupKindFetcher) fetchListeners(namespace string) ([]*unstructured.Unstructured, error) {
+	gateways, err := d.FetchInNamespace(GatewayGK, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return listenersFromGateways(gateways)
+}
 
+// listenersFromGateways returns the Listener objects of the Gateways. If a
+// Gateway appears more than once, the last occurrence takes precedence.
+func listenersFromGateways(gateways []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	var gatewayNames []types.NamespacedName
+	gatewaysByName := make(map[types.NamespacedName]*unstructured.Unstructured)
+	for _, gateway := range gateways {
+		nn := types.NamespacedName{Namespace: gateway.GetNamespace(), Name: gateway.GetName()}
+		if _, ok := gatewaysByName[nn]; !ok {
+			gatewayNames = append(gatewayNames, nn)
+		}
+		gatewaysByName[nn] = gateway
+	}
+
+	var result []*unstructured.Unstructured
+	for _, nn := range gatewayNames {
+		listeners, err := ListenersFromGateway(gatewaysByName[nn])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, listeners...)
+	}
 	return result, nil
 }
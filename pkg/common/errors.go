@@ -17,9 +17,52 @@ limitations under the License.
 package common //nolint:revive
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
+// Severity indicates how severe an issue found during analysis is.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+var severityRanks = map[Severity]int{
+	SeverityInfo:    0,
+	SeverityWarning: 1,
+	SeverityError:   2,
+}
+
+// ParseSeverity returns the Severity with the given name.
+func ParseSeverity(s string) (Severity, error) {
+	severity := Severity(s)
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("invalid severity %q, must be one of: %v, %v, %v", s, SeverityError, SeverityWarning, SeverityInfo)
+	}
+	return severity, nil
+}
+
+// AtLeast returns true if the severity is the same as, or more severe than, the
+// other severity.
+func (s Severity) AtLeast(other Severity) bool {
+	return severityRanks[s] >= severityRanks[other]
+}
+
+// SeverityOf returns the severity of an error found during analysis. Errors can
+// specify their severity by implementing a Severity() method, and are otherwise
+// considered to be of SeverityError.
+func SeverityOf(err error) Severity {
+	var severityErr interface{ Severity() Severity }
+	if errors.As(err, &severityErr) {
+		return severityErr.Severity()
+	}
+	return SeverityError
+}
+
 type ReferenceToNonExistentResourceError struct {
 	ReferenceFromTo
 }
@@ -40,6 +83,348 @@ func (r ReferenceNotPermittedError) Error() string {
 		r.referredObjectKind(), r.referredObjectName())
 }
 
+// ParentRefMatchesNoListenerError is returned when the sectionName and/or port
+// of a parentRef match no listener within the referenced Gateway.
+type ParentRefMatchesNoListenerError struct {
+	ReferenceFromTo
+	// SectionName is the sectionName specified in the parentRef, if any.
+	SectionName string
+	// Port is the port specified in the parentRef, if any. A zero value means
+	// that the port was not specified.
+	Port int32
+}
+
+func (r ParentRefMatchesNoListenerError) Error() string {
+	var selector string
+	switch {
+	case r.SectionName != "" && r.Port != 0:
+		selector = fmt.Sprintf("sectionName %q and port %v", r.SectionName, r.Port)
+	case r.SectionName != "":
+		selector = fmt.Sprintf("sectionName %q", r.SectionName)
+	default:
+		selector = fmt.Sprintf("port %v", r.Port)
+	}
+	return fmt.Sprintf("%v %q references %v %q with %v which matches no listener",
+		r.referringObjectKind(), r.referringObjectName(),
+		r.referredObjectKind(), r.referredObjectName(),
+		selector)
+}
+
+// RouteNamespaceNotAllowedError is returned when a listener of the referenced
+// Gateway does not allow Routes from the namespace of the Route, as per
+// allowedRoutes.namespaces of the listener.
+type RouteNamespaceNotAllowedError struct {
+	ReferenceFromTo
+	// ListenerName is the name of the listener which does not allow the Route.
+	ListenerName string
+	// From is the value of allowedRoutes.namespaces.from of the listener.
+	From string
+}
+
+func (r RouteNamespaceNotAllowedError) Error() string {
+	return fmt.Sprintf("%v %q is not allowed to attach to listener %q of %v %q since its namespace is not allowed by the listener (allowedRoutes.namespaces.from is %v)",
+		r.referringObjectKind(), r.referringObjectName(),
+		r.ListenerName, r.referredObjectKind(), r.referredObjectName(),
+		r.From)
+}
+
+// RouteKindNotAllowedError is returned when a listener of the referenced
+// Gateway does not allow Routes of the kind of the Route, either through
+// allowedRoutes.kinds or through the protocol of the listener.
+type RouteKindNotAllowedError struct {
+	ReferenceFromTo
+	// ListenerName is the name of the listener which does not allow the Route.
+	ListenerName string
+}
+
+func (r RouteKindNotAllowedError) Error() string {
+	return fmt.Sprintf("%v %q is not allowed to attach to listener %q of %v %q since the listener does not allow the kind %v",
+		r.referringObjectKind(), r.referringObjectName(),
+		r.ListenerName, r.referredObjectKind(), r.referredObjectName(),
+		r.ReferringObject.Kind)
+}
+
+// RouteHostnamesNotMatchingError is returned when none of the hostnames of the
+// Route intersect with the hostname of a listener of the referenced Gateway.
+type RouteHostnamesNotMatchingError struct {
+	ReferenceFromTo
+	// ListenerName is the name of the listener which does not allow the Route.
+	ListenerName string
+	// ListenerHostname is the hostname of the listener.
+	ListenerHostname string
+}
+
+func (r RouteHostnamesNotMatchingError) Error() string {
+	return fmt.Sprintf("%v %q is not allowed to attach to listener %q of %v %q since none of its hostnames match the listener hostname %q",
+		r.referringObjectKind(), r.referringObjectName(),
+		r.ListenerName, r.referredObjectKind(), r.referredObjectName(),
+		r.ListenerHostname)
+}
+
+// UnusedReferenceGrantError is returned for a ReferenceGrant which does not
+// permit any of the existing references.
+type UnusedReferenceGrantError struct {
+	ReferenceGrant GKNN
+}
+
+func (e UnusedReferenceGrantError) Error() string {
+	return fmt.Sprintf("ReferenceGrant %q does not permit any existing reference",
+		e.ReferenceGrant.NamespacedName().String())
+}
+
+func (e UnusedReferenceGrantError) Severity() Severity {
+	return SeverityWarning
+}
+
+// BroadReferenceGrantError is returned for a ReferenceGrant which permits
+// references to all objects of some kind within its namespace, while only some
+// of those objects are actually referenced.
+type BroadReferenceGrantError struct {
+	ReferenceGrant GKNN
+	// Group and Kind are the kind of objects permitted by the ReferenceGrant.
+	Group string
+	Kind  string
+	// Permitted is the number of objects of the kind within the namespace, and
+	// Referenced is the number of those which are actually referenced.
+	Permitted  int
+	Referenced int
+}
+
+func (e BroadReferenceGrantError) Error() string {
+	kind := e.Kind
+	if e.Group != "" {
+		kind = fmt.Sprintf("%v(.%v)", e.Kind, e.Group)
+	}
+	return fmt.Sprintf("ReferenceGrant %q permits references to all %v objects in namespace %q, but only %v of %v are referenced; consider restricting it by name",
+		e.ReferenceGrant.NamespacedName().String(), kind, e.ReferenceGrant.Namespace, e.Referenced, e.Permitted)
+}
+
+func (e BroadReferenceGrantError) Severity() Severity {
+	return SeverityInfo
+}
+
+// PolicyConflictError is returned when two policies of the same kind set the
+// same fields for an object, either because both of them target the object, or
+// because the object inherits one of them. Only one of the policies takes
+// effect for each of the fields, as per GEP-713.
+type PolicyConflictError struct {
+	// Policy is the policy which targets the object.
+	Policy GKNN
+	// ConflictingPolicy either targets the same object, or is inherited by the
+	// object if Inherited is true.
+	ConflictingPolicy GKNN
+	Inherited         bool
+	// Fields are the conflicting fields, relative to the spec of the policies
+	// (or to spec.default and spec.override for inherited policies).
+	Fields []string
+	// Winner is the policy whose values take effect for the Fields, and Reason
+	// explains why it takes precedence.
+	Winner GKNN
+	Reason string
+}
+
+func (e PolicyConflictError) Error() string {
+	inherited := ""
+	if e.Inherited {
+		inherited = "inherited "
+	}
+	return fmt.Sprintf("%v %q conflicts with %v%q on fields %v; %q takes precedence since %v",
+		humanReadableKind(e.Policy), humanReadableName(e.Policy), inherited, humanReadableName(e.ConflictingPolicy),
+		strings.Join(e.Fields, ", "), humanReadableName(e.Winner), e.Reason)
+}
+
+// Severity of conflicts between policies targeting the same object is higher,
+// since they are usually unintended, unlike conflicts with inherited policies
+// which are how defaults and overrides are meant to be used.
+func (e PolicyConflictError) Severity() Severity {
+	if e.Inherited {
+		return SeverityInfo
+	}
+	return SeverityWarning
+}
+
+// ShadowedRouteRuleError is returned for a rule of a Route which never matches
+// requests received by a listener, since all the requests it matches are also
+// matched by a rule with higher precedence.
+type ShadowedRouteRuleError struct {
+	Route GKNN
+	Rule  string
+	// ShadowingRoute and ShadowingRule identify the rule which takes
+	// precedence.
+	ShadowingRoute GKNN
+	ShadowingRule  string
+	// Gateway and ListenerName identify the listener to which both rules are
+	// attached.
+	Gateway      GKNN
+	ListenerName string
+	// Duplicate is true if both rules match exactly the same requests, in which
+	// case Reason explains why ShadowingRule takes precedence.
+	Duplicate bool
+	Reason    string
+}
+
+func (e ShadowedRouteRuleError) Error() string {
+	listener := fmt.Sprintf("listener %q of %v %q", e.ListenerName, humanReadableKind(e.Gateway), humanReadableName(e.Gateway))
+	if e.Duplicate {
+		return fmt.Sprintf("%v %q rule %q duplicates rule %q of %v %q on %v; rule %q of %q takes precedence since %v",
+			humanReadableKind(e.Route), humanReadableName(e.Route), e.Rule,
+			e.ShadowingRule, humanReadableKind(e.ShadowingRoute), humanReadableName(e.ShadowingRoute), listener,
+			e.ShadowingRule, humanReadableName(e.ShadowingRoute), e.Reason)
+	}
+	return fmt.Sprintf("%v %q rule %q is shadowed by rule %q of %v %q on %v, which takes precedence for all the requests it matches",
+		humanReadableKind(e.Route), humanReadableName(e.Route), e.Rule,
+		e.ShadowingRule, humanReadableKind(e.ShadowingRoute), humanReadableName(e.ShadowingRoute), listener)
+}
+
+func (e ShadowedRouteRuleError) Severity() Severity {
+	return SeverityWarning
+}
+
+// ZeroWeightRouteRuleError is returned for a rule of a Route which has
+// backendRefs, all of which have a weight of 0. Requests matching the rule are
+// rejected instead of being forwarded to any of the backends.
+type ZeroWeightRouteRuleError struct {
+	Route GKNN
+	Rule  string
+}
+
+func (e ZeroWeightRouteRuleError) Error() string {
+	return fmt.Sprintf("%v %q rule %q has a total backend weight of 0, so requests matching it are rejected with a 500 status code",
+		humanReadableKind(e.Route), humanReadableName(e.Route), e.Rule)
+}
+
+func (e ZeroWeightRouteRuleError) Severity() Severity {
+	return SeverityWarning
+}
+
+// ListenerError is implemented by errors which are caused by a single listener
+// of a Gateway.
+type ListenerError interface {
+	error
+	// Listener returns the name of the listener.
+	Listener() string
+	// Reason describes the error without mentioning the Gateway and listener.
+	Reason() string
+}
+
+// ListenerProtocolConflictError is returned for a listener which uses the same
+// port as another listener of the Gateway, with a protocol which cannot share
+// the port with the protocol of the other listener.
+type ListenerProtocolConflictError struct {
+	Gateway      GKNN
+	ListenerName string
+	Protocol     string
+	Port         int32
+	// ConflictingListenerName and ConflictingProtocol identify the other
+	// listener.
+	ConflictingListenerName string
+	ConflictingProtocol     string
+}
+
+func (e ListenerProtocolConflictError) Error() string {
+	return listenerErrorString(e.Gateway, e)
+}
+
+func (e ListenerProtocolConflictError) Listener() string {
+	return e.ListenerName
+}
+
+func (e ListenerProtocolConflictError) Reason() string {
+	return fmt.Sprintf("its protocol %v conflicts with protocol %v of listener %q on port %d",
+		e.Protocol, e.ConflictingProtocol, e.ConflictingListenerName, e.Port)
+}
+
+// ListenerHostnameConflictError is returned for a listener which uses the same
+// port and hostname as another listener of the Gateway with a compatible
+// protocol, such that requests cannot be told apart.
+type ListenerHostnameConflictError struct {
+	Gateway      GKNN
+	ListenerName string
+	// Hostname is empty if neither listener specifies a hostname.
+	Hostname string
+	Port     int32
+	// ConflictingListenerName is the name of the other listener.
+	ConflictingListenerName string
+}
+
+func (e ListenerHostnameConflictError) Error() string {
+	return listenerErrorString(e.Gateway, e)
+}
+
+func (e ListenerHostnameConflictError) Listener() string {
+	return e.ListenerName
+}
+
+func (e ListenerHostnameConflictError) Reason() string {
+	if e.Hostname == "" {
+		return fmt.Sprintf("listener %q also uses port %d without a hostname", e.ConflictingListenerName, e.Port)
+	}
+	return fmt.Sprintf("listener %q also uses port %d with hostname %q", e.ConflictingListenerName, e.Port, e.Hostname)
+}
+
+// DuplicateListenerNameError is returned when several listeners of a Gateway
+// have the same name.
+type DuplicateListenerNameError struct {
+	Gateway      GKNN
+	ListenerName string
+	// Count is the number of listeners with the name.
+	Count int
+}
+
+func (e DuplicateListenerNameError) Error() string {
+	return listenerErrorString(e.Gateway, e)
+}
+
+func (e DuplicateListenerNameError) Listener() string {
+	return e.ListenerName
+}
+
+func (e DuplicateListenerNameError) Reason() string {
+	return fmt.Sprintf("its name is used by %d listeners", e.Count)
+}
+
+func listenerErrorString(gateway GKNN, e ListenerError) string {
+	return fmt.Sprintf("Listener %q of %v %q is conflicted since %v",
+		e.Listener(), humanReadableKind(gateway), humanReadableName(gateway), e.Reason())
+}
+
+// GatewayAddressConflictError is returned when a Gateway requests a static
+// address which is also requested by another Gateway.
+type GatewayAddressConflictError struct {
+	Gateway            GKNN
+	ConflictingGateway GKNN
+	Address            string
+}
+
+func (e GatewayAddressConflictError) Error() string {
+	return fmt.Sprintf("%v %q requests address %q, which is also requested by %v %q",
+		humanReadableKind(e.Gateway), humanReadableName(e.Gateway), e.Address,
+		humanReadableKind(e.ConflictingGateway), humanReadableName(e.ConflictingGateway))
+}
+
+// humanReadableKind returns a human readable Kind.
+func humanReadableKind(gknn GKNN) string {
+	if gknn.Group != "" {
+		return fmt.Sprintf("%v(.%v)", gknn.Kind, gknn.Group)
+	}
+	return gknn.Kind
+}
+
+// humanReadableName returns a human readable Name.
+func humanReadableName(gknn GKNN) string {
+	if gknn.Namespace != "" {
+		return fmt.Sprintf("%v/%v", gknn.Namespace, gknn.Name)
+	}
+	return gknn.Name
+}
+
+// ReferenceError is implemented by errors which are caused by a reference from
+// one object to another.
+type ReferenceError interface {
+	error
+	Reference() ReferenceFromTo
+}
+
 type ReferenceFromTo struct {
 	// ReferringObject is the "from" object which is referring "to" some other
 	// object.
@@ -49,34 +434,28 @@ type ReferenceFromTo struct {
 	ReferredObject GKNN
 }
 
+// Reference returns the reference which caused the error. This allows errors
+// which embed ReferenceFromTo to implement ReferenceError.
+func (r ReferenceFromTo) Reference() ReferenceFromTo {
+	return r
+}
+
 // referringObjectKind returns a human readable Kind.
 func (r ReferenceFromTo) referringObjectKind() string {
-	if r.ReferringObject.Group != "" {
-		return fmt.Sprintf("%v(.%v)", r.ReferringObject.Kind, r.ReferringObject.Group)
-	}
-	return r.ReferringObject.Kind
+	return humanReadableKind(r.ReferringObject)
 }
 
 // referredObjectKind returns a human readable Kind.
 func (r ReferenceFromTo) referredObjectKind() string {
-	if r.ReferredObject.Group != "" {
-		return fmt.Sprintf("%v(.%v)", r.ReferredObject.Kind, r.ReferredObject.Group)
-	}
-	return r.ReferredObject.Kind
+	return humanReadableKind(r.ReferredObject)
 }
 
 // referringObjectName returns a human readable Name.
 func (r ReferenceFromTo) referringObjectName() string {
-	if r.ReferringObject.Namespace != "" {
-		return fmt.Sprintf("%v/%v", r.ReferringObject.Namespace, r.ReferringObject.Name)
-	}
-	return r.ReferringObject.Name
+	return humanReadableName(r.ReferringObject)
 }
 
 // referredObjectName returns a human readable Name.
 func (r ReferenceFromTo) referredObjectName() string {
-	if r.ReferredObject.Namespace != "" {
-		return fmt.Sprintf("%v/%v", r.ReferredObject.Namespace, r.ReferredObject.Name)
-	}
-	return r.ReferredObject.Name
+	return humanReadableName(r.ReferredObject)
 }
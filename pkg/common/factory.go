@@ -17,6 +17,7 @@ limitations under the License.
 package common //nolint:revive
 
 import (
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/resource"
 )
@@ -24,6 +25,7 @@ import (
 type Factory interface {
 	NewBuilder() *resource.Builder
 	KubeConfigNamespace() (string, bool, error)
+	ToRESTMapper() (meta.RESTMapper, error)
 }
 
 type factoryImpl struct {
@@ -41,3 +43,7 @@ func (f *factoryImpl) NewBuilder() *resource.Builder {
 func (f *factoryImpl) KubeConfigNamespace() (string, bool, error) {
 	return f.clientGetter.ToRawKubeConfigLoader().Namespace()
 }
+
+func (f *factoryImpl) ToRESTMapper() (meta.RESTMapper, error) {
+	return f.clientGetter.ToRESTMapper()
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common //nolint:revive
+
+import (
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+)
+
+// FetchCache caches the resources listed by GroupKindFetchers, so that the
+// resources of a GroupKind are listed only once within a command, even when
+// they are requested concurrently. Lists within a namespace are served from the
+// list across all namespaces when it is available.
+type FetchCache struct {
+	mu      sync.Mutex
+	entries map[fetchCacheKey]*fetchCacheEntry
+
+	// hits, misses and listDuration are reported by LogMetrics.
+	hits         int
+	misses       int
+	listDuration time.Duration
+}
+
+type fetchCacheKey struct {
+	gk        schema.GroupKind
+	namespace string
+}
+
+type fetchCacheEntry struct {
+	// done is closed once the list completes.
+	done      chan struct{}
+	resources []*unstructured.Unstructured
+	err       error
+}
+
+func NewFetchCache() *FetchCache {
+	return &FetchCache{entries: make(map[fetchCacheKey]*fetchCacheEntry)}
+}
+
+// get returns copies of the resources of the GroupKind in the namespace. list
+// is called only if the resources are not already cached or being listed.
+// Failed lists are not cached.
+func (c *FetchCache) get(gk schema.GroupKind, namespace string, list func() ([]*unstructured.Unstructured, error)) ([]*unstructured.Unstructured, error) {
+	c.mu.Lock()
+	entry, filter := c.entries[fetchCacheKey{gk, metav1.NamespaceAll}], namespace != metav1.NamespaceAll
+	if entry == nil {
+		entry, filter = c.entries[fetchCacheKey{gk, namespace}], false
+	}
+	if entry != nil {
+		c.hits++
+		c.mu.Unlock()
+		<-entry.done
+		if entry.err != nil {
+			return nil, entry.err
+		}
+		klog.V(3).InfoS("Using cached resources", "groupKind", gk, "namespace", namespace)
+		return copyResources(entry.resources, namespace, filter), nil
+	}
+	entry = &fetchCacheEntry{done: make(chan struct{})}
+	key := fetchCacheKey{gk, namespace}
+	c.entries[key] = entry
+	c.misses++
+	c.mu.Unlock()
+
+	start := time.Now()
+	entry.resources, entry.err = list()
+	c.mu.Lock()
+	c.listDuration += time.Since(start)
+	if entry.err != nil {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+	close(entry.done)
+
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return copyResources(entry.resources, namespace, false), nil
+}
+
+// LogMetrics logs how many lists were served from the cache, and the total
+// time spent listing resources.
+func (c *FetchCache) LogMetrics() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	klog.V(2).InfoS("Fetch cache metrics", "lists", c.misses, "cacheHits", c.hits, "listDuration", c.listDuration)
+}
+
+// copyResources returns deep copies of the resources, so that callers sharing
+// the cache can not observe modifications made by each other. If filter is
+// true, only resources within the namespace (or cluster scoped resources) are
+// returned.
+func copyResources(resources []*unstructured.Unstructured, namespace string, filter bool) []*unstructured.Unstructured {
+	var result []*unstructured.Unstructured
+	for _, resource := range resources {
+		if filter && resource.GetNamespace() != namespace && resource.GetNamespace() != "" {
+			continue
+		}
+		result = append(result, resource.DeepCopy())
+	}
+	return result
+}
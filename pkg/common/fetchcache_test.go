@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common //nolint:revive
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestFetchCache(t *testing.T) {
+	newService := func(namespace, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("Service")
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		return u
+	}
+	services := []*unstructured.Unstructured{
+		newService("ns1", "svc-1"),
+		newService("ns2", "svc-2"),
+	}
+
+	var lists atomic.Int32
+	list := func() ([]*unstructured.Unstructured, error) {
+		lists.Add(1)
+		return services, nil
+	}
+
+	cache := NewFetchCache()
+
+	// Concurrent requests for the same GroupKind should result in a single
+	// list.
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			got, err := cache.get(ServiceGK, "", list)
+			if err != nil {
+				t.Errorf("get() failed: %v", err)
+				return
+			}
+			if diff := cmp.Diff(services, got); diff != "" {
+				t.Errorf("get() returned unexpected resources (-want, +got):\n%v", diff)
+			}
+		})
+	}
+	wg.Wait()
+	if got := lists.Load(); got != 1 {
+		t.Errorf("list called %v times, want 1", got)
+	}
+
+	// Namespaced requests should be served from the list across all
+	// namespaces.
+	got, err := cache.get(ServiceGK, "ns2", list)
+	if err != nil {
+		t.Fatalf("get() failed: %v", err)
+	}
+	if diff := cmp.Diff(services[1:], got); diff != "" {
+		t.Errorf("get() returned unexpected resources (-want, +got):\n%v", diff)
+	}
+	if got := lists.Load(); got != 1 {
+		t.Errorf("list called %v times, want 1", got)
+	}
+
+	// Returned resources are copies, so modifying them should not affect the
+	// cache.
+	got[0].SetName("modified")
+	got, err = cache.get(ServiceGK, "ns2", list)
+	if err != nil {
+		t.Fatalf("get() failed: %v", err)
+	}
+	if got[0].GetName() != "svc-2" {
+		t.Errorf("get() returned %q, want %q", got[0].GetName(), "svc-2")
+	}
+
+	// Failed lists should not be cached.
+	failures := 0
+	failingList := func() ([]*unstructured.Unstructured, error) {
+		failures++
+		return nil, errors.New("list failed")
+	}
+	for range 2 {
+		if _, err := cache.get(GatewayGK, "", failingList); err == nil {
+			t.Errorf("get() succeeded, want error")
+		}
+	}
+	if failures != 2 {
+		t.Errorf("failing list called %v times, want 2", failures)
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common //nolint:revive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// ObjectChange describes a change to an object observed by an
+// InformerGroupKindFetcher.
+type ObjectChange struct {
+	Type watch.EventType
+	// Object is the current state of the object, or its last known state if
+	// it was deleted.
+	Object *unstructured.Unstructured
+	// OldObject is the previous state of the object. It is only set for
+	// modifications.
+	OldObject *unstructured.Unstructured
+}
+
+var _ GroupKindFetcher = (*InformerGroupKindFetcher)(nil)
+
+// informerSyncPollInterval is the interval at which Fetch checks whether the
+// informer has synced.
+const informerSyncPollInterval = 10 * time.Millisecond
+
+// InformerGroupKindFetcher is a GroupKindFetcher backed by informers. The
+// informer for a GroupKind is started the first time the GroupKind is fetched,
+// and subsequent fetches are served from its cache. Any changes to the objects
+// of the watched GroupKinds are reported through the onChange callback, and
+// errors encountered while watching are reported through the onError callback.
+// The informers keep retrying after such errors.
+type InformerGroupKindFetcher struct {
+	ctx      context.Context
+	factory  Factory
+	onChange func(ObjectChange)
+	onError  func(schema.GroupKind, error)
+
+	mu        sync.Mutex
+	informers map[schema.GroupKind]*groupKindInformer
+}
+
+type groupKindInformer struct {
+	informer cache.SharedIndexInformer
+	ctx      context.Context
+	cancel   context.CancelFunc
+
+	// failed is closed if listing the objects fails before the informer has
+	// synced, in which case err contains the error.
+	failed   chan struct{}
+	failOnce sync.Once
+	err      error
+}
+
+// NewInformerGroupKindFetcher returns an InformerGroupKindFetcher whose
+// informers run until ctx is cancelled.
+func NewInformerGroupKindFetcher(ctx context.Context, factory Factory, onChange func(ObjectChange), onError func(schema.GroupKind, error)) *InformerGroupKindFetcher {
+	return &InformerGroupKindFetcher{
+		ctx:       ctx,
+		factory:   factory,
+		onChange:  onChange,
+		onError:   onError,
+		informers: make(map[schema.GroupKind]*groupKindInformer),
+	}
+}
+
+func (f *InformerGroupKindFetcher) Fetch(gk schema.GroupKind) ([]*unstructured.Unstructured, error) {
+	if gk == ListenerGK {
+		gateways, err := f.Fetch(GatewayGK)
+		if err != nil {
+			return nil, err
+		}
+		return listenersFromGateways(gateways)
+	}
+
+	// Similar to the defaultGroupKindFetcher, kinds which are not installed in
+	// the cluster are treated as having no resources. They are not watched,
+	// so installing them later will not be noticed.
+	restMapper, err := f.factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	if _, mappingErr := restMapper.RESTMapping(gk); mappingErr != nil {
+		if !meta.IsNoMatchError(mappingErr) {
+			return nil, mappingErr
+		}
+		klog.V(3).InfoS("Resource type not found in the server, skipping watch", "groupKind", gk)
+		return nil, nil
+	}
+
+	i := f.informerFor(gk)
+	err = wait.PollUntilContextCancel(i.ctx, informerSyncPollInterval, true, func(context.Context) (bool, error) {
+		select {
+		case <-i.failed:
+			return false, i.err
+		default:
+			return i.informer.HasSynced(), nil
+		}
+	})
+	if err != nil {
+		f.mu.Lock()
+		delete(f.informers, gk)
+		f.mu.Unlock()
+		i.cancel()
+		return nil, err
+	}
+
+	var result []*unstructured.Unstructured
+	for _, obj := range i.informer.GetStore().List() {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			result = append(result, u.DeepCopy())
+		}
+	}
+	slices.SortFunc(result, func(a, b *unstructured.Unstructured) int {
+		if c := strings.Compare(a.GetNamespace(), b.GetNamespace()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return result, nil
+}
+
+// informerFor returns the informer for the GroupKind, starting it if needed.
+func (f *InformerGroupKindFetcher) informerFor(gk schema.GroupKind) *groupKindInformer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if i, ok := f.informers[gk]; ok {
+		return i
+	}
+
+	ctx, cancel := context.WithCancel(f.ctx)
+	i := &groupKindInformer{
+		informer: cache.NewSharedIndexInformer(f.newListWatch(gk), &unstructured.Unstructured{}, 0, cache.Indexers{}),
+		ctx:      ctx,
+		cancel:   cancel,
+		failed:   make(chan struct{}),
+	}
+	_ = i.informer.SetWatchErrorHandlerWithContext(func(ctx context.Context, r *cache.Reflector, err error) {
+		if !i.informer.HasSynced() {
+			i.failOnce.Do(func() {
+				i.err = fmt.Errorf("failed to watch %v: %w", gk, err)
+				close(i.failed)
+			})
+			return
+		}
+		if f.onError == nil {
+			cache.DefaultWatchErrorHandler(ctx, r, err)
+			return
+		}
+		f.onError(gk, err)
+	})
+	_, _ = i.informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj any, isInInitialList bool) {
+			if isInInitialList {
+				return
+			}
+			f.notify(ObjectChange{Type: watch.Added, Object: toUnstructured(obj)})
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			oldU, newU := toUnstructured(oldObj), toUnstructured(newObj)
+			if oldU != nil && newU != nil && oldU.GetResourceVersion() != "" && oldU.GetResourceVersion() == newU.GetResourceVersion() {
+				return
+			}
+			f.notify(ObjectChange{Type: watch.Modified, Object: newU, OldObject: oldU})
+		},
+		DeleteFunc: func(obj any) {
+			f.notify(ObjectChange{Type: watch.Deleted, Object: toUnstructured(obj)})
+		},
+	})
+	f.informers[gk] = i
+
+	klog.V(3).InfoS("Starting informer", "groupKind", gk)
+	go i.informer.RunWithContext(i.ctx)
+	return i
+}
+
+func (f *InformerGroupKindFetcher) notify(change ObjectChange) {
+	if change.Object == nil || f.onChange == nil {
+		return
+	}
+	f.onChange(change)
+}
+
+// newListWatch lists and watches the objects of the GroupKind across all
+// namespaces through resource builders, so that it works with any Factory.
+func (f *InformerGroupKindFetcher) newListWatch(gk schema.GroupKind) cache.ListerWatcher {
+	resourceArg := fmt.Sprintf("%v.%v", gk.Kind, gk.Group)
+	lw := &cache.ListWatch{
+		ListWithContextFunc: func(context.Context, metav1.ListOptions) (runtime.Object, error) {
+			return f.factory.NewBuilder().
+				Unstructured().
+				AllNamespaces(true).
+				ResourceTypeOrNameArgs(true, resourceArg).
+				Do().
+				Object()
+		},
+		WatchFuncWithContext: func(_ context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			return f.factory.NewBuilder().
+				Unstructured().
+				AllNamespaces(true).
+				ResourceTypeOrNameArgs(true, resourceArg).
+				Do().
+				Watch(options.ResourceVersion)
+		},
+	}
+	// The resource builders can only list and watch, so the informers should
+	// not attempt streaming lists.
+	return cache.ToListWatcherWithWatchListSemantics(lw, watchListUnsupported{})
+}
+
+type watchListUnsupported struct{}
+
+func (watchListUnsupported) IsWatchListSemanticsUnSupported() bool { return true }
+
+func toUnstructured(obj any) *unstructured.Unstructured {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	return u.DeepCopy()
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common //nolint:revive
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// listenerNameSeparator separates the Gateway name from the listener name in
+// the name of a Listener object. Since '#' is not allowed in Kubernetes object
+// names, the combined name can always be split back unambiguously.
+const listenerNameSeparator = "#"
+
+// ListenerName returns the name used for the Listener object corresponding to
+// the listener of the given Gateway, eg. "my-gateway#http".
+func ListenerName(gatewayName string, listenerName gatewayv1.SectionName) string {
+	return gatewayName + listenerNameSeparator + string(listenerName)
+}
+
+// SplitListenerName splits the name of a Listener object into the name of the
+// Gateway and the name of the listener within the Gateway.
+func SplitListenerName(name string) (gatewayName string, listenerName gatewayv1.SectionName) {
+	gatewayName, section, _ := strings.Cut(name, listenerNameSeparator)
+	return gatewayName, gatewayv1.SectionName(section)
+}
+
+// ListenerGKNN returns the GKNN of the Listener object corresponding to the
+// listener of the given Gateway.
+func ListenerGKNN(gateway types.NamespacedName, listenerName gatewayv1.SectionName) GKNN {
+	return GKNN{
+		Group:     ListenerGK.Group,
+		Kind:      ListenerGK.Kind,
+		Namespace: gateway.Namespace,
+		Name:      ListenerName(gateway.Name, listenerName),
+	}
+}
+
+// ListenersFromGateway returns a Listener object for each listener within the
+// Gateway. Listeners are not real API resources; these objects only exist so
+// that listeners can be addressed individually within the topology. The
+// object contains the listener's spec under "spec" and its status (if any)
+// under "status".
+func ListenersFromGateway(gatewayUnstructured *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	gateway := &gatewayv1.Gateway{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(gatewayUnstructured.UnstructuredContent(), gateway); err != nil {
+		return nil, fmt.Errorf("failed to convert unstructured Gateway to structured: %v", err)
+	}
+
+	statusByName := make(map[gatewayv1.SectionName]*gatewayv1.ListenerStatus)
+	for i := range gateway.Status.Listeners {
+		statusByName[gateway.Status.Listeners[i].Name] = &gateway.Status.Listeners[i]
+	}
+
+	var result []*unstructured.Unstructured
+	for i := range gateway.Spec.Listeners {
+		listener := &gateway.Spec.Listeners[i]
+		spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(listener)
+		if err != nil {
+			return nil, err
+		}
+
+		u := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+		u.SetGroupVersionKind(ListenerGK.WithVersion("v1"))
+		u.SetNamespace(gateway.GetNamespace())
+		u.SetName(ListenerName(gateway.GetName(), listener.Name))
+		u.SetCreationTimestamp(gateway.GetCreationTimestamp())
+		if listenerStatus, ok := statusByName[listener.Name]; ok {
+			status, err := runtime.DefaultUnstructuredConverter.ToUnstructured(listenerStatus)
+			if err != nil {
+				return nil, err
+			}
+			u.Object["status"] = status
+		}
+		result = append(result, u)
+	}
+	return result, nil
+}
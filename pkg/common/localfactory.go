@@ -0,0 +1,446 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common //nolint:revive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/klog/v2"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// localResource describes an API resource which is served by the localFactory.
+type localResource struct {
+	resource   string
+	kind       string
+	namespaced bool
+}
+
+// builtinLocalResources are the resources which are always served by the
+// localFactory, even if the manifests contain no objects of these kinds, so
+// that listing them returns an empty list instead of failing.
+var builtinLocalResources = map[schema.GroupVersion][]localResource{
+	corev1.SchemeGroupVersion: {
+		{resource: "namespaces", kind: "Namespace", namespaced: false},
+		{resource: "services", kind: "Service", namespaced: true},
+		{resource: "secrets", kind: "Secret", namespaced: true},
+		{resource: "configmaps", kind: "ConfigMap", namespaced: true},
+		{resource: "events", kind: "Event", namespaced: true},
+	},
+	apiextensionsv1.SchemeGroupVersion: {
+		{resource: "customresourcedefinitions", kind: "CustomResourceDefinition", namespaced: false},
+	},
+	gatewayv1.SchemeGroupVersion: {
+		{resource: "gatewayclasses", kind: GatewayClassGK.Kind, namespaced: false},
+		{resource: "gateways", kind: GatewayGK.Kind, namespaced: true},
+		{resource: "httproutes", kind: HTTPRouteGK.Kind, namespaced: true},
+		{resource: "grpcroutes", kind: GRPCRouteGK.Kind, namespaced: true},
+	},
+	gatewayv1beta1.SchemeGroupVersion: {
+		{resource: "referencegrants", kind: ReferenceGrantGK.Kind, namespaced: true},
+	},
+}
+
+// clusterScopedKinds are well known cluster scoped kinds. Objects of kinds
+// which are neither builtin, nor defined by a CRD within the manifests, are
+// assumed to be namespaced unless they are listed here.
+var clusterScopedKinds = map[schema.GroupKind]bool{
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                       true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}: true,
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:   true,
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                                 true,
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                             true,
+	{Group: "", Kind: "PersistentVolume"}:                                           true,
+	{Group: "", Kind: "Node"}:                                                       true,
+}
+
+type localFactory struct {
+	namespace string
+
+	// resourcesByGV contains the resources served for each GroupVersion. This
+	// is used to serve the discovery endpoints.
+	resourcesByGV map[schema.GroupVersion][]localResource
+	// resourcesByGK is used to find the resource for objects in the manifests.
+	resourcesByGK map[schema.GroupKind]localResource
+	// objects contains the objects for each resource, irrespective of their
+	// version.
+	objects map[schema.GroupResource][]*unstructured.Unstructured
+
+	restConfig      *rest.Config
+	discoveryClient discovery.CachedDiscoveryInterface
+	restMapper      meta.RESTMapper
+}
+
+// NewLocalFactory returns a Factory which serves the objects from the given
+// files or directories instead of talking to an API server. Directories are
+// read recursively.
+//
+// Namespaced objects which do not specify a namespace are placed in the given
+// namespace, just like they would be when applied with kubectl. Kinds defined
+// by CustomResourceDefinitions within the manifests are served as well, which
+// allows discovering policy CRDs. Namespaces which are used by the objects but
+// are not defined in the manifests are assumed to exist.
+func NewLocalFactory(filenames []string, namespace string) (Factory, error) {
+	f := &localFactory{
+		namespace:     namespace,
+		resourcesByGV: make(map[schema.GroupVersion][]localResource),
+		resourcesByGK: make(map[schema.GroupKind]localResource),
+		objects:       make(map[schema.GroupResource][]*unstructured.Unstructured),
+	}
+	for gv, resources := range builtinLocalResources {
+		for _, r := range resources {
+			f.addResource(gv, r)
+		}
+	}
+
+	objects, err := readLocalObjects(filenames)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the kinds defined by CRDs first so that objects of those kinds
+	// are served with the correct resource name and scope.
+	for _, u := range objects {
+		if u.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: apiextensionsv1.GroupName, Kind: "CustomResourceDefinition"}) {
+			continue
+		}
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), crd); err != nil { //nolint:govet
+			return nil, fmt.Errorf("failed to convert unstructured CustomResourceDefinition %q: %v", u.GetName(), err)
+		}
+		for _, crdVersion := range crd.Spec.Versions {
+			f.addResource(schema.GroupVersion{Group: crd.Spec.Group, Version: crdVersion.Name}, localResource{
+				resource:   crd.Spec.Names.Plural,
+				kind:       crd.Spec.Names.Kind,
+				namespaced: crd.Spec.Scope == apiextensionsv1.NamespaceScoped,
+			})
+		}
+	}
+
+	namespaces := map[string]bool{}
+	definedNamespaces := map[string]bool{}
+	for _, u := range objects {
+		gvk := u.GroupVersionKind()
+		r, ok := f.resourcesByGK[gvk.GroupKind()]
+		if !ok {
+			plural, _ := meta.UnsafeGuessKindToResource(gvk)
+			r = localResource{
+				resource:   plural.Resource,
+				kind:       gvk.Kind,
+				namespaced: !clusterScopedKinds[gvk.GroupKind()],
+			}
+		}
+		f.addResource(gvk.GroupVersion(), r)
+
+		if r.namespaced {
+			if u.GetNamespace() == "" {
+				u.SetNamespace(namespace)
+			}
+			namespaces[u.GetNamespace()] = true
+		} else {
+			u.SetNamespace("")
+		}
+		if gvk.GroupKind() == NamespaceGK {
+			definedNamespaces[u.GetName()] = true
+		}
+		gr := schema.GroupResource{Group: gvk.Group, Resource: r.resource}
+		f.objects[gr] = append(f.objects[gr], u)
+	}
+	for ns := range namespaces {
+		if definedNamespaces[ns] {
+			continue
+		}
+		klog.V(3).InfoS("Namespace not found in manifests, assuming it exists", "namespace", ns)
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+		u.SetName(ns)
+		gr := schema.GroupResource{Resource: "namespaces"}
+		f.objects[gr] = append(f.objects[gr], u)
+	}
+
+	f.restConfig = &rest.Config{
+		Host:      "http://gwctl.local",
+		Transport: f,
+		// Disable client side rate limiting since no requests reach a server.
+		QPS: -1,
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(f.restConfig)
+	if err != nil {
+		return nil, err
+	}
+	f.discoveryClient = memory.NewMemCacheClient(discoveryClient)
+	f.restMapper = restmapper.NewDeferredDiscoveryRESTMapper(f.discoveryClient)
+
+	return f, nil
+}
+
+// readLocalObjects reads all objects from the given files or directories.
+func readLocalObjects(filenames []string) ([]*unstructured.Unstructured, error) {
+	if len(filenames) == 0 {
+		return nil, nil
+	}
+	infos, err := resource.NewLocalBuilder().
+		Unstructured().
+		FilenameParam(false, &resource.FilenameOptions{Filenames: filenames, Recursive: true}).
+		Flatten().
+		ContinueOnError().
+		Do().
+		Infos()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*unstructured.Unstructured
+	for _, info := range infos {
+		o, err := runtime.DefaultUnstructuredConverter.ToUnstructured(info.Object)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &unstructured.Unstructured{Object: o})
+	}
+	return result, nil
+}
+
+func (f *localFactory) addResource(gv schema.GroupVersion, r localResource) {
+	if !slices.Contains(f.resourcesByGV[gv], r) {
+		f.resourcesByGV[gv] = append(f.resourcesByGV[gv], r)
+	}
+	gk := schema.GroupKind{Group: gv.Group, Kind: r.kind}
+	if _, ok := f.resourcesByGK[gk]; !ok {
+		f.resourcesByGK[gk] = r
+	}
+}
+
+func (f *localFactory) NewBuilder() *resource.Builder {
+	return resource.NewBuilder(f)
+}
+
+func (f *localFactory) KubeConfigNamespace() (string, bool, error) {
+	return f.namespace, false, nil
+}
+
+func (f *localFactory) ToRESTConfig() (*rest.Config, error) {
+	return rest.CopyConfig(f.restConfig), nil
+}
+
+func (f *localFactory) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	return f.discoveryClient, nil
+}
+
+func (f *localFactory) ToRESTMapper() (meta.RESTMapper, error) {
+	return f.restMapper, nil
+}
+
+// RoundTrip serves the requests made by the REST and discovery clients from
+// the objects read from the manifests. Only GET requests are supported, and
+// objects cannot be watched.
+func (f *localFactory) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return localResponse(http.StatusMethodNotAllowed, statusFailure(http.StatusMethodNotAllowed, metav1.StatusReasonMethodNotAllowed,
+			fmt.Sprintf("%v is not supported when using local manifests", req.Method)))
+	}
+	if req.URL.Query().Get("watch") == "true" {
+		return localResponse(http.StatusMethodNotAllowed, statusFailure(http.StatusMethodNotAllowed, metav1.StatusReasonMethodNotAllowed,
+			"watch is not supported when using local manifests"))
+	}
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	var gv schema.GroupVersion
+	switch {
+	case len(segments) == 1 && segments[0] == "api":
+		return localResponse(http.StatusOK, &metav1.APIVersions{
+			TypeMeta: metav1.TypeMeta{Kind: "APIVersions"},
+			Versions: []string{corev1.SchemeGroupVersion.Version},
+		})
+	case len(segments) == 1 && segments[0] == "apis":
+		return localResponse(http.StatusOK, f.apiGroupList())
+	case len(segments) >= 2 && segments[0] == "api":
+		gv, segments = schema.GroupVersion{Version: segments[1]}, segments[2:]
+	case len(segments) >= 3 && segments[0] == "apis":
+		gv, segments = schema.GroupVersion{Group: segments[1], Version: segments[2]}, segments[3:]
+	default:
+		return localNotFound(req.URL.Path)
+	}
+
+	if len(segments) == 0 {
+		return localResponse(http.StatusOK, f.apiResourceList(gv))
+	}
+
+	var namespace, resourceName, name string
+	if segments[0] == "namespaces" && len(segments) >= 3 {
+		namespace, segments = segments[1], segments[2:]
+	}
+	resourceName = segments[0]
+	if len(segments) >= 2 {
+		name = segments[1]
+	}
+	if !slices.ContainsFunc(f.resourcesByGV[gv], func(r localResource) bool { return r.resource == resourceName }) {
+		return localNotFound(req.URL.Path)
+	}
+	gr := schema.GroupResource{Group: gv.Group, Resource: resourceName}
+
+	if name != "" {
+		for _, u := range f.objects[gr] {
+			if u.GetNamespace() == namespace && u.GetName() == name {
+				return localResponse(http.StatusOK, u)
+			}
+		}
+		return localResponse(http.StatusNotFound, statusFailure(http.StatusNotFound, metav1.StatusReasonNotFound,
+			fmt.Sprintf("%v %q not found", gr, name)))
+	}
+
+	labelSelector, err := labels.Parse(req.URL.Query().Get("labelSelector"))
+	if err != nil {
+		return localResponse(http.StatusBadRequest, statusFailure(http.StatusBadRequest, metav1.StatusReasonBadRequest, err.Error()))
+	}
+	fieldSelector, err := fields.ParseSelector(req.URL.Query().Get("fieldSelector"))
+	if err != nil {
+		return localResponse(http.StatusBadRequest, statusFailure(http.StatusBadRequest, metav1.StatusReasonBadRequest, err.Error()))
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion(gv.String())
+	list.SetKind("List")
+	for _, u := range f.objects[gr] {
+		if namespace != "" && u.GetNamespace() != namespace {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(u.GetLabels())) || !matchesFieldSelector(u, fieldSelector) {
+			continue
+		}
+		list.Items = append(list.Items, *u)
+	}
+	return localResponse(http.StatusOK, list)
+}
+
+func (f *localFactory) apiGroupList() *metav1.APIGroupList {
+	versionsByGroup := map[string][]string{}
+	for gv := range f.resourcesByGV {
+		if gv.Group == "" {
+			continue
+		}
+		versionsByGroup[gv.Group] = append(versionsByGroup[gv.Group], gv.Version)
+	}
+
+	result := &metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"}}
+	for group, versions := range versionsByGroup {
+		// Prefer the most stable version, as per the Kubernetes versioning
+		// conventions.
+		slices.SortFunc(versions, func(a, b string) int { return -version.CompareKubeAwareVersionStrings(a, b) })
+		apiGroup := metav1.APIGroup{Name: group}
+		for _, version := range versions {
+			apiGroup.Versions = append(apiGroup.Versions, metav1.GroupVersionForDiscovery{
+				GroupVersion: schema.GroupVersion{Group: group, Version: version}.String(),
+				Version:      version,
+			})
+		}
+		apiGroup.PreferredVersion = apiGroup.Versions[0]
+		result.Groups = append(result.Groups, apiGroup)
+	}
+	slices.SortFunc(result.Groups, func(a, b metav1.APIGroup) int { return strings.Compare(a.Name, b.Name) })
+	return result
+}
+
+func (f *localFactory) apiResourceList(gv schema.GroupVersion) *metav1.APIResourceList {
+	result := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: gv.String(),
+	}
+	for _, r := range f.resourcesByGV[gv] {
+		result.APIResources = append(result.APIResources, metav1.APIResource{
+			Name:       r.resource,
+			Namespaced: r.namespaced,
+			Kind:       r.kind,
+			Verbs:      metav1.Verbs{"get", "list"},
+		})
+	}
+	return result
+}
+
+// matchesFieldSelector evaluates the field selector against the fields of the
+// object, like `metadata.name` or `involvedObject.uid`.
+func matchesFieldSelector(u *unstructured.Unstructured, selector fields.Selector) bool {
+	for _, requirement := range selector.Requirements() {
+		value, _, _ := unstructured.NestedFieldNoCopy(u.Object, strings.Split(requirement.Field, ".")...)
+		matches := value != nil && fmt.Sprintf("%v", value) == requirement.Value
+		if requirement.Operator == selection.NotEquals {
+			matches = !matches
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
+
+func statusFailure(code int32, reason metav1.StatusReason, message string) *metav1.Status {
+	return &metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Code:     code,
+		Reason:   reason,
+		Message:  message,
+	}
+}
+
+func localNotFound(path string) (*http.Response, error) {
+	return localResponse(http.StatusNotFound, statusFailure(http.StatusNotFound, metav1.StatusReasonNotFound,
+		fmt.Sprintf("the server could not find the requested resource %v", path)))
+}
+
+func localResponse(code int, body any) (*http.Response, error) {
+	var data []byte
+	var err error
+	if u, ok := body.(runtime.Unstructured); ok {
+		data, err = json.Marshal(u.UnstructuredContent())
+	} else {
+		data, err = json.Marshal(body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Type": []string{runtime.ContentTypeJSON}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+	}, nil
+}
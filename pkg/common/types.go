@@ -36,11 +36,24 @@ var (
 	GatewayClassGK   schema.GroupKind = schema.GroupKind{Group: gatewayv1.GroupName, Kind: "GatewayClass"}
 	GatewayGK        schema.GroupKind = schema.GroupKind{Group: gatewayv1.GroupName, Kind: "Gateway"}
 	HTTPRouteGK      schema.GroupKind = schema.GroupKind{Group: gatewayv1.GroupName, Kind: "HTTPRoute"}
+	GRPCRouteGK      schema.GroupKind = schema.GroupKind{Group: gatewayv1.GroupName, Kind: "GRPCRoute"}
+	TLSRouteGK       schema.GroupKind = schema.GroupKind{Group: gatewayv1.GroupName, Kind: "TLSRoute"}
+	TCPRouteGK       schema.GroupKind = schema.GroupKind{Group: gatewayv1.GroupName, Kind: "TCPRoute"}
+	UDPRouteGK       schema.GroupKind = schema.GroupKind{Group: gatewayv1.GroupName, Kind: "UDPRoute"}
 	NamespaceGK      schema.GroupKind = schema.GroupKind{Group: corev1.GroupName, Kind: "Namespace"}
 	ServiceGK        schema.GroupKind = schema.GroupKind{Group: corev1.GroupName, Kind: "Service"}
 	ReferenceGrantGK schema.GroupKind = schema.GroupKind{Group: gatewayv1beta1.GroupName, Kind: "ReferenceGrant"}
+	SecretGK         schema.GroupKind = schema.GroupKind{Group: corev1.GroupName, Kind: "Secret"}
+	ConfigMapGK      schema.GroupKind = schema.GroupKind{Group: corev1.GroupName, Kind: "ConfigMap"}
 	PolicyGK         schema.GroupKind = schema.GroupKind{Group: gwctlPolicyGroup, Kind: "Policy"}
 	PolicyCRDGK      schema.GroupKind = schema.GroupKind{Group: gwctlPolicyGroup, Kind: "PolicyCRD"}
+	// ListenerGK is the GroupKind used for the listeners of a Gateway, so that
+	// they can be represented as separate nodes. It is not a real API resource.
+	ListenerGK schema.GroupKind = schema.GroupKind{Group: gwctlPolicyGroup, Kind: "Listener"}
+	// BackendTLSPolicyGK is also handled as a policy by the PolicyManager. It
+	// is additionally modelled in the topology to validate its references to
+	// CA certificates.
+	BackendTLSPolicyGK schema.GroupKind = schema.GroupKind{Group: gatewayv1.GroupName, Kind: "BackendTLSPolicy"}
 )
 
 type GKNN struct {
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendweightvalidator
+
+import (
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+)
+
+const (
+	ExtensionName = "backendweightvalidator"
+)
+
+var metadataKey = topology.NewMetadataKey[*NodeMetadata](ExtensionName)
+
+type Extension struct{}
+
+func NewExtension() *Extension {
+	return &Extension{}
+}
+
+func (a *Extension) Name() string {
+	return ExtensionName
+}
+
+func (a *Extension) Dependencies() []string {
+	return nil
+}
+
+// Execute reports the rules of HTTPRoutes which have backendRefs, all of
+// which have a weight of 0. Rules without any backendRefs (like rules which
+// only redirect requests) are not reported.
+func (a *Extension) Execute(graph *topology.Graph) error {
+	graph.RemoveMetadata(ExtensionName)
+	for _, routeNode := range graph.Nodes[common.HTTPRouteGK] {
+		if routeNode.Depth > graph.MaxDepth {
+			klog.V(3).InfoS("Not validating HTTPRoute since it's depth is greater than the max depth",
+				"extension", ExtensionName, "routeNode.Depth", routeNode.Depth, "MaxDepth", graph.MaxDepth,
+			)
+			continue
+		}
+		for _, rule := range topologygw.HTTPRouteNode(routeNode).Rules() {
+			if len(rule.Backends) == 0 || rule.TotalWeight() != 0 {
+				continue
+			}
+			weightErr := common.ZeroWeightRouteRuleError{Route: routeNode.GKNN(), Rule: rule.Name()}
+			if err := a.putErrorInNode(routeNode, weightErr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Extension) putErrorInNode(node *topology.Node, weightErr error) error {
+	data, err := metadataKey.GetOrSet(node, &NodeMetadata{
+		Errors: make([]error, 0),
+	})
+	if err != nil {
+		return err
+	}
+	data.Errors = append(data.Errors, weightErr)
+	klog.V(3).InfoS("Found route rule with zero total weight", "extension", ExtensionName, "node", node.GKNN(), "err", weightErr)
+	return nil
+}
+
+type NodeMetadata struct {
+	Errors []error
+}
+
+var _ topology.AnalysisResult = (*NodeMetadata)(nil)
+
+func (m *NodeMetadata) AnalysisErrors() []error {
+	if m == nil {
+		return nil
+	}
+	return m.Errors
+}
+
+func Access(node *topology.Node) (*NodeMetadata, error) {
+	return metadataKey.Get(node)
+}
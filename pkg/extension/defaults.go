@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extension
+
+import (
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/extension/backendweightvalidator"
+	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
+	"sigs.k8s.io/gwctl/pkg/extension/gatewayconflictvalidator"
+	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
+	"sigs.k8s.io/gwctl/pkg/extension/notfoundrefvalidator"
+	"sigs.k8s.io/gwctl/pkg/extension/parentrefvalidator"
+	"sigs.k8s.io/gwctl/pkg/extension/policyconflictvalidator"
+	"sigs.k8s.io/gwctl/pkg/extension/refgrantvalidator"
+	"sigs.k8s.io/gwctl/pkg/extension/routeattachmentvalidator"
+	"sigs.k8s.io/gwctl/pkg/extension/shadowedrulevalidator"
+	"sigs.k8s.io/gwctl/pkg/policymanager"
+)
+
+// DefaultExtensions returns the extensions which compute the policies and the
+// analysis errors of the nodes. The fetcher is used by the validators which
+// need objects from outside the graph, like all ReferenceGrants and Gateways.
+func DefaultExtensions(fetcher common.GroupKindFetcher, policyManager *policymanager.PolicyManager) []Extension {
+	return []Extension{
+		directlyattachedpolicy.NewExtension(policyManager),
+		gatewayeffectivepolicy.NewExtension(),
+		refgrantvalidator.NewExtension(refgrantvalidator.NewDefaultReferenceGrantFetcher(fetcher)),
+		gatewayconflictvalidator.NewExtension(gatewayconflictvalidator.NewDefaultGatewayFetcher(fetcher)),
+		notfoundrefvalidator.NewExtension(),
+		parentrefvalidator.NewExtension(),
+		policyconflictvalidator.NewExtension(),
+		routeattachmentvalidator.NewExtension(),
+		shadowedrulevalidator.NewExtension(),
+		backendweightvalidator.NewExtension(),
+	}
+}
@@ -17,19 +17,17 @@ limitations under the License.
 package directlyattachedpolicy
 
 import (
-	"fmt"
-
-	"k8s.io/klog/v2"
-
 	"sigs.k8s.io/gwctl/pkg/common"
 	"sigs.k8s.io/gwctl/pkg/policymanager"
 	"sigs.k8s.io/gwctl/pkg/topology"
 )
 
 const (
-	extensionName = "DirectlyAttachedPolicy"
+	ExtensionName = "directlyattachedpolicy"
 )
 
+var metadataKey = topology.NewMetadataKey[map[common.GKNN]*policymanager.Policy](ExtensionName)
+
 type Extension struct {
 	policyManager *policymanager.PolicyManager
 }
@@ -38,8 +36,16 @@ func NewExtension(policyManager *policymanager.PolicyManager) *Extension {
 	return &Extension{policyManager: policyManager}
 }
 
+func (a *Extension) Name() string {
+	return ExtensionName
+}
+
+func (a *Extension) Dependencies() []string {
+	return nil
+}
+
 func (a *Extension) Execute(graph *topology.Graph) error {
-	graph.RemoveMetadata(extensionName)
+	graph.RemoveMetadata(ExtensionName)
 	for _, policy := range a.policyManager.GetPolicies() {
 		for _, targetRef := range policy.TargetRefs {
 			gk := targetRef.GroupKind()
@@ -51,14 +57,7 @@ func (a *Extension) Execute(graph *topology.Graph) error {
 			}
 
 			node := graph.Nodes[gk][nn]
-			if node.Metadata == nil {
-				node.Metadata = map[string]any{}
-			}
-			if node.Metadata[extensionName] == nil {
-				node.Metadata[extensionName] = map[common.GKNN]*policymanager.Policy{}
-			}
-
-			data, err := Access(node)
+			data, err := metadataKey.GetOrSet(node, map[common.GKNN]*policymanager.Policy{})
 			if err != nil {
 				return err
 			}
@@ -69,14 +68,5 @@ func (a *Extension) Execute(graph *topology.Graph) error {
 }
 
 func Access(node *topology.Node) (map[common.GKNN]*policymanager.Policy, error) {
-	rawData, ok := node.Metadata[extensionName]
-	if !ok || rawData == nil {
-		klog.V(3).InfoS(fmt.Sprintf("no data found in node for %v", extensionName), "node", node.GKNN())
-		return nil, nil
-	}
-	data, ok := rawData.(map[common.GKNN]*policymanager.Policy)
-	if !ok {
-		return nil, fmt.Errorf("unable to perform type assertion for %v in node %v", extensionName, node.GKNN())
-	}
-	return data, nil
+	return metadataKey.Get(node)
 }
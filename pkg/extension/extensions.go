@@ -16,16 +16,38 @@ limitations under the License.
 
 package extension
 
-import "sigs.k8s.io/gwctl/pkg/topology"
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/gwctl/pkg/topology"
+)
 
 type Extension interface {
+	// Name uniquely identifies the extension. It is also the key under which
+	// the extension stores its results in the node metadata.
+	Name() string
+	// Dependencies returns the names of the extensions whose results are used
+	// by this extension, and which therefore need to execute before it.
+	Dependencies() []string
 	Execute(*topology.Graph) error
 }
 
-// TODO: Scope of improvement in the future involves:
-//   - Making executions parallel, when there are blocking operations.
-//   - Defining dependent extensions to determine their relative order.
+// ExecuteAll executes the extensions on the graph. Extensions execute after
+// all their dependencies, and extensions which do not depend on each other
+// execute concurrently. An error is returned without executing any extension if
+// a dependency is not among the extensions, or if the dependencies form a
+// cycle.
 func ExecuteAll(graph *topology.Graph, extensions ...Extension) error {
+	if err := validateDependencies(extensions); err != nil {
+		return err
+	}
+
 	for _, nodes := range graph.Nodes {
 		for _, node := range nodes {
 			if node.Metadata == nil {
@@ -34,11 +56,75 @@ func ExecuteAll(graph *topology.Graph, extensions ...Extension) error {
 		}
 	}
 
+	// done is closed for an extension once it has finished executing.
+	done := make(map[string]chan struct{})
+	for _, extension := range extensions {
+		done[extension.Name()] = make(chan struct{})
+	}
+	var failed atomic.Bool
+
+	var g errgroup.Group
+	for _, extension := range extensions {
+		g.Go(func() error {
+			defer close(done[extension.Name()])
+			for _, dependency := range extension.Dependencies() {
+				<-done[dependency]
+			}
+			// Skip the remaining extensions once any extension fails.
+			if failed.Load() {
+				return nil
+			}
+			klog.V(3).InfoS("Executing extension", "extension", extension.Name())
+			if err := extension.Execute(graph); err != nil {
+				failed.Store(true)
+				return err
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// validateDependencies checks that the extensions have unique names, and that
+// their dependencies are among the extensions and do not form a cycle.
+func validateDependencies(extensions []Extension) error {
+	byName := make(map[string]Extension)
+	for _, extension := range extensions {
+		if _, ok := byName[extension.Name()]; ok {
+			return fmt.Errorf("extension %q is specified more than once", extension.Name())
+		}
+		byName[extension.Name()] = extension
+	}
+	for _, extension := range extensions {
+		for _, dependency := range extension.Dependencies() {
+			if _, ok := byName[dependency]; !ok {
+				return fmt.Errorf("extension %q depends on extension %q which is not being executed", extension.Name(), dependency)
+			}
+		}
+	}
+
+	// Repeatedly remove the extensions whose dependencies have all been
+	// removed. Any remaining extensions are part of, or depend on, a cycle.
+	remaining := make(map[string][]string)
 	for _, extension := range extensions {
-		err := extension.Execute(graph)
-		if err != nil {
-			return err
+		remaining[extension.Name()] = extension.Dependencies()
+	}
+	for progress := true; progress; {
+		progress = false
+		for name, dependencies := range remaining {
+			if !slices.ContainsFunc(dependencies, func(dependency string) bool { _, ok := remaining[dependency]; return ok }) {
+				delete(remaining, name)
+				progress = true
+			}
+		}
+	}
+	if len(remaining) != 0 {
+		var names []string
+		for name := range remaining {
+			names = append(names, name)
 		}
+		slices.Sort(names)
+		return fmt.Errorf("extensions have cyclic dependencies: %v", strings.Join(names, ", "))
 	}
 	return nil
 }
@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extension
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"sigs.k8s.io/gwctl/pkg/topology"
+)
+
+type fakeExtension struct {
+	name         string
+	dependencies []string
+	err          error
+
+	// executed records the names of the executed extensions in order.
+	executed *[]string
+	mu       *sync.Mutex
+}
+
+func (e *fakeExtension) Name() string           { return e.name }
+func (e *fakeExtension) Dependencies() []string { return e.dependencies }
+
+func (e *fakeExtension) Execute(*topology.Graph) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	*e.executed = append(*e.executed, e.name)
+	return e.err
+}
+
+func TestExecuteAll(t *testing.T) {
+	testCases := []struct {
+		name       string
+		extensions []*fakeExtension
+		// wantBefore lists pairs of extensions, where the first must execute
+		// before the second.
+		wantBefore   [][2]string
+		wantExecuted []string
+		wantErr      bool
+	}{
+		{
+			name: "dependencies execute first",
+			extensions: []*fakeExtension{
+				{name: "c", dependencies: []string{"a", "b"}},
+				{name: "b", dependencies: []string{"a"}},
+				{name: "a"},
+				{name: "d"},
+			},
+			wantBefore:   [][2]string{{"a", "b"}, {"a", "c"}, {"b", "c"}},
+			wantExecuted: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "missing dependency",
+			extensions: []*fakeExtension{
+				{name: "a", dependencies: []string{"b"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cyclic dependencies",
+			extensions: []*fakeExtension{
+				{name: "a", dependencies: []string{"c"}},
+				{name: "b", dependencies: []string{"a"}},
+				{name: "c", dependencies: []string{"b"}},
+				{name: "d"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate names",
+			extensions: []*fakeExtension{
+				{name: "a"},
+				{name: "a"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "dependents of a failed extension do not execute",
+			extensions: []*fakeExtension{
+				{name: "a", err: errors.New("failed")},
+				{name: "b", dependencies: []string{"a"}},
+			},
+			wantExecuted: []string{"a"},
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var executed []string
+			var mu sync.Mutex
+			var extensions []Extension
+			for _, e := range tc.extensions {
+				e.executed, e.mu = &executed, &mu
+				extensions = append(extensions, e)
+			}
+
+			err := ExecuteAll(&topology.Graph{}, extensions...)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ExecuteAll() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			for _, pair := range tc.wantBefore {
+				if slices.Index(executed, pair[0]) > slices.Index(executed, pair[1]) {
+					t.Errorf("Extension %q executed before %q: %v", pair[1], pair[0], executed)
+				}
+			}
+			got := slices.Sorted(slices.Values(executed))
+			if !slices.Equal(got, tc.wantExecuted) {
+				t.Errorf("ExecuteAll() executed %v, want %v", got, tc.wantExecuted)
+			}
+		})
+	}
+}
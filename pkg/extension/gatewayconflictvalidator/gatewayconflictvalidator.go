@@ -0,0 +1,305 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayconflictvalidator
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	ExtensionName = "gatewayconflictvalidator"
+)
+
+var metadataKey = topology.NewMetadataKey[*NodeMetadata](ExtensionName)
+
+type Extension struct {
+	fetcher gatewayFetcher
+}
+
+func NewExtension(fetcher gatewayFetcher) *Extension {
+	return &Extension{fetcher: fetcher}
+}
+
+func (a *Extension) Name() string {
+	return ExtensionName
+}
+
+func (a *Extension) Dependencies() []string {
+	return nil
+}
+
+// Execute validates that the listeners of each Gateway do not conflict with
+// each other, and that the static addresses requested by the Gateway are not
+// requested by any other Gateway. Addresses are compared against all the
+// Gateways returned by the fetcher, since the conflicting Gateway need not be
+// part of the Graph.
+func (a *Extension) Execute(graph *topology.Graph) error {
+	graph.RemoveMetadata(ExtensionName)
+
+	var gateways []*gatewayv1.Gateway
+	if len(graph.Nodes[common.GatewayGK]) != 0 {
+		var err error
+		gateways, err = a.fetcher.FetchGateways()
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(gateways, func(a, b *gatewayv1.Gateway) int {
+			return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+		})
+	}
+
+	for _, gatewayNode := range topology.SortedNodes(maps.Values(graph.Nodes[common.GatewayGK])) {
+		if gatewayNode.Depth > graph.MaxDepth {
+			klog.V(3).InfoS("Not validating Gateway since it's depth is greater than the max depth",
+				"extension", ExtensionName, "gatewayNode.Depth", gatewayNode.Depth, "MaxDepth", graph.MaxDepth,
+			)
+			continue
+		}
+		gateway := topology.MustAccessObject(gatewayNode, &gatewayv1.Gateway{})
+		for _, conflictErr := range validateListeners(gatewayNode.GKNN(), gateway.Spec.Listeners) {
+			if err := a.putErrorInNode(gatewayNode, conflictErr); err != nil {
+				return err
+			}
+		}
+		for _, conflictErr := range validateAddresses(gatewayNode.GKNN(), gateway, gateways) {
+			if err := a.putErrorInNode(gatewayNode, conflictErr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateListeners returns the conflicts between the listeners of a Gateway.
+// Each listener is reported for the first listener it conflicts with, so both
+// listeners of a conflicting pair are reported.
+func validateListeners(gatewayGKNN common.GKNN, listeners []gatewayv1.Listener) []error {
+	var result []error
+
+	nameCount := make(map[gatewayv1.SectionName]int)
+	for _, listener := range listeners {
+		nameCount[listener.Name]++
+	}
+	reported := make(map[gatewayv1.SectionName]bool)
+	for _, listener := range listeners {
+		if nameCount[listener.Name] > 1 && !reported[listener.Name] {
+			reported[listener.Name] = true
+			result = append(result, common.DuplicateListenerNameError{
+				Gateway:      gatewayGKNN,
+				ListenerName: string(listener.Name),
+				Count:        nameCount[listener.Name],
+			})
+		}
+	}
+
+	for i, listener := range listeners {
+		var protocolErr, hostnameErr error
+		for j, other := range listeners {
+			// Listeners with the same name are already reported as duplicates.
+			if i == j || listener.Name == other.Name || listener.Port != other.Port {
+				continue
+			}
+			if isCustomProtocol(listener.Protocol) || isCustomProtocol(other.Protocol) {
+				continue
+			}
+			if transport(listener.Protocol) != transport(other.Protocol) {
+				continue
+			}
+			if protocolFamily(listener.Protocol) != protocolFamily(other.Protocol) {
+				if protocolErr == nil {
+					protocolErr = common.ListenerProtocolConflictError{
+						Gateway:                 gatewayGKNN,
+						ListenerName:            string(listener.Name),
+						Protocol:                string(listener.Protocol),
+						Port:                    int32(listener.Port),
+						ConflictingListenerName: string(other.Name),
+						ConflictingProtocol:     string(other.Protocol),
+					}
+				}
+				continue
+			}
+			if hostname := effectiveHostname(listener); hostname == effectiveHostname(other) && hostnameErr == nil {
+				hostnameErr = common.ListenerHostnameConflictError{
+					Gateway:                 gatewayGKNN,
+					ListenerName:            string(listener.Name),
+					Hostname:                hostname,
+					Port:                    int32(listener.Port),
+					ConflictingListenerName: string(other.Name),
+				}
+			}
+		}
+		for _, err := range []error{protocolErr, hostnameErr} {
+			if err != nil {
+				result = append(result, err)
+			}
+		}
+	}
+	return result
+}
+
+// isCustomProtocol returns true for implementation specific protocols, like
+// "example.com/protocol", whose compatibility with other protocols is unknown.
+func isCustomProtocol(protocol gatewayv1.ProtocolType) bool {
+	return strings.Contains(string(protocol), "/")
+}
+
+// transport returns the transport protocol used by the protocol. Listeners with
+// different transports can share a port.
+func transport(protocol gatewayv1.ProtocolType) string {
+	if protocol == gatewayv1.UDPProtocolType {
+		return "UDP"
+	}
+	return "TCP"
+}
+
+// protocolFamily groups the protocols which can share a port. HTTPS and TLS
+// listeners can share a port, since both are distinguished through SNI.
+func protocolFamily(protocol gatewayv1.ProtocolType) string {
+	if protocol == gatewayv1.HTTPSProtocolType || protocol == gatewayv1.TLSProtocolType {
+		return string(gatewayv1.TLSProtocolType)
+	}
+	return string(protocol)
+}
+
+// effectiveHostname returns the hostname which distinguishes the listener from
+// other listeners on the same port. The hostname of TCP and UDP listeners is
+// ignored.
+func effectiveHostname(listener gatewayv1.Listener) string {
+	if listener.Hostname == nil || listener.Protocol == gatewayv1.TCPProtocolType || listener.Protocol == gatewayv1.UDPProtocolType {
+		return ""
+	}
+	return string(*listener.Hostname)
+}
+
+// validateAddresses returns the static addresses of the Gateway which are also
+// requested by other Gateways. Each address is reported for the first other
+// Gateway requesting it.
+func validateAddresses(gatewayGKNN common.GKNN, gateway *gatewayv1.Gateway, gateways []*gatewayv1.Gateway) []error {
+	var result []error
+	for _, address := range gateway.Spec.Addresses {
+		for _, other := range gateways {
+			if other.Namespace == gateway.Namespace && other.Name == gateway.Name {
+				continue
+			}
+			if !containsAddress(other.Spec.Addresses, address) {
+				continue
+			}
+			result = append(result, common.GatewayAddressConflictError{
+				Gateway: gatewayGKNN,
+				ConflictingGateway: common.GKNN{
+					Group:     common.GatewayGK.Group,
+					Kind:      common.GatewayGK.Kind,
+					Namespace: other.Namespace,
+					Name:      other.Name,
+				},
+				Address: address.Value,
+			})
+			break
+		}
+	}
+	return result
+}
+
+func containsAddress(addresses []gatewayv1.GatewaySpecAddress, address gatewayv1.GatewaySpecAddress) bool {
+	for _, other := range addresses {
+		if addressType(other) == addressType(address) && other.Value == address.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func addressType(address gatewayv1.GatewaySpecAddress) gatewayv1.AddressType {
+	if address.Type == nil {
+		return gatewayv1.IPAddressType
+	}
+	return *address.Type
+}
+
+func (a *Extension) putErrorInNode(node *topology.Node, conflictErr error) error {
+	data, err := metadataKey.GetOrSet(node, &NodeMetadata{
+		Errors: make([]error, 0),
+	})
+	if err != nil {
+		return err
+	}
+	data.Errors = append(data.Errors, conflictErr)
+	klog.V(3).InfoS("Found Gateway conflict", "extension", ExtensionName, "node", node.GKNN(), "err", conflictErr)
+	return nil
+}
+
+type NodeMetadata struct {
+	Errors []error
+}
+
+var _ topology.AnalysisResult = (*NodeMetadata)(nil)
+
+func (m *NodeMetadata) AnalysisErrors() []error {
+	if m == nil {
+		return nil
+	}
+	return m.Errors
+}
+
+func Access(node *topology.Node) (*NodeMetadata, error) {
+	return metadataKey.Get(node)
+}
+
+type gatewayFetcher interface {
+	FetchGateways() ([]*gatewayv1.Gateway, error)
+}
+
+var _ gatewayFetcher = (*defaultGatewayFetcher)(nil)
+
+type defaultGatewayFetcher struct {
+	fetcher common.GroupKindFetcher
+}
+
+// NewDefaultGatewayFetcher returns a gatewayFetcher which fetches the Gateways
+// of all namespaces through the GroupKindFetcher.
+func NewDefaultGatewayFetcher(fetcher common.GroupKindFetcher) *defaultGatewayFetcher { //nolint:revive
+	return &defaultGatewayFetcher{fetcher: fetcher}
+}
+
+func (f *defaultGatewayFetcher) FetchGateways() ([]*gatewayv1.Gateway, error) {
+	resources, err := f.fetcher.Fetch(common.GatewayGK)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*gatewayv1.Gateway
+	for _, u := range resources {
+		gateway := &gatewayv1.Gateway{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), gateway); err != nil {
+			return nil, fmt.Errorf("converting Gateway from Unstructured to typed: %v", err)
+		}
+		result = append(result, gateway)
+	}
+	return result, nil
+}
@@ -17,9 +17,10 @@ limitations under the License.
 package gatewayeffectivepolicy
 
 import (
-	"fmt"
 	"maps"
+	"slices"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/gwctl/pkg/common"
@@ -30,19 +31,29 @@ import (
 )
 
 const (
-	extensionName = "InheritedPolicy"
+	ExtensionName = "gatewayeffectivepolicy"
 )
 
+var metadataKey = topology.NewMetadataKey[*NodeMetadata](ExtensionName)
+
 type Extension struct{}
 
 func NewExtension() *Extension {
 	return &Extension{}
 }
 
-// Extension calculates the effective policies for all Gateways, HTTPRoutes, and
+func (a *Extension) Name() string {
+	return ExtensionName
+}
+
+func (a *Extension) Dependencies() []string {
+	return []string{directlyattachedpolicy.ExtensionName}
+}
+
+// Extension calculates the effective policies for all Gateways, Routes, and
 // Backends in the Graph.
 func (a *Extension) Execute(graph *topology.Graph) error {
-	graph.RemoveMetadata(extensionName)
+	graph.RemoveMetadata(ExtensionName)
 	if err := a.calculateInheritedPolicies(graph); err != nil {
 		return err
 	}
@@ -50,12 +61,12 @@ func (a *Extension) Execute(graph *topology.Graph) error {
 }
 
 // calculateInheritedPolicies calculates the inherited polices for all Gateways,
-// HTTRoutes, and Backends in the Graph.
+// Routes, and Backends in the Graph.
 func (a *Extension) calculateInheritedPolicies(graph *topology.Graph) error {
 	if err := a.calculateInheritedPoliciesForGateways(graph); err != nil {
 		return err
 	}
-	if err := a.calculateInheritedPoliciesForHTTPRoutes(graph); err != nil {
+	if err := a.calculateInheritedPoliciesForRoutes(graph); err != nil {
 		return err
 	}
 	if err := a.calculateInheritedPoliciesForBackends(graph); err != nil {
@@ -90,47 +101,50 @@ func (a *Extension) calculateInheritedPoliciesForGateways(graph *topology.Graph)
 			maps.Copy(result, filterInheritablePolicies(gatewayClassPoliciesMap))
 		}
 
-		gatewayNode.Metadata[extensionName] = &NodeMetadata{GatewayInheritedPolicies: result}
+		metadataKey.Set(gatewayNode, &NodeMetadata{GatewayInheritedPolicies: result})
 	}
 	return nil
 }
 
-// calculateInheritedPoliciesForHTTPRoutes calculates the inherited policies for
-// all HTTPRoutes present in the Graph.
-func (a *Extension) calculateInheritedPoliciesForHTTPRoutes(graph *topology.Graph) error {
-	for _, httpRouteNode := range graph.Nodes[common.HTTPRouteGK] {
-		result := make(map[common.GKNN]*policymanager.Policy)
-
-		// Policies inherited from HTTPRoute's namespace.
-		namespaceNode := topologygw.HTTPRouteNode(httpRouteNode).Namespace()
-		if namespaceNode != nil {
-			namespacePoliciesMap, err := directlyattachedpolicy.Access(namespaceNode)
-			if err != nil {
-				return err
+// calculateInheritedPoliciesForRoutes calculates the inherited policies for
+// all Routes present in the Graph. All route kinds inherit policies in the
+// same way.
+func (a *Extension) calculateInheritedPoliciesForRoutes(graph *topology.Graph) error {
+	for _, routeGK := range topologygw.RouteGKs {
+		for _, routeNode := range graph.Nodes[routeGK] {
+			result := make(map[common.GKNN]*policymanager.Policy)
+
+			// Policies inherited from Route's namespace.
+			namespaceNode := topologygw.RouteNode(routeNode).Namespace()
+			if namespaceNode != nil {
+				namespacePoliciesMap, err := directlyattachedpolicy.Access(namespaceNode)
+				if err != nil {
+					return err
+				}
+				maps.Copy(result, filterInheritablePolicies(namespacePoliciesMap))
 			}
-			maps.Copy(result, filterInheritablePolicies(namespacePoliciesMap))
-		}
 
-		// Policies inherited from Gateways.
-		for _, gatewayNode := range topologygw.HTTPRouteNode(httpRouteNode).Gateways() {
-			// Add policies inherited by GatewayNode.
-			effPolicyMetadata, err := Access(gatewayNode)
-			if err != nil {
-				return err
-			}
-			if effPolicyMetadata != nil {
-				maps.Copy(result, effPolicyMetadata.GatewayInheritedPolicies)
-			}
+			// Policies inherited from Gateways.
+			for _, gatewayNode := range topologygw.RouteNode(routeNode).Gateways() {
+				// Add policies inherited by GatewayNode.
+				effPolicyMetadata, err := Access(gatewayNode)
+				if err != nil {
+					return err
+				}
+				if effPolicyMetadata != nil {
+					maps.Copy(result, effPolicyMetadata.GatewayInheritedPolicies)
+				}
 
-			// Add inheritable policies directly applied to GatewayNode.
-			gatewayPoliciesMap, err := directlyattachedpolicy.Access(gatewayNode)
-			if err != nil {
-				return err
+				// Add inheritable policies directly applied to GatewayNode.
+				gatewayPoliciesMap, err := directlyattachedpolicy.Access(gatewayNode)
+				if err != nil {
+					return err
+				}
+				maps.Copy(result, filterInheritablePolicies(gatewayPoliciesMap))
 			}
-			maps.Copy(result, filterInheritablePolicies(gatewayPoliciesMap))
-		}
 
-		httpRouteNode.Metadata[extensionName] = &NodeMetadata{HTTPRouteInheritedPolicies: result}
+			metadataKey.Set(routeNode, &NodeMetadata{RouteInheritedPolicies: result})
+		}
 	}
 	return nil
 }
@@ -138,7 +152,7 @@ func (a *Extension) calculateInheritedPoliciesForHTTPRoutes(graph *topology.Grap
 // calculateInheritedPoliciesForBackends calculates the inherited policies for
 // all Backends present in ResourceModel.
 func (a *Extension) calculateInheritedPoliciesForBackends(graph *topology.Graph) error {
-	for _, backendNode := range graph.Nodes[common.ServiceGK] {
+	for _, backendNode := range topologygw.BackendNodes(graph) {
 		result := make(map[common.GKNN]*policymanager.Policy)
 
 		// Policies inherited from Backend's namespace.
@@ -151,26 +165,26 @@ func (a *Extension) calculateInheritedPoliciesForBackends(graph *topology.Graph)
 			maps.Copy(result, filterInheritablePolicies(namespacePoliciesMap))
 		}
 
-		// Policies inherited from HTTPRoutes.
-		for _, httpRouteNode := range topologygw.BackendNode(backendNode).HTTPRoutes() {
-			// Add policies inherited by HTTPRouteNode.
-			effPolicyMetadata, err := Access(httpRouteNode)
+		// Policies inherited from Routes.
+		for _, routeNode := range topologygw.BackendNode(backendNode).Routes() {
+			// Add policies inherited by RouteNode.
+			effPolicyMetadata, err := Access(routeNode)
 			if err != nil {
 				return err
 			}
 			if effPolicyMetadata != nil {
-				maps.Copy(result, effPolicyMetadata.HTTPRouteInheritedPolicies)
+				maps.Copy(result, effPolicyMetadata.RouteInheritedPolicies)
 			}
 
-			// Add inheritable policies directly applied to HTTPRouteNode.
-			httpRoutePoliciesMap, err := directlyattachedpolicy.Access(httpRouteNode)
+			// Add inheritable policies directly applied to RouteNode.
+			routePoliciesMap, err := directlyattachedpolicy.Access(routeNode)
 			if err != nil {
 				return err
 			}
-			maps.Copy(result, filterInheritablePolicies(httpRoutePoliciesMap))
+			maps.Copy(result, filterInheritablePolicies(routePoliciesMap))
 		}
 
-		backendNode.Metadata[extensionName] = &NodeMetadata{BackendInheritedPolicies: result}
+		metadataKey.Set(backendNode, &NodeMetadata{BackendInheritedPolicies: result})
 	}
 	return nil
 }
@@ -192,7 +206,7 @@ func (a *Extension) calculateEffectivePolicies(graph *topology.Graph) error {
 	if err := a.calculateEffectivePoliciesForGateways(graph); err != nil {
 		return err
 	}
-	if err := a.calculateEffectivePoliciesForHTTPRoutes(graph); err != nil {
+	if err := a.calculateEffectivePoliciesForRoutes(graph); err != nil {
 		return err
 	}
 	if err := a.calculateEffectivePoliciesForBackends(graph); err != nil {
@@ -277,91 +291,154 @@ func (a *Extension) calculateEffectivePoliciesForGateways(graph *topology.Graph)
 		}
 		if gatewayNodeMetadata == nil {
 			gatewayNodeMetadata = &NodeMetadata{}
-			gatewayNode.Metadata[extensionName] = gatewayNodeMetadata
+			metadataKey.Set(gatewayNode, gatewayNodeMetadata)
 		}
 		gatewayNodeMetadata.GatewayEffectivePolicies = result
 	}
 	return nil
 }
 
-// calculateEffectivePoliciesForHTTPRoutes calculates the effective policies for
-// each HTTPRoute, taking into account policies from different hierarchies
-// (GatewayClass, Namespace, Gateway, and HTTPRoute).
-func (a *Extension) calculateEffectivePoliciesForHTTPRoutes(graph *topology.Graph) error {
-	for _, httpRouteNode := range graph.Nodes[common.HTTPRouteGK] {
-		result := make(map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy)
-
-		namespaceNode := topologygw.HTTPRouteNode(httpRouteNode).Namespace()
-		if namespaceNode == nil {
-			klog.V(3).InfoS("No Namespace node found for HTTPRoute, skipping effective policy calculation", "httpRoute", httpRouteNode.GKNN())
-			continue
+// calculateEffectivePoliciesForRoutes calculates the effective policies for
+// each Route, taking into account policies from different hierarchies
+// (GatewayClass, Namespace, Gateway, and Route).
+func (a *Extension) calculateEffectivePoliciesForRoutes(graph *topology.Graph) error {
+	for _, routeGK := range topologygw.RouteGKs {
+		for _, routeNode := range graph.Nodes[routeGK] {
+			if err := a.calculateEffectivePoliciesForRoute(routeNode); err != nil {
+				return err
+			}
 		}
+	}
+	return nil
+}
 
-		httpRoutePoliciesMap, err := directlyattachedpolicy.Access(httpRouteNode)
-		if err != nil {
-			return err
-		}
-		namespacePoliciesMap, err := directlyattachedpolicy.Access(namespaceNode)
-		if err != nil {
-			return err
+// calculateEffectivePoliciesForRoute calculates the effective policies for a
+// single Route.
+func (a *Extension) calculateEffectivePoliciesForRoute(routeNode *topology.Node) error {
+	if topologygw.RouteNode(routeNode).Namespace() == nil {
+		klog.V(3).InfoS("No Namespace node found for Route, skipping effective policy calculation", "route", routeNode.GKNN())
+		return nil
+	}
+
+	routePoliciesMap, err := directlyattachedpolicy.Access(routeNode)
+	if err != nil {
+		return err
+	}
+	result, err := routeEffectivePolicies(routeNode, routePoliciesMap)
+	if err != nil {
+		return err
+	}
+
+	routeNodeMetadata, err := Access(routeNode)
+	if err != nil {
+		return err
+	}
+	if routeNodeMetadata == nil {
+		routeNodeMetadata = &NodeMetadata{}
+		metadataKey.Set(routeNode, routeNodeMetadata)
+	}
+	routeNodeMetadata.RouteEffectivePolicies = result
+	return nil
+}
+
+// RouteRuleEffectivePolicies returns the effective policies for the rule of
+// the Route with the given name, partitioned by Gateway. Policies attached to
+// the rule through the sectionName of their targetRefs are more specific than
+// the policies attached to the whole Route, while policies attached to other
+// rules are excluded. The extension must have been executed on the graph.
+func RouteRuleEffectivePolicies(routeNode *topology.Node, ruleName string) (map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy, error) {
+	if topologygw.RouteNode(routeNode).Namespace() == nil {
+		return nil, nil
+	}
+
+	routePoliciesMap, err := directlyattachedpolicy.Access(routeNode)
+	if err != nil {
+		return nil, err
+	}
+	wholeRoutePoliciesMap, rulePoliciesMap := partitionRulePolicies(routeNode.GKNN(), routePoliciesMap, ruleName)
+	return routeEffectivePolicies(routeNode, wholeRoutePoliciesMap, rulePoliciesMap)
+}
+
+// partitionRulePolicies partitions the policies attached to the Route into the
+// policies attached to the whole Route, and the policies attached to the rule
+// with the given name. Policies attached to other rules are dropped.
+func partitionRulePolicies(routeGKNN common.GKNN, policies map[common.GKNN]*policymanager.Policy, ruleName string) (wholeRoutePolicies, rulePolicies map[common.GKNN]*policymanager.Policy) {
+	wholeRoutePolicies = make(map[common.GKNN]*policymanager.Policy)
+	rulePolicies = make(map[common.GKNN]*policymanager.Policy)
+	for gknn, policy := range policies {
+		switch {
+		case policy.IsAttachedToSection(routeGKNN, ""):
+			wholeRoutePolicies[gknn] = policy
+		case policy.IsAttachedToSection(routeGKNN, ruleName):
+			rulePolicies[gknn] = policy
 		}
+	}
+	return wholeRoutePolicies, rulePolicies
+}
+
+// routeEffectivePolicies merges the effective policies of each of the Route's
+// Gateways with the policies of the Route's namespace, followed by the policies
+// attached to the Route. The Route's policies are given as one or more levels,
+// where each level is more specific than the previous one.
+func routeEffectivePolicies(routeNode *topology.Node, routePolicyLevels ...map[common.GKNN]*policymanager.Policy) (map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy, error) {
+	result := make(map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy)
 
-		// Step 1: Aggregate all policies of the HTTPRoute and the
-		// HTTPRoute-namespace.
-		httpRoutePolicies := policymanager.ConvertPoliciesMapToSlice(filterInheritablePolicies(httpRoutePoliciesMap))
-		httpRouteNamespacePolicies := policymanager.ConvertPoliciesMapToSlice(filterInheritablePolicies(namespacePoliciesMap))
+	namespacePoliciesMap, err := directlyattachedpolicy.Access(topologygw.RouteNode(routeNode).Namespace())
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 1: Aggregate all policies of the Route and the Route-namespace.
+	routeNamespacePolicies := policymanager.ConvertPoliciesMapToSlice(filterInheritablePolicies(namespacePoliciesMap))
 
-		// Step 2: Merge HTTPRoute and HTTPRoute-namespace policies by their kind.
-		httpRoutePoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(httpRoutePolicies)
+	// Step 2: Merge Route and Route-namespace policies by their kind.
+	var routePoliciesByKindLevels []map[policymanager.PolicyCrdID]*policymanager.Policy
+	for _, routePoliciesMap := range routePolicyLevels {
+		routePolicies := policymanager.ConvertPoliciesMapToSlice(filterInheritablePolicies(routePoliciesMap))
+		routePoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(routePolicies) //nolint:govet
 		if err != nil {
-			return err
+			return nil, err
 		}
-		httpRouteNamespacePoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(httpRouteNamespacePolicies)
+		routePoliciesByKindLevels = append(routePoliciesByKindLevels, routePoliciesByKind)
+	}
+	routeNamespacePoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(routeNamespacePolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Loop through all Gateways and merge policies for each Gateway.
+	// End result is we get policies partitioned by each Gateway.
+	for gatewayGKNN, gatewayNode := range topologygw.RouteNode(routeNode).Gateways() {
+		gatewayNodeMetadata, err := Access(gatewayNode) //nolint:govet
 		if err != nil {
-			return err
+			return nil, err
 		}
+		gatewayPoliciesByKind := gatewayNodeMetadata.GatewayEffectivePolicies
 
-		// Step 3: Loop through all Gateways and merge policies for each Gateway.
-		// End result is we get policies partitioned by each Gateway.
-		for gatewayGKNN, gatewayNode := range topologygw.HTTPRouteNode(httpRouteNode).Gateways() {
-			gatewayNodeMetadata, err := Access(gatewayNode) //nolint:govet
-			if err != nil {
-				return err
-			}
-			gatewayPoliciesByKind := gatewayNodeMetadata.GatewayEffectivePolicies
-
-			// Merge all hierarchial policies.
-			mergedPolicies, err := policymanager.MergePoliciesOfDifferentHierarchy(gatewayPoliciesByKind, httpRouteNamespacePoliciesByKind)
-			if err != nil {
-				return err
-			}
+		// Merge all hierarchial policies.
+		mergedPolicies, err := policymanager.MergePoliciesOfDifferentHierarchy(gatewayPoliciesByKind, routeNamespacePoliciesByKind)
+		if err != nil {
+			return nil, err
+		}
 
-			mergedPolicies, err = policymanager.MergePoliciesOfDifferentHierarchy(mergedPolicies, httpRoutePoliciesByKind)
+		for _, routePoliciesByKind := range routePoliciesByKindLevels {
+			mergedPolicies, err = policymanager.MergePoliciesOfDifferentHierarchy(mergedPolicies, routePoliciesByKind)
 			if err != nil {
-				return err
+				return nil, err
 			}
-
-			result[gatewayGKNN] = mergedPolicies
 		}
 
-		httpRouteNodeMetadata, err := Access(httpRouteNode)
-		if err != nil {
-			return err
-		}
-		if httpRouteNodeMetadata == nil {
-			httpRouteNodeMetadata = &NodeMetadata{}
-			httpRouteNode.Metadata[extensionName] = httpRouteNodeMetadata
-		}
-		httpRouteNodeMetadata.HTTPRouteEffectivePolicies = result
+		result[gatewayGKNN] = mergedPolicies
 	}
-	return nil
+
+	return result, nil
 }
 
 // calculateEffectivePoliciesForBackends calculates the effective policies for
 // each Backend, considering policies from different hierarchies (GatewayClass,
 // Namespace, Gateway, HTTPRoute, and Backend).
 func (a *Extension) calculateEffectivePoliciesForBackends(graph *topology.Graph) error {
-	for _, backendNode := range graph.Nodes[common.ServiceGK] {
+	for _, backendNode := range topologygw.BackendNodes(graph) {
 		result := make(map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy)
 
 		namespaceNode := topologygw.BackendNode(backendNode).Namespace()
@@ -393,20 +470,20 @@ func (a *Extension) calculateEffectivePoliciesForBackends(graph *topology.Graph)
 			return err
 		}
 
-		// Step 3: Loop through all HTTPRoutes and get their effective policies. Merge
+		// Step 3: Loop through all Routes and get their effective policies. Merge
 		// effective policies such that we get policies partitioned by Gateway.
-		for _, httpRouteNode := range topologygw.BackendNode(backendNode).HTTPRoutes() {
-			httpRouteNodeMetadata, err := Access(httpRouteNode) //nolint:govet
+		for _, routeNode := range topologygw.BackendNode(backendNode).Routes() {
+			routeNodeMetadata, err := Access(routeNode) //nolint:govet
 			if err != nil {
 				return err
 			}
-			if httpRouteNodeMetadata == nil {
-				klog.V(3).InfoS("No effective policy metadata found for HTTPRoute, skipping", "httpRoute", httpRouteNode.GKNN())
+			if routeNodeMetadata == nil {
+				klog.V(3).InfoS("No effective policy metadata found for Route, skipping", "route", routeNode.GKNN())
 				continue
 			}
-			httpRoutePoliciesByGateway := httpRouteNodeMetadata.HTTPRouteEffectivePolicies
+			routePoliciesByGateway := routeNodeMetadata.RouteEffectivePolicies
 
-			for gatewayID, policies := range httpRoutePoliciesByGateway {
+			for gatewayID, policies := range routePoliciesByGateway {
 				result[gatewayID], err = policymanager.MergePoliciesOfSameHierarchy(result[gatewayID], policies)
 				if err != nil {
 					return err
@@ -439,32 +516,206 @@ func (a *Extension) calculateEffectivePoliciesForBackends(graph *topology.Graph)
 		}
 		if backendNodeMetadata == nil {
 			backendNodeMetadata = &NodeMetadata{}
-			backendNode.Metadata[extensionName] = backendNodeMetadata
+			metadataKey.Set(backendNode, backendNodeMetadata)
 		}
 		backendNodeMetadata.BackendEffectivePolicies = result
 	}
 	return nil
 }
 
-type NodeMetadata struct {
-	GatewayInheritedPolicies   map[common.GKNN]*policymanager.Policy
-	HTTPRouteInheritedPolicies map[common.GKNN]*policymanager.Policy
-	BackendInheritedPolicies   map[common.GKNN]*policymanager.Policy
+// InheritedPolicyEffects returns the Gateways, Routes, and Backends in the
+// Graph on which each inherited policy takes effect through inheritance.
+func InheritedPolicyEffects(graph *topology.Graph) (map[common.GKNN][]common.GKNN, error) {
+	result := map[common.GKNN][]common.GKNN{}
+	addEffects := func(node *topology.Node, policies map[common.GKNN]*policymanager.Policy) {
+		for policyGKNN := range policies {
+			result[policyGKNN] = append(result[policyGKNN], node.GKNN())
+		}
+	}
+
+	for _, gatewayNode := range graph.Nodes[common.GatewayGK] {
+		metadata, err := Access(gatewayNode)
+		if err != nil {
+			return nil, err
+		}
+		if metadata != nil {
+			addEffects(gatewayNode, metadata.GatewayInheritedPolicies)
+		}
+	}
+	for _, routeGK := range topologygw.RouteGKs {
+		for _, routeNode := range graph.Nodes[routeGK] {
+			metadata, err := Access(routeNode)
+			if err != nil {
+				return nil, err
+			}
+			if metadata != nil {
+				addEffects(routeNode, metadata.RouteInheritedPolicies)
+			}
+		}
+	}
+	for _, backendNode := range topologygw.BackendNodes(graph) {
+		metadata, err := Access(backendNode)
+		if err != nil {
+			return nil, err
+		}
+		if metadata != nil {
+			addEffects(backendNode, metadata.BackendInheritedPolicies)
+		}
+	}
+	return result, nil
+}
 
-	GatewayEffectivePolicies   map[policymanager.PolicyCrdID]*policymanager.Policy
-	HTTPRouteEffectivePolicies map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy
-	BackendEffectivePolicies   map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy
+// PolicyChainLink is an inheritable policy which is merged to calculate the
+// effective policies of an object, along with the object in the hierarchy to
+// which the policy is attached.
+type PolicyChainLink struct {
+	Policy *policymanager.Policy
+	Target common.GKNN
 }
 
-func Access(node *topology.Node) (*NodeMetadata, error) {
-	rawData, ok := node.Metadata[extensionName]
-	if !ok || rawData == nil {
-		klog.V(3).InfoS(fmt.Sprintf("no data found in node for %v", extensionName), "node", node.GKNN())
-		return nil, nil
+// PolicyChain returns the inheritable policies which are merged to calculate
+// the effective policies of the Gateway, Route or Backend, partitioned by
+// Gateway. The policies are ordered from the top of the hierarchy (the
+// GatewayClass) down to the node itself. For Routes, a non-empty ruleName
+// excludes the policies attached to other rules of the Route.
+func PolicyChain(node *topology.Node, ruleName string) (map[common.GKNN][]PolicyChainLink, error) {
+	result := make(map[common.GKNN][]PolicyChainLink)
+	gk := node.GKNN().GroupKind()
+	switch {
+	case gk == common.GatewayGK:
+		chain, err := gatewayPolicyChain(node)
+		if err != nil {
+			return nil, err
+		}
+		result[node.GKNN()] = chain
+
+	case topologygw.IsRoute(gk):
+		for gatewayGKNN, gatewayNode := range topologygw.RouteNode(node).Gateways() {
+			chain, err := routePolicyChain(node, gatewayNode, ruleName)
+			if err != nil {
+				return nil, err
+			}
+			result[gatewayGKNN] = chain
+		}
+
+	default:
+		// Routes are at the same level of the hierarchy, so the policies of all
+		// Routes using the same Gateway are part of the chain for the Gateway.
+		for _, routeNode := range topologygw.BackendNode(node).Routes() {
+			for gatewayGKNN, gatewayNode := range topologygw.RouteNode(routeNode).Gateways() {
+				chain, err := routePolicyChain(routeNode, gatewayNode, "")
+				if err != nil {
+					return nil, err
+				}
+				for _, link := range chain {
+					if !slices.ContainsFunc(result[gatewayGKNN], func(existing PolicyChainLink) bool {
+						return existing.Policy.GKNN() == link.Policy.GKNN() && existing.Target == link.Target
+					}) {
+						result[gatewayGKNN] = append(result[gatewayGKNN], link)
+					}
+				}
+			}
+		}
+		links, err := attachedPolicyLinks(topologygw.BackendNode(node).Namespace(), node)
+		if err != nil {
+			return nil, err
+		}
+		for gatewayGKNN := range result {
+			result[gatewayGKNN] = append(result[gatewayGKNN], links...)
+		}
+	}
+	return result, nil
+}
+
+// gatewayPolicyChain returns the policies merged to calculate the effective
+// policies of the Gateway.
+func gatewayPolicyChain(gatewayNode *topology.Node) ([]PolicyChainLink, error) {
+	return attachedPolicyLinks(
+		topologygw.GatewayNode(gatewayNode).GatewayClass(),
+		topologygw.GatewayNode(gatewayNode).Namespace(),
+		gatewayNode,
+	)
+}
+
+// routePolicyChain returns the policies merged to calculate the effective
+// policies of the Route (or the rule of the Route with the given name) for the
+// Gateway.
+func routePolicyChain(routeNode, gatewayNode *topology.Node, ruleName string) ([]PolicyChainLink, error) {
+	chain, err := gatewayPolicyChain(gatewayNode)
+	if err != nil {
+		return nil, err
+	}
+	links, err := attachedPolicyLinks(topologygw.RouteNode(routeNode).Namespace(), routeNode)
+	if err != nil {
+		return nil, err
 	}
-	data, ok := rawData.(*NodeMetadata)
-	if !ok {
-		return nil, fmt.Errorf("unable to perform type assertion for %v in node %v", extensionName, node.GKNN())
+	if ruleName == "" {
+		return append(chain, links...), nil
 	}
-	return data, nil
+
+	// Policies attached to the rule are more specific than the policies
+	// attached to the whole Route, so they come last.
+	var ruleLinks []PolicyChainLink
+	for _, link := range links {
+		switch {
+		case link.Target != routeNode.GKNN() || link.Policy.IsAttachedToSection(link.Target, ""):
+			chain = append(chain, link)
+		case link.Policy.IsAttachedToSection(link.Target, ruleName):
+			ruleLinks = append(ruleLinks, link)
+		}
+	}
+	return append(chain, ruleLinks...), nil
+}
+
+// attachedPolicyLinks returns the inheritable policies attached to each of the
+// nodes, in the order of the nodes. Nil nodes are skipped.
+func attachedPolicyLinks(nodes ...*topology.Node) ([]PolicyChainLink, error) {
+	var result []PolicyChainLink
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		policiesMap, err := directlyattachedpolicy.Access(node)
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range policymanager.ConvertPoliciesMapToSlice(filterInheritablePolicies(policiesMap)) {
+			result = append(result, PolicyChainLink{Policy: policy, Target: node.GKNN()})
+		}
+	}
+	return result, nil
+}
+
+type NodeMetadata struct {
+	GatewayInheritedPolicies map[common.GKNN]*policymanager.Policy
+	// RouteInheritedPolicies are the inherited policies of a Route of any
+	// kind.
+	RouteInheritedPolicies   map[common.GKNN]*policymanager.Policy
+	BackendInheritedPolicies map[common.GKNN]*policymanager.Policy
+
+	GatewayEffectivePolicies map[policymanager.PolicyCrdID]*policymanager.Policy
+	// RouteEffectivePolicies are the effective policies of a Route of any
+	// kind, partitioned by Gateway.
+	RouteEffectivePolicies   map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy
+	BackendEffectivePolicies map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy
+}
+
+// InheritedPolicies returns the inherited policies for a Gateway, Route or
+// Backend of the given kind.
+func (m *NodeMetadata) InheritedPolicies(gk schema.GroupKind) map[common.GKNN]*policymanager.Policy {
+	if m == nil {
+		return nil
+	}
+	switch {
+	case gk == common.GatewayGK:
+		return m.GatewayInheritedPolicies
+	case topologygw.IsRoute(gk):
+		return m.RouteInheritedPolicies
+	default:
+		return m.BackendInheritedPolicies
+	}
+}
+
+func Access(node *topology.Node) (*NodeMetadata, error) {
+	return metadataKey.Get(node)
 }
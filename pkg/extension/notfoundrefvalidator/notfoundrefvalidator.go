@@ -17,7 +17,6 @@ limitations under the License.
 package notfoundrefvalidator
 
 import (
-	"fmt"
 	"slices"
 
 	"k8s.io/klog/v2"
@@ -27,26 +26,36 @@ import (
 )
 
 const (
-	extensionName = "NotFoundReferenceValidator"
+	ExtensionName = "notfoundrefvalidator"
 )
 
+var metadataKey = topology.NewMetadataKey[*NodeMetadata](ExtensionName)
+
 type Extension struct{}
 
 func NewExtension() *Extension {
 	return &Extension{}
 }
 
+func (a *Extension) Name() string {
+	return ExtensionName
+}
+
+func (a *Extension) Dependencies() []string {
+	return nil
+}
+
 func (a *Extension) Execute(graph *topology.Graph) error {
-	graph.RemoveMetadata(extensionName)
+	graph.RemoveMetadata(ExtensionName)
 	for _, relation := range graph.Relations {
 		for _, fromNode := range graph.Nodes[relation.From] {
 			if fromNode.Depth > graph.MaxDepth {
 				klog.V(3).InfoS("Not validating resource since it's depth is greater than the max depth",
-					"extension", extensionName, "resource", fromNode.GKNN(), "depth", fromNode.Depth, "MaxDepth", graph.MaxDepth,
+					"extension", ExtensionName, "resource", fromNode.GKNN(), "depth", fromNode.Depth, "MaxDepth", graph.MaxDepth,
 				)
 			}
 
-			for _, toNodeGKNN := range relation.NeighborFunc(fromNode.Object) {
+			for _, toNodeGKNN := range relation.Neighbors(fromNode.Object, graph) {
 				err := common.ReferenceToNonExistentResourceError{ReferenceFromTo: common.ReferenceFromTo{
 					ReferringObject: fromNode.GKNN(),
 					ReferredObject:  toNodeGKNN,
@@ -73,16 +82,9 @@ func (a *Extension) Execute(graph *topology.Graph) error {
 }
 
 func (a *Extension) putErrorInNode(node *topology.Node, notFoundErr error) error {
-	if node.Metadata == nil {
-		node.Metadata = map[string]any{}
-	}
-	if node.Metadata[extensionName] == nil {
-		node.Metadata[extensionName] = &NodeMetadata{
-			Errors: make([]error, 0),
-		}
-	}
-
-	data, err := Access(node)
+	data, err := metadataKey.GetOrSet(node, &NodeMetadata{
+		Errors: make([]error, 0),
+	})
 	if err != nil {
 		return err
 	}
@@ -99,15 +101,15 @@ type NodeMetadata struct {
 	Errors []error
 }
 
-func Access(node *topology.Node) (*NodeMetadata, error) {
-	rawData, ok := node.Metadata[extensionName]
-	if !ok || rawData == nil {
-		klog.V(3).InfoS(fmt.Sprintf("no data found in node for %v", extensionName), "node", node.GKNN())
-		return nil, nil
-	}
-	data, ok := rawData.(*NodeMetadata)
-	if !ok {
-		return nil, fmt.Errorf("unable to perform type assertion for %v in node %v", extensionName, node.GKNN())
+var _ topology.AnalysisResult = (*NodeMetadata)(nil)
+
+func (m *NodeMetadata) AnalysisErrors() []error {
+	if m == nil {
+		return nil
 	}
-	return data, nil
+	return m.Errors
+}
+
+func Access(node *topology.Node) (*NodeMetadata, error) {
+	return metadataKey.Get(node)
 }
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parentrefvalidator
+
+import (
+	"slices"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	ExtensionName = "parentrefvalidator"
+)
+
+var metadataKey = topology.NewMetadataKey[*NodeMetadata](ExtensionName)
+
+type Extension struct{}
+
+func NewExtension() *Extension {
+	return &Extension{}
+}
+
+func (a *Extension) Name() string {
+	return ExtensionName
+}
+
+func (a *Extension) Dependencies() []string {
+	return nil
+}
+
+// Execute validates that the sectionName and port of every parentRef of the
+// Routes in the Graph match some listener of the referenced Gateway.
+// References to Gateways which do not exist are reported by the
+// notfoundrefvalidator instead.
+func (a *Extension) Execute(graph *topology.Graph) error {
+	graph.RemoveMetadata(ExtensionName)
+	for _, routeGK := range topologygw.RouteGKs {
+		for _, routeNode := range graph.Nodes[routeGK] {
+			if routeNode.Depth > graph.MaxDepth {
+				klog.V(3).InfoS("Not validating Route since it's depth is greater than the max depth",
+					"extension", ExtensionName, "routeNode.Depth", routeNode.Depth, "MaxDepth", graph.MaxDepth,
+				)
+				continue
+			}
+			if err := a.validateRoute(graph, routeNode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Extension) validateRoute(graph *topology.Graph, routeNode *topology.Node) error {
+	for _, parentRef := range topologygw.RouteParentRefs(routeNode.Object) {
+		if parentRef.SectionName == nil && parentRef.Port == nil {
+			continue
+		}
+		if !topologygw.IsGatewayParentRef(parentRef) {
+			continue
+		}
+		gatewayNN := topologygw.ParentRefGatewayNamespacedName(routeNode.GKNN().Namespace, parentRef)
+		gatewayNode := graph.Nodes[common.GatewayGK][gatewayNN]
+		if gatewayNode == nil {
+			continue
+		}
+		gateway := topology.MustAccessObject(gatewayNode, &gatewayv1.Gateway{})
+
+		matched := slices.ContainsFunc(gateway.Spec.Listeners, func(listener gatewayv1.Listener) bool {
+			return topologygw.ParentRefMatchesListener(parentRef, listener)
+		})
+		if matched {
+			continue
+		}
+
+		err := common.ParentRefMatchesNoListenerError{
+			ReferenceFromTo: common.ReferenceFromTo{
+				ReferringObject: routeNode.GKNN(),
+				ReferredObject:  gatewayNode.GKNN(),
+			},
+		}
+		if parentRef.SectionName != nil {
+			err.SectionName = string(*parentRef.SectionName)
+		}
+		if parentRef.Port != nil {
+			err.Port = int32(*parentRef.Port)
+		}
+		if err := a.putErrorInNode(routeNode, err); err != nil {
+			return err
+		}
+		klog.V(1).Info(err)
+	}
+	return nil
+}
+
+func (a *Extension) putErrorInNode(node *topology.Node, parentRefErr error) error {
+	data, err := metadataKey.GetOrSet(node, &NodeMetadata{
+		Errors: make([]error, 0),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(data.Errors, parentRefErr) {
+		data.Errors = append(data.Errors, parentRefErr)
+	}
+	return nil
+}
+
+type NodeMetadata struct {
+	Errors []error
+}
+
+var _ topology.AnalysisResult = (*NodeMetadata)(nil)
+
+func (m *NodeMetadata) AnalysisErrors() []error {
+	if m == nil {
+		return nil
+	}
+	return m.Errors
+}
+
+func Access(node *topology.Node) (*NodeMetadata, error) {
+	return metadataKey.Get(node)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyconflictvalidator
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
+	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
+	"sigs.k8s.io/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gwctl/pkg/topology"
+)
+
+const (
+	ExtensionName = "policyconflictvalidator"
+)
+
+var metadataKey = topology.NewMetadataKey[*NodeMetadata](ExtensionName)
+
+type Extension struct{}
+
+func NewExtension() *Extension {
+	return &Extension{}
+}
+
+func (a *Extension) Name() string {
+	return ExtensionName
+}
+
+func (a *Extension) Dependencies() []string {
+	return []string{directlyattachedpolicy.ExtensionName, gatewayeffectivepolicy.ExtensionName}
+}
+
+// Execute reports policies of the same kind which set the same fields for an
+// object, since only one of them takes effect for each of those fields. The
+// policies conflict if they both target the object, in which case the policy
+// which takes precedence is decided as per GEP-713, or if the object inherits
+// one of them, in which case the overrides of the inherited policy take
+// precedence over the policy targeting the object, whose values take
+// precedence over the defaults of the inherited policy.
+//
+// Conflicts are reported for the object targeted by the policies.
+func (a *Extension) Execute(graph *topology.Graph) error {
+	graph.RemoveMetadata(ExtensionName)
+	for _, nodes := range graph.Nodes {
+		for _, node := range nodes {
+			policiesMap, err := directlyattachedpolicy.Access(node)
+			if err != nil {
+				return err
+			}
+			if len(policiesMap) == 0 {
+				continue
+			}
+			policies := policymanager.ConvertPoliciesMapToSlice(policiesMap)
+
+			if err := a.validateDirectlyAttachedPolicies(node, policies); err != nil {
+				return err
+			}
+			if err := a.validateInheritedPolicies(node, policies); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateDirectlyAttachedPolicies reports conflicts between the policies which
+// target the node.
+func (a *Extension) validateDirectlyAttachedPolicies(node *topology.Node, policies []*policymanager.Policy) error {
+	for i, policy := range policies {
+		for _, otherPolicy := range policies[i+1:] {
+			if policy.PolicyCrdID() != otherPolicy.PolicyCrdID() {
+				continue
+			}
+			fields := overlappingFields(allSpecFields(policy), allSpecFields(otherPolicy))
+			if len(fields) == 0 {
+				continue
+			}
+
+			winner := policymanager.HigherPrecedencePolicy(policy, otherPolicy)
+			reason := "it is older"
+			policyTime, otherPolicyTime := policy.Unstructured.GetCreationTimestamp(), otherPolicy.Unstructured.GetCreationTimestamp()
+			if policyTime.Equal(&otherPolicyTime) {
+				reason = "it is alphabetically first"
+			}
+			conflictErr := common.PolicyConflictError{
+				Policy:            policy.GKNN(),
+				ConflictingPolicy: otherPolicy.GKNN(),
+				Fields:            fields,
+				Winner:            winner.GKNN(),
+				Reason:            reason,
+			}
+			if err := a.putErrorInNode(node, conflictErr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateInheritedPolicies reports conflicts between the inheritable policies
+// which target the node, and the policies which the node inherits.
+func (a *Extension) validateInheritedPolicies(node *topology.Node, policies []*policymanager.Policy) error {
+	effPolicyMetadata, err := gatewayeffectivepolicy.Access(node)
+	if err != nil {
+		return err
+	}
+	inheritedPolicies := policymanager.ConvertPoliciesMapToSlice(effPolicyMetadata.InheritedPolicies(node.GKNN().GroupKind()))
+
+	for _, policy := range policies {
+		if !policy.IsInheritable() {
+			continue
+		}
+		for _, inheritedPolicy := range inheritedPolicies {
+			if policy.PolicyCrdID() != inheritedPolicy.PolicyCrdID() || policy.GKNN() == inheritedPolicy.GKNN() {
+				continue
+			}
+			fields := allSpecFields(policy)
+			inheritedDefaults, inheritedOverrides := inheritedPolicy.SpecFields()
+
+			// Overrides of the inherited policy take precedence over all the
+			// values of the policy, while the values of the policy take
+			// precedence over the defaults of the inherited policy.
+			overridden := overlappingFields(fields, inheritedOverrides)
+			var defaulted []string
+			for _, field := range overlappingFields(fields, inheritedDefaults) {
+				if !slices.Contains(overridden, field) {
+					defaulted = append(defaulted, field)
+				}
+			}
+
+			if len(overridden) != 0 {
+				conflictErr := common.PolicyConflictError{
+					Policy:            policy.GKNN(),
+					ConflictingPolicy: inheritedPolicy.GKNN(),
+					Inherited:         true,
+					Fields:            overridden,
+					Winner:            inheritedPolicy.GKNN(),
+					Reason:            "it overrides them",
+				}
+				if err := a.putErrorInNode(node, conflictErr); err != nil {
+					return err
+				}
+			}
+			if len(defaulted) != 0 {
+				conflictErr := common.PolicyConflictError{
+					Policy:            policy.GKNN(),
+					ConflictingPolicy: inheritedPolicy.GKNN(),
+					Inherited:         true,
+					Fields:            defaulted,
+					Winner:            policy.GKNN(),
+					Reason:            "it is more specific",
+				}
+				if err := a.putErrorInNode(node, conflictErr); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Extension) putErrorInNode(node *topology.Node, conflictErr error) error {
+	data, err := metadataKey.GetOrSet(node, &NodeMetadata{
+		Errors: make([]error, 0),
+	})
+	if err != nil {
+		return err
+	}
+	data.Errors = append(data.Errors, conflictErr)
+	klog.V(3).InfoS("Found conflicting policies", "extension", ExtensionName, "node", node.GKNN(), "err", conflictErr)
+	return nil
+}
+
+// allSpecFields returns the fields set by the policy, irrespective of whether
+// they are defaults or overrides.
+func allSpecFields(policy *policymanager.Policy) []string {
+	fields, overrideFields := policy.SpecFields()
+	return slices.Compact(slices.Sorted(slices.Values(append(fields, overrideFields...))))
+}
+
+// overlappingFields returns the sorted fields which are set by both a and b. A
+// field also overlaps with the fields nested within it, in which case the outer
+// field is returned.
+func overlappingFields(a, b []string) []string {
+	result := make(map[string]bool)
+	for _, x := range a {
+		for _, y := range b {
+			switch {
+			case x == y || strings.HasPrefix(y, x+"."):
+				result[x] = true
+			case strings.HasPrefix(x, y+"."):
+				result[y] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(result))
+}
+
+type NodeMetadata struct {
+	Errors []error
+}
+
+var _ topology.AnalysisResult = (*NodeMetadata)(nil)
+
+func (m *NodeMetadata) AnalysisErrors() []error {
+	if m == nil {
+		return nil
+	}
+	return m.Errors
+}
+
+func Access(node *topology.Node) (*NodeMetadata, error) {
+	return metadataKey.Get(node)
+}
@@ -17,10 +17,12 @@ limitations under the License.
 package refgrantvalidator
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -32,9 +34,11 @@ import (
 )
 
 const (
-	extensionName = "ReferenceGrantsForBackend"
+	ExtensionName = "refgrantvalidator"
 )
 
+var metadataKey = topology.NewMetadataKey[*NodeMetadata](ExtensionName)
+
 type Extension struct {
 	fetcher referenceGrantFetcher
 }
@@ -43,39 +47,63 @@ func NewExtension(fetcher referenceGrantFetcher) *Extension {
 	return &Extension{fetcher: fetcher}
 }
 
-// Extension calculates the effective policies for all Gateways, HTTPRoutes, and
-// Backends in the Graph.
+func (a *Extension) Name() string {
+	return ExtensionName
+}
+
+func (a *Extension) Dependencies() []string {
+	return nil
+}
+
+// Execute validates that all cross namespace references from Routes to
+// Backends, and from Gateways to Secrets, are permitted by ReferenceGrants. The
+// references permitted by each ReferenceGrant in the graph are recorded in its
+// node, and ReferenceGrants which are unused or overly broad are reported.
 func (a *Extension) Execute(graph *topology.Graph) error {
-	graph.RemoveMetadata(extensionName)
-	if err := a.discoverReferenceGrantsForBackends(graph); err != nil {
+	graph.RemoveMetadata(ExtensionName)
+	if err := a.discoverReferenceGrants(graph); err != nil {
+		return err
+	}
+	if err := a.validateRoutes(graph); err != nil {
+		return err
+	}
+	if err := a.validateGateways(graph); err != nil {
 		return err
 	}
-	return a.validateHTTPRoutes(graph)
+	return a.validateReferenceGrants(graph)
 }
 
-func (a *Extension) discoverReferenceGrantsForBackends(graph *topology.Graph) error {
+// discoverReferenceGrants finds the ReferenceGrants exposing each of the
+// objects which can be referenced across namespaces, ie. Backends and Secrets.
+func (a *Extension) discoverReferenceGrants(graph *topology.Graph) error {
+	var nodes []*topology.Node
+	nodes = append(nodes, topologygw.BackendNodes(graph)...)
+	for _, secretNode := range graph.Nodes[common.SecretGK] {
+		nodes = append(nodes, secretNode)
+	}
+
 	referenceGrantsByNamespace := make(map[string][]*gatewayv1beta1.ReferenceGrant)
-	for _, backendNode := range graph.Nodes[common.ServiceGK] {
-		backendNS := backendNode.Object.GetNamespace()
+	for _, node := range nodes {
+		namespace := node.Object.GetNamespace()
 
-		referenceGrants, ok := referenceGrantsByNamespace[backendNS]
+		referenceGrants, ok := referenceGrantsByNamespace[namespace]
 		if !ok {
 			var err error
-			referenceGrants, err = a.fetcher.FetchReferenceGrantsForNamespace(backendNS)
+			referenceGrants, err = a.fetcher.FetchReferenceGrantsForNamespace(namespace)
 			if err != nil {
 				return err
 			}
-			referenceGrantsByNamespace[backendNS] = referenceGrants
+			referenceGrantsByNamespace[namespace] = referenceGrants
 		}
 
 		for _, referenceGrant := range referenceGrants {
-			backendRef := backendNode.GKNN()
-			if ReferenceGrantExposes(referenceGrant, backendRef) {
-				klog.V(1).InfoS("ReferenceGrant exposes Backend",
+			ref := node.GKNN()
+			if ReferenceGrantExposes(referenceGrant, ref) {
+				klog.V(1).InfoS("ReferenceGrant exposes resource",
 					"referenceGrant", referenceGrant.GetNamespace()+"/"+referenceGrant.GetName(),
-					"backendRef", backendRef.Namespace+"/"+backendRef.Name,
+					"ref", ref,
 				)
-				if err := a.putReferenceGrantInNode(backendNode, referenceGrant); err != nil {
+				if err := a.putReferenceGrantInNode(node, referenceGrant); err != nil {
 					return err
 				}
 			}
@@ -84,62 +112,186 @@ func (a *Extension) discoverReferenceGrantsForBackends(graph *topology.Graph) er
 	return nil
 }
 
-func (a *Extension) validateHTTPRoutes(graph *topology.Graph) error {
-	for _, httpRouteNode := range graph.Nodes[common.HTTPRouteGK] {
-		if httpRouteNode.Depth > graph.MaxDepth {
-			klog.V(3).InfoS("Not validating HTTPRoute since it's depth is greater than the max depth",
-				"extension", extensionName, "httpRouteNode.Depth", httpRouteNode.Depth, "MaxDepth", graph.MaxDepth,
+// validateRoutes ensures that all cross namespace backend references from
+// Routes are permitted by some ReferenceGrant.
+func (a *Extension) validateRoutes(graph *topology.Graph) error {
+	for _, routeGK := range topologygw.RouteGKs {
+		if err := a.validateRoutesOfKind(graph, routeGK); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Extension) validateRoutesOfKind(graph *topology.Graph, routeGK schema.GroupKind) error {
+	for _, routeNode := range graph.Nodes[routeGK] {
+		if routeNode.Depth > graph.MaxDepth {
+			klog.V(3).InfoS("Not validating Route since it's depth is greater than the max depth",
+				"extension", ExtensionName, "routeNode.Depth", routeNode.Depth, "MaxDepth", graph.MaxDepth,
 			)
 			continue
 		}
 
-		for backendGKNN, backendNode := range topologygw.HTTPRouteNode(httpRouteNode).Backends() {
-			// Ensure that if this is a cross namespace reference, then it is accepted
-			// through some ReferenceGrant.
-			if httpRouteNode.GKNN().Namespace != backendGKNN.Namespace {
-				backendNodeMetadata, err := Access(backendNode)
-				if err != nil {
-					return err
-				}
+		for _, backendNode := range topologygw.RouteNode(routeNode).Backends() {
+			if err := a.validateReference(graph, routeNode, backendNode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
 
-				var referenceAccepted bool
-				if backendNodeMetadata != nil {
-					for _, referenceGrant := range backendNodeMetadata.ReferenceGrants {
-						if ReferenceGrantAccepts(referenceGrant, httpRouteNode.GKNN()) {
-							referenceAccepted = true
-							break
-						}
-					}
-				}
-				if !referenceAccepted {
-					err := common.ReferenceNotPermittedError{ReferenceFromTo: common.ReferenceFromTo{
-						ReferringObject: httpRouteNode.GKNN(),
-						ReferredObject:  backendGKNN,
-					}}
-					if err := a.putReferenceGrantErrorInNode(httpRouteNode, err); err != nil {
-						return err
-					}
-					klog.V(1).InfoS("Reference not permitted", "from", httpRouteNode.GKNN(), "to", backendGKNN)
-					continue
-				}
+// validateGateways ensures that all cross namespace certificate references
+// from Gateways are permitted by some ReferenceGrant.
+func (a *Extension) validateGateways(graph *topology.Graph) error {
+	for _, gatewayNode := range graph.Nodes[common.GatewayGK] {
+		if gatewayNode.Depth > graph.MaxDepth {
+			klog.V(3).InfoS("Not validating Gateway since it's depth is greater than the max depth",
+				"extension", ExtensionName, "gatewayNode.Depth", gatewayNode.Depth, "MaxDepth", graph.MaxDepth,
+			)
+			continue
+		}
+
+		for _, secretNode := range topologygw.GatewayNode(gatewayNode).Secrets() {
+			if err := a.validateReference(graph, gatewayNode, secretNode); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
-func (a *Extension) putReferenceGrantInNode(node *topology.Node, referenceGrant *gatewayv1beta1.ReferenceGrant) error {
-	if node.Metadata == nil {
-		node.Metadata = map[string]any{}
+// validateReference ensures that if the reference from fromNode to toNode is a
+// cross namespace reference, then it is accepted through some ReferenceGrant.
+// Otherwise, an error is put in fromNode. The reference is recorded in the
+// nodes of all ReferenceGrants accepting it.
+func (a *Extension) validateReference(graph *topology.Graph, fromNode, toNode *topology.Node) error {
+	if fromNode.GKNN().Namespace == toNode.GKNN().Namespace {
+		return nil
+	}
+
+	reference := common.ReferenceFromTo{
+		ReferringObject: fromNode.GKNN(),
+		ReferredObject:  toNode.GKNN(),
 	}
-	if node.Metadata[extensionName] == nil {
-		node.Metadata[extensionName] = &NodeMetadata{
-			ReferenceGrants: make(map[common.GKNN]*gatewayv1beta1.ReferenceGrant),
-			Errors:          make([]error, 0),
+
+	toNodeMetadata, err := Access(toNode)
+	if err != nil {
+		return err
+	}
+	var referenceAccepted bool
+	if toNodeMetadata != nil {
+		for referenceGrantGKNN, referenceGrant := range toNodeMetadata.ReferenceGrants {
+			if !ReferenceGrantAccepts(referenceGrant, fromNode.GKNN()) {
+				continue
+			}
+			referenceAccepted = true
+			if !graph.HasNode(referenceGrantGKNN) {
+				continue
+			}
+			referenceGrantNode := graph.Nodes[common.ReferenceGrantGK][referenceGrantGKNN.NamespacedName()]
+			if err := a.putPermittedReferenceInNode(referenceGrantNode, reference); err != nil {
+				return err
+			}
 		}
 	}
+	if referenceAccepted {
+		return nil
+	}
 
-	data, err := Access(node)
+	notPermittedErr := common.ReferenceNotPermittedError{ReferenceFromTo: reference}
+	klog.V(1).InfoS("Reference not permitted", "from", fromNode.GKNN(), "to", toNode.GKNN())
+	return a.putReferenceGrantErrorInNode(fromNode, notPermittedErr)
+}
+
+// validateReferenceGrants reports ReferenceGrants which do not permit any
+// reference, or which permit references to all objects of some kind while only
+// some of them are referenced.
+//
+// References are only validated from nodes within the max depth, so a
+// ReferenceGrant is only checked if all references relying on it have been
+// validated, ie. if the objects referring to the objects exposed by the
+// ReferenceGrant are within the max depth.
+func (a *Extension) validateReferenceGrants(graph *topology.Graph) error {
+	for _, referenceGrantNode := range graph.Nodes[common.ReferenceGrantGK] {
+		if metadata, err := Access(referenceGrantNode); err != nil {
+			return err
+		} else if metadata != nil {
+			slices.SortFunc(metadata.References, func(a, b common.ReferenceFromTo) int {
+				return cmp.Or(
+					cmp.Compare(a.ReferringObject.String(), b.ReferringObject.String()),
+					cmp.Compare(a.ReferredObject.String(), b.ReferredObject.String()),
+				)
+			})
+		}
+
+		if referenceGrantNode.Depth+2 > graph.MaxDepth {
+			klog.V(3).InfoS("Not validating ReferenceGrant since the references relying on it may be beyond the max depth",
+				"extension", ExtensionName, "referenceGrantNode.Depth", referenceGrantNode.Depth, "MaxDepth", graph.MaxDepth,
+			)
+			continue
+		}
+
+		var references []common.ReferenceFromTo
+		metadata, err := Access(referenceGrantNode)
+		if err != nil {
+			return err
+		}
+		if metadata != nil {
+			references = metadata.References
+		}
+		if len(references) == 0 {
+			err := common.UnusedReferenceGrantError{ReferenceGrant: referenceGrantNode.GKNN()}
+			if err := a.putReferenceGrantErrorInNode(referenceGrantNode, err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		referenceGrant := topology.MustAccessObject(referenceGrantNode, &gatewayv1beta1.ReferenceGrant{})
+		exposed := topologygw.ReferenceGrantNode(referenceGrantNode).Exposes()
+		for _, to := range referenceGrant.Spec.To {
+			if to.Name != nil && len(*to.Name) != 0 {
+				continue
+			}
+			gk := schema.GroupKind{Group: string(to.Group), Kind: string(to.Kind)}
+
+			var permitted int
+			for gknn := range exposed {
+				if gknn.GroupKind() == gk {
+					permitted++
+				}
+			}
+			referenced := map[common.GKNN]bool{}
+			for _, reference := range references {
+				if reference.ReferredObject.GroupKind() == gk {
+					referenced[reference.ReferredObject] = true
+				}
+			}
+			if permitted <= len(referenced) {
+				continue
+			}
+
+			err := common.BroadReferenceGrantError{
+				ReferenceGrant: referenceGrantNode.GKNN(),
+				Group:          gk.Group,
+				Kind:           gk.Kind,
+				Permitted:      permitted,
+				Referenced:     len(referenced),
+			}
+			if err := a.putReferenceGrantErrorInNode(referenceGrantNode, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Extension) putReferenceGrantInNode(node *topology.Node, referenceGrant *gatewayv1beta1.ReferenceGrant) error {
+	data, err := metadataKey.GetOrSet(node, &NodeMetadata{
+		ReferenceGrants: make(map[common.GKNN]*gatewayv1beta1.ReferenceGrant),
+		Errors:          make([]error, 0),
+	})
 	if err != nil {
 		return err
 	}
@@ -153,18 +305,25 @@ func (a *Extension) putReferenceGrantInNode(node *topology.Node, referenceGrant
 	return nil
 }
 
-func (a *Extension) putReferenceGrantErrorInNode(node *topology.Node, refGrantErr error) error {
-	if node.Metadata == nil {
-		node.Metadata = map[string]any{}
+func (a *Extension) putPermittedReferenceInNode(node *topology.Node, reference common.ReferenceFromTo) error {
+	data, err := metadataKey.GetOrSet(node, &NodeMetadata{
+		ReferenceGrants: make(map[common.GKNN]*gatewayv1beta1.ReferenceGrant),
+		Errors:          make([]error, 0),
+	})
+	if err != nil {
+		return err
 	}
-	if node.Metadata[extensionName] == nil {
-		node.Metadata[extensionName] = &NodeMetadata{
-			ReferenceGrants: make(map[common.GKNN]*gatewayv1beta1.ReferenceGrant),
-			Errors:          make([]error, 0),
-		}
+	if !slices.Contains(data.References, reference) {
+		data.References = append(data.References, reference)
 	}
+	return nil
+}
 
-	data, err := Access(node)
+func (a *Extension) putReferenceGrantErrorInNode(node *topology.Node, refGrantErr error) error {
+	data, err := metadataKey.GetOrSet(node, &NodeMetadata{
+		ReferenceGrants: make(map[common.GKNN]*gatewayv1beta1.ReferenceGrant),
+		Errors:          make([]error, 0),
+	})
 	if err != nil {
 		return err
 	}
@@ -173,21 +332,26 @@ func (a *Extension) putReferenceGrantErrorInNode(node *topology.Node, refGrantEr
 }
 
 type NodeMetadata struct {
+	// ReferenceGrants contains the ReferenceGrants exposing the object of the
+	// node.
 	ReferenceGrants map[common.GKNN]*gatewayv1beta1.ReferenceGrant
-	Errors          []error
+	// References contains the cross namespace references permitted by the
+	// ReferenceGrant of the node.
+	References []common.ReferenceFromTo
+	Errors     []error
 }
 
-func Access(node *topology.Node) (*NodeMetadata, error) {
-	rawData, ok := node.Metadata[extensionName]
-	if !ok || rawData == nil {
-		klog.V(3).InfoS(fmt.Sprintf("no data found in node for %v", extensionName), "node", node.GKNN())
-		return nil, nil
-	}
-	data, ok := rawData.(*NodeMetadata)
-	if !ok {
-		return nil, fmt.Errorf("unable to perform type assertion for %v in node %v", extensionName, node.GKNN())
+var _ topology.AnalysisResult = (*NodeMetadata)(nil)
+
+func (m *NodeMetadata) AnalysisErrors() []error {
+	if m == nil {
+		return nil
 	}
-	return data, nil
+	return m.Errors
+}
+
+func Access(node *topology.Node) (*NodeMetadata, error) {
+	return metadataKey.Get(node)
 }
 
 // ReferenceGrantExposes returns true if the provided reference grant "exposes"
@@ -236,68 +400,29 @@ type referenceGrantFetcher interface {
 var _ referenceGrantFetcher = (*defaultReferenceGrantFetcher)(nil)
 
 type defaultReferenceGrantFetcher struct {
-	factory                        common.Factory
-	additionalResourcesByNamespace map[string][]*unstructured.Unstructured
-}
-
-type referenceGrantFetcherOption func(*defaultReferenceGrantFetcher)
-
-func WithAdditionalResources(resources []*unstructured.Unstructured) referenceGrantFetcherOption { //nolint:revive
-	return func(f *defaultReferenceGrantFetcher) {
-		for _, resource := range resources {
-			if resource.GroupVersionKind().GroupKind() == common.ReferenceGrantGK {
-				f.additionalResourcesByNamespace[resource.GetNamespace()] = append(f.additionalResourcesByNamespace[resource.GetNamespace()], resource)
-			}
-		}
-	}
+	fetcher common.GroupKindFetcher
 }
 
-func NewDefaultReferenceGrantFetcher(factory common.Factory, options ...referenceGrantFetcherOption) *defaultReferenceGrantFetcher { //nolint:revive
-	f := &defaultReferenceGrantFetcher{
-		factory:                        factory,
-		additionalResourcesByNamespace: make(map[string][]*unstructured.Unstructured),
-	}
-	for _, option := range options {
-		option(f)
-	}
-	return f
+// NewDefaultReferenceGrantFetcher returns a referenceGrantFetcher which fetches
+// the ReferenceGrants of a namespace through the GroupKindFetcher. Only the
+// namespace is listed when the fetcher supports it.
+func NewDefaultReferenceGrantFetcher(fetcher common.GroupKindFetcher) *defaultReferenceGrantFetcher { //nolint:revive
+	return &defaultReferenceGrantFetcher{fetcher: fetcher}
 }
 
 func (f *defaultReferenceGrantFetcher) FetchReferenceGrantsForNamespace(namespace string) ([]*gatewayv1beta1.ReferenceGrant, error) {
-	infos, err := f.factory.NewBuilder().
-		Unstructured().
-		Flatten().
-		NamespaceParam(namespace).RequireNamespace().
-		AllNamespaces(false).
-		ResourceTypeOrNameArgs(true, []string{fmt.Sprintf("%v.%v", common.ReferenceGrantGK.Kind, common.ReferenceGrantGK.Group)}...).
-		ContinueOnError().
-		Do().
-		Infos()
+	resources, err := common.FetchInNamespace(f.fetcher, common.ReferenceGrantGK, namespace)
 	if err != nil {
 		return nil, err
 	}
 
 	var result []*gatewayv1beta1.ReferenceGrant
-	for _, info := range infos {
-		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(info.Object)
-		if err != nil {
-			return nil, err
-		}
-		refGrant := &gatewayv1beta1.ReferenceGrant{}
-		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u, refGrant); err != nil {
-			return nil, err
-		}
-		result = append(result, refGrant)
-	}
-
-	// Return any additional ReferenceGrants if they have been provided.
-	for _, u := range f.additionalResourcesByNamespace[namespace] {
+	for _, u := range resources {
 		refGrant := &gatewayv1beta1.ReferenceGrant{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), refGrant); err != nil {
-			return nil, fmt.Errorf("converting local ReferenceGrant from Unstructurued to typed: %v", err)
+			return nil, fmt.Errorf("converting ReferenceGrant from Unstructurued to typed: %v", err)
 		}
 		result = append(result, refGrant)
 	}
-
 	return result, nil
 }
@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeattachmentvalidator
+
+import (
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	ExtensionName = "routeattachmentvalidator"
+)
+
+var metadataKey = topology.NewMetadataKey[*NodeMetadata](ExtensionName)
+
+type Extension struct{}
+
+func NewExtension() *Extension {
+	return &Extension{}
+}
+
+func (a *Extension) Name() string {
+	return ExtensionName
+}
+
+func (a *Extension) Dependencies() []string {
+	return nil
+}
+
+// Execute validates that the Routes in the Graph are allowed to attach to the
+// listeners selected by their parentRefs. A listener allows a Route to attach
+// if the namespace and kind of the Route are allowed through allowedRoutes,
+// and the hostnames of the Route intersect with the hostname of the listener.
+//
+// A parentRef is only reported if none of the listeners it selects allow the
+// Route, since the Route is still accepted by the Gateway if at least one of
+// them does. ParentRefs which select no listener at all are reported by the
+// parentrefvalidator instead.
+func (a *Extension) Execute(graph *topology.Graph) error {
+	graph.RemoveMetadata(ExtensionName)
+	for _, routeGK := range topologygw.RouteGKs {
+		for _, routeNode := range graph.Nodes[routeGK] {
+			if routeNode.Depth > graph.MaxDepth {
+				klog.V(3).InfoS("Not validating Route since it's depth is greater than the max depth",
+					"extension", ExtensionName, "routeNode.Depth", routeNode.Depth, "MaxDepth", graph.MaxDepth,
+				)
+				continue
+			}
+			if err := a.validateRoute(graph, routeNode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Extension) validateRoute(graph *topology.Graph, routeNode *topology.Node) error {
+	for _, parentRef := range topologygw.RouteParentRefs(routeNode.Object) {
+		if !topologygw.IsGatewayParentRef(parentRef) {
+			continue
+		}
+		gatewayNN := topologygw.ParentRefGatewayNamespacedName(routeNode.GKNN().Namespace, parentRef)
+		gatewayNode := graph.Nodes[common.GatewayGK][gatewayNN]
+		if gatewayNode == nil {
+			continue
+		}
+		gateway := topology.MustAccessObject(gatewayNode, &gatewayv1.Gateway{})
+
+		var attachmentErrors []error
+		allowed := false
+		for _, listener := range gateway.Spec.Listeners {
+			if !topologygw.ParentRefMatchesListener(parentRef, listener) {
+				continue
+			}
+			err := ValidateAttachment(routeNode, gatewayNode, listener)
+			if err == nil {
+				allowed = true
+				break
+			}
+			attachmentErrors = append(attachmentErrors, err)
+		}
+		if allowed {
+			continue
+		}
+
+		for _, attachmentErr := range attachmentErrors {
+			if err := a.putErrorInNode(routeNode, attachmentErr); err != nil {
+				return err
+			}
+			klog.V(1).Info(attachmentErr)
+		}
+	}
+	return nil
+}
+
+// ValidateAttachment returns an error describing why the listener does not
+// allow the Route to attach, or nil if it does.
+func ValidateAttachment(routeNode, gatewayNode *topology.Node, listener gatewayv1.Listener) error {
+	referenceFromTo := common.ReferenceFromTo{
+		ReferringObject: routeNode.GKNN(),
+		ReferredObject:  gatewayNode.GKNN(),
+	}
+
+	if !namespaceAllowed(routeNode, gatewayNode, listener) {
+		from := gatewayv1.NamespacesFromSame
+		if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil && listener.AllowedRoutes.Namespaces.From != nil {
+			from = *listener.AllowedRoutes.Namespaces.From
+		}
+		return common.RouteNamespaceNotAllowedError{
+			ReferenceFromTo: referenceFromTo,
+			ListenerName:    string(listener.Name),
+			From:            string(from),
+		}
+	}
+
+	if !topologygw.ListenerSupportsRouteKind(listener, routeNode.GKNN().GroupKind()) {
+		return common.RouteKindNotAllowedError{
+			ReferenceFromTo: referenceFromTo,
+			ListenerName:    string(listener.Name),
+		}
+	}
+
+	if !hostnamesIntersect(routeHostnames(routeNode.Object), listener.Hostname) {
+		return common.RouteHostnamesNotMatchingError{
+			ReferenceFromTo:  referenceFromTo,
+			ListenerName:     string(listener.Name),
+			ListenerHostname: string(*listener.Hostname),
+		}
+	}
+
+	return nil
+}
+
+// namespaceAllowed returns true if the namespace of the Route is allowed by
+// allowedRoutes.namespaces of the listener. If the listener uses a selector
+// and the Namespace of the Route is not in the Graph, the namespace is assumed
+// to be allowed since it cannot be evaluated.
+func namespaceAllowed(routeNode, gatewayNode *topology.Node, listener gatewayv1.Listener) bool {
+	routeNamespace := routeNode.GKNN().Namespace
+	if routeNamespace == "" {
+		routeNamespace = metav1.NamespaceDefault
+	}
+
+	from := gatewayv1.NamespacesFromSame
+	var selector *metav1.LabelSelector
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil {
+		if listener.AllowedRoutes.Namespaces.From != nil {
+			from = *listener.AllowedRoutes.Namespaces.From
+		}
+		selector = listener.AllowedRoutes.Namespaces.Selector
+	}
+
+	switch from {
+	case gatewayv1.NamespacesFromAll:
+		return true
+	case gatewayv1.NamespacesFromSame:
+		return routeNamespace == gatewayNode.GKNN().Namespace
+	case gatewayv1.NamespacesFromSelector:
+		if selector == nil {
+			return false
+		}
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			klog.V(3).InfoS("Invalid namespace selector in listener", "extension", ExtensionName,
+				"gateway", gatewayNode.GKNN(), "listener", listener.Name, "err", err)
+			return false
+		}
+		namespaceNode := topologygw.RouteNode(routeNode).Namespace()
+		if namespaceNode == nil {
+			klog.V(3).InfoS("Namespace of Route not found, skipping namespace selector validation",
+				"extension", ExtensionName, "route", routeNode.GKNN(), "namespace", routeNamespace)
+			return true
+		}
+		return labelSelector.Matches(labels.Set(namespaceNode.Object.GetLabels()))
+	}
+	// Unknown values of from are rejected by the API server, so this should
+	// never happen.
+	return false
+}
+
+// routeHostnames returns the hostnames of the Route. Routes which do not have
+// hostnames (like TCPRoute and UDPRoute) return nil.
+func routeHostnames(u *unstructured.Unstructured) []string {
+	hostnames, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "hostnames")
+	return hostnames
+}
+
+// hostnamesIntersect returns true if any of the route hostnames intersect with
+// the listener hostname. A Route without hostnames, or a listener without a
+// hostname, matches all hostnames.
+func hostnamesIntersect(routeHostnames []string, listenerHostname *gatewayv1.Hostname) bool {
+	if len(routeHostnames) == 0 || listenerHostname == nil || *listenerHostname == "" {
+		return true
+	}
+	return slices.ContainsFunc(routeHostnames, func(routeHostname string) bool {
+		return hostnameIntersects(routeHostname, string(*listenerHostname))
+	})
+}
+
+// hostnameIntersects returns true if the two hostnames, any of which may be a
+// wildcard hostname, can match a common host.
+func hostnameIntersects(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if strings.HasPrefix(a, "*.") && strings.HasSuffix(b, a[1:]) {
+		return true
+	}
+	if strings.HasPrefix(b, "*.") && strings.HasSuffix(a, b[1:]) {
+		return true
+	}
+	return false
+}
+
+func (a *Extension) putErrorInNode(node *topology.Node, attachmentErr error) error {
+	data, err := metadataKey.GetOrSet(node, &NodeMetadata{
+		Errors: make([]error, 0),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(data.Errors, attachmentErr) {
+		data.Errors = append(data.Errors, attachmentErr)
+	}
+	return nil
+}
+
+type NodeMetadata struct {
+	Errors []error
+}
+
+var _ topology.AnalysisResult = (*NodeMetadata)(nil)
+
+func (m *NodeMetadata) AnalysisErrors() []error {
+	if m == nil {
+		return nil
+	}
+	return m.Errors
+}
+
+func Access(node *topology.Node) (*NodeMetadata, error) {
+	return metadataKey.Get(node)
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shadowedrulevalidator
+
+import (
+	"golang.org/x/exp/maps"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/routing"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+)
+
+const (
+	ExtensionName = "shadowedrulevalidator"
+)
+
+var metadataKey = topology.NewMetadataKey[*NodeMetadata](ExtensionName)
+
+type Extension struct{}
+
+func NewExtension() *Extension {
+	return &Extension{}
+}
+
+func (a *Extension) Name() string {
+	return ExtensionName
+}
+
+func (a *Extension) Dependencies() []string {
+	return nil
+}
+
+// Execute reports the rules of HTTPRoutes which never match requests received
+// by a listener, since each of their matches is covered by a match of another
+// rule with higher precedence. Rules which match exactly the same requests as
+// the rule taking precedence are reported as duplicates.
+//
+// Shadowed rules are reported for the Route containing them, and for the Route
+// containing the rule taking precedence. A rule which is shadowed by the same
+// rule on several listeners is only reported for the first of them.
+func (a *Extension) Execute(graph *topology.Graph) error {
+	graph.RemoveMetadata(ExtensionName)
+	for _, listenerNode := range topology.SortedNodes(maps.Values(graph.Nodes[common.ListenerGK])) {
+		if listenerNode.Depth > graph.MaxDepth {
+			klog.V(3).InfoS("Not validating listener since it's depth is greater than the max depth",
+				"extension", ExtensionName, "listenerNode.Depth", listenerNode.Depth, "MaxDepth", graph.MaxDepth,
+			)
+			continue
+		}
+		if err := a.validateListener(listenerNode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ruleKey identifies a rule within a Route.
+type ruleKey struct {
+	route     common.GKNN
+	ruleIndex int
+}
+
+// shadowedMatch is a match of a rule, along with the match with higher
+// precedence which covers it.
+type shadowedMatch struct {
+	match     routing.RuleMatch
+	shadowing routing.RuleMatch
+}
+
+func (a *Extension) validateListener(listenerNode *topology.Node) error {
+	gatewayNode := topologygw.ListenerNode(listenerNode).Gateway()
+	if gatewayNode == nil {
+		return nil
+	}
+	_, listenerName := common.SplitListenerName(listenerNode.GKNN().Name)
+
+	// matches are ordered by precedence, so a match can only be shadowed by the
+	// matches preceding it.
+	matches := routing.ListenerRuleMatches(listenerNode)
+	var rules []ruleKey
+	matchCount := make(map[ruleKey]int)
+	shadowedMatches := make(map[ruleKey][]shadowedMatch)
+	for i, match := range matches {
+		key := ruleKey{route: match.RouteNode.GKNN(), ruleIndex: match.RuleIndex}
+		if matchCount[key] == 0 {
+			rules = append(rules, key)
+		}
+		matchCount[key]++
+
+		for _, other := range matches[:i] {
+			if (ruleKey{route: other.RouteNode.GKNN(), ruleIndex: other.RuleIndex}) == key {
+				continue
+			}
+			if other.Covers(match) {
+				shadowedMatches[key] = append(shadowedMatches[key], shadowedMatch{match: match, shadowing: other})
+				break
+			}
+		}
+	}
+
+	for _, key := range rules {
+		shadowed := shadowedMatches[key]
+		if len(shadowed) != matchCount[key] {
+			continue
+		}
+		shadowedErr := newShadowedRouteRuleError(shadowed, gatewayNode.GKNN(), string(listenerName))
+		routeNodes := []*topology.Node{shadowed[0].match.RouteNode}
+		if shadowingRouteNode := shadowed[0].shadowing.RouteNode; shadowingRouteNode != routeNodes[0] {
+			routeNodes = append(routeNodes, shadowingRouteNode)
+		}
+		for _, routeNode := range routeNodes {
+			if err := a.putErrorInNode(routeNode, shadowedErr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newShadowedRouteRuleError reports a rule, all of whose matches are shadowed.
+// The rule is reported as a duplicate if all of its matches are shadowed by
+// identical matches of the same rule.
+func newShadowedRouteRuleError(shadowed []shadowedMatch, gateway common.GKNN, listenerName string) common.ShadowedRouteRuleError {
+	match, shadowing := shadowed[0].match, shadowed[0].shadowing
+	duplicate := true
+	for _, s := range shadowed {
+		sameRule := s.shadowing.RouteNode == shadowing.RouteNode && s.shadowing.RuleIndex == shadowing.RuleIndex
+		if !sameRule || !s.match.Covers(s.shadowing) {
+			duplicate = false
+			break
+		}
+	}
+
+	result := common.ShadowedRouteRuleError{
+		Route:          match.RouteNode.GKNN(),
+		Rule:           match.RuleName(),
+		ShadowingRoute: shadowing.RouteNode.GKNN(),
+		ShadowingRule:  shadowing.RuleName(),
+		Gateway:        gateway,
+		ListenerName:   listenerName,
+		Duplicate:      duplicate,
+	}
+	if duplicate {
+		// Identical matches only differ in the tie-breakers of the precedence
+		// rules.
+		switch {
+		case routing.CompareCreationTimestamps(shadowing.Route, match.Route) != 0:
+			result.Reason = "it is older"
+		case match.RouteNode != shadowing.RouteNode:
+			result.Reason = "it is alphabetically first"
+		default:
+			result.Reason = "it comes first within the HTTPRoute"
+		}
+	}
+	return result
+}
+
+// putErrorInNode records the error for the node, unless the same rules have
+// already been reported for it on another listener.
+func (a *Extension) putErrorInNode(node *topology.Node, shadowedErr common.ShadowedRouteRuleError) error {
+	data, err := metadataKey.GetOrSet(node, &NodeMetadata{
+		Errors: make([]error, 0),
+	})
+	if err != nil {
+		return err
+	}
+	for _, existing := range data.Errors {
+		if existing, ok := existing.(common.ShadowedRouteRuleError); ok && sameRules(existing, shadowedErr) {
+			return nil
+		}
+	}
+	data.Errors = append(data.Errors, shadowedErr)
+	klog.V(3).InfoS("Found shadowed route rule", "extension", ExtensionName, "node", node.GKNN(), "err", shadowedErr)
+	return nil
+}
+
+// sameRules returns true if both errors report the same rule being shadowed by
+// the same rule, possibly on different listeners.
+func sameRules(a, b common.ShadowedRouteRuleError) bool {
+	return a.Route == b.Route && a.Rule == b.Rule &&
+		a.ShadowingRoute == b.ShadowingRoute && a.ShadowingRule == b.ShadowingRule
+}
+
+type NodeMetadata struct {
+	Errors []error
+}
+
+var _ topology.AnalysisResult = (*NodeMetadata)(nil)
+
+func (m *NodeMetadata) AnalysisErrors() []error {
+	if m == nil {
+		return nil
+	}
+	return m.Errors
+}
+
+func Access(node *topology.Node) (*NodeMetadata, error) {
+	return metadataKey.Get(node)
+}
@@ -17,26 +17,49 @@ limitations under the License.
 package utils //nolint:revive
 
 import (
-	"sigs.k8s.io/gwctl/pkg/extension/notfoundrefvalidator"
-	"sigs.k8s.io/gwctl/pkg/extension/refgrantvalidator"
+	"maps"
+	"slices"
+
+	"sigs.k8s.io/gwctl/pkg/common"
 	"sigs.k8s.io/gwctl/pkg/topology"
 )
 
-func AggregateAnalysisErrors(node *topology.Node) ([]error, error) {
-	var analysisErrors []error
-	refGrantValidationMetadata, err := refgrantvalidator.Access(node)
-	if err != nil {
-		return nil, err
-	}
-	if refGrantValidationMetadata != nil && len(refGrantValidationMetadata.Errors) != 0 {
-		analysisErrors = append(analysisErrors, refGrantValidationMetadata.Errors...)
+// AnalysisError is an error reported for a node by one of the extensions which
+// analyze the graph.
+type AnalysisError struct {
+	// Extension is the name of the extension which reported the error.
+	Extension string
+	Err       error
+}
+
+// Severity returns the severity of the error.
+func (e AnalysisError) Severity() common.Severity {
+	return common.SeverityOf(e.Err)
+}
+
+// CollectAnalysisErrors returns the errors reported for the node by all
+// extensions storing a topology.AnalysisResult in the node, along with the
+// extension which reported each of them. Errors are ordered by the extension
+// name.
+func CollectAnalysisErrors(node *topology.Node) ([]AnalysisError, error) {
+	analysisResults := node.AnalysisResults()
+	var result []AnalysisError
+	for _, name := range slices.Sorted(maps.Keys(analysisResults)) {
+		for _, analysisErr := range analysisResults[name].AnalysisErrors() {
+			result = append(result, AnalysisError{Extension: name, Err: analysisErr})
+		}
 	}
-	notFoundRefValidatorMetadata, err := notfoundrefvalidator.Access(node)
+	return result, nil
+}
+
+func AggregateAnalysisErrors(node *topology.Node) ([]error, error) {
+	analysisErrors, err := CollectAnalysisErrors(node)
 	if err != nil {
 		return nil, err
 	}
-	if notFoundRefValidatorMetadata != nil && len(notFoundRefValidatorMetadata.Errors) != 0 {
-		analysisErrors = append(analysisErrors, notFoundRefValidatorMetadata.Errors...)
+	var result []error
+	for _, analysisErr := range analysisErrors {
+		result = append(result, analysisErr.Err)
 	}
-	return analysisErrors, nil
+	return result, nil
 }
@@ -65,10 +65,22 @@ func (f *ForFlag) ToOption() (common.GKNN, error) {
 		case "httproute", "httproutes":
 			objRef.Group = gatewayv1.GroupVersion.Group
 			objRef.Kind = "HTTPRoute"
+		case "grpcroute", "grpcroutes":
+			objRef.Group = gatewayv1.GroupVersion.Group
+			objRef.Kind = "GRPCRoute"
+		case "tlsroute", "tlsroutes":
+			objRef.Group = gatewayv1.GroupVersion.Group
+			objRef.Kind = "TLSRoute"
+		case "tcproute", "tcproutes":
+			objRef.Group = gatewayv1.GroupVersion.Group
+			objRef.Kind = "TCPRoute"
+		case "udproute", "udproutes":
+			objRef.Group = gatewayv1.GroupVersion.Group
+			objRef.Kind = "UDPRoute"
 		case "service", "services":
 			objRef.Kind = "Service"
 		default:
-			fmt.Fprintf(os.Stderr, "invalid type provided in --for flag; type must be one of [gatewayclass, gateway, httproute, service]\n")
+			fmt.Fprintf(os.Stderr, "invalid type provided in --for flag; type must be one of [gatewayclass, gateway, httproute, grpcroute, tlsroute, tcproute, udproute, service]\n")
 			os.Exit(1)
 		}
 	}
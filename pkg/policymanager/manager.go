@@ -19,8 +19,10 @@ package policymanager
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"golang.org/x/exp/maps"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -28,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/gwctl/pkg/common"
 
@@ -53,12 +56,17 @@ func New(fetcher common.GroupKindFetcher) *PolicyManager {
 
 // Init will construct a local cache of all Policy CRDs and Policy Resources.
 func (p *PolicyManager) Init() error {
+	start := time.Now()
 	err := p.initPolicyCRDs()
 	if err != nil {
 		return err
 	}
 
-	return p.initPolicies()
+	if err := p.initPolicies(); err != nil {
+		return err
+	}
+	klog.V(2).InfoS("Initialized PolicyManager", "policyCRDs", len(p.policyCRDs), "policies", len(p.policies), "duration", time.Since(start))
+	return nil
 }
 
 func (p *PolicyManager) initPolicyCRDs() error {
@@ -84,15 +92,21 @@ func (p *PolicyManager) initPolicyCRDs() error {
 }
 
 func (p *PolicyManager) initPolicies() error {
+	var gks []schema.GroupKind
+	inheritable := make(map[schema.GroupKind]bool)
 	for _, policyCRD := range p.policyCRDs {
 		gk := schema.GroupKind{Group: policyCRD.CRD.Spec.Group, Kind: policyCRD.CRD.Spec.Names.Kind}
-		policies, err := p.Fetcher.Fetch(gk)
-		if err != nil {
-			return err
-		}
+		gks = append(gks, gk)
+		inheritable[gk] = policyCRD.IsInheritable()
+	}
+	policiesByGK, err := common.FetchAll(p.Fetcher, gks)
+	if err != nil {
+		return err
+	}
 
-		for _, unstrucutredPolicy := range policies {
-			policy, err := ConstructPolicy(unstrucutredPolicy, policyCRD.IsInheritable())
+	for _, gk := range gks {
+		for _, unstrucutredPolicy := range policiesByGK[gk] {
+			policy, err := ConstructPolicy(unstrucutredPolicy, inheritable[gk])
 			if err != nil {
 				return err
 			}
@@ -168,11 +182,33 @@ type Policy struct {
 	// only makes sense in case of a directly-attached-policy, or an
 	// unmerged-inherited-policy.
 	TargetRefs []common.GKNN
+	// targetSectionNames contains the sectionName of the targetRef at the same
+	// index within TargetRefs, or an empty string if the targetRef does not
+	// specify one.
+	targetSectionNames []string
 	// Indicates whether the policy is supposed to be "inherited" (as opposed to
 	// "direct").
 	Inheritable bool
 
 	Status gatewayv1.PolicyStatus
+
+	// fieldSources records where the fields of a policy which results from
+	// merging other policies come from. It is nil for policies which have not
+	// been merged.
+	fieldSources map[string]FieldSource
+}
+
+// FieldSource identifies the policy which sets the value of a field within the
+// effective spec of a policy.
+type FieldSource struct {
+	Policy common.GKNN
+	// Level is the Kind of the objects targeted by Policy (for example
+	// GatewayClass, Namespace, Gateway or HTTPRoute), which determines the place
+	// of Policy in the hierarchy.
+	Level string
+	// Override is true if the value comes from spec.override of Policy, and
+	// false if it comes from spec.default (or the spec of a Direct policy).
+	Override bool
 }
 
 func ConstructPolicy(u *unstructured.Unstructured, inherited bool) (Policy, error) {
@@ -183,8 +219,8 @@ func ConstructPolicy(u *unstructured.Unstructured, inherited bool) (Policy, erro
 		metav1.TypeMeta   `json:",inline"`
 		metav1.ObjectMeta `json:"metadata,omitempty"`
 		Spec              struct {
-			TargetRef  gatewayv1.NamespacedPolicyTargetReference   `json:"targetRef,omitempty"`
-			TargetRefs []gatewayv1.NamespacedPolicyTargetReference `json:"targetRefs,omitempty"`
+			TargetRef  policyTargetReference   `json:"targetRef,omitempty"`
+			TargetRefs []policyTargetReference `json:"targetRefs,omitempty"`
 		} `json:"spec"`
 		Status gatewayv1.PolicyStatus `json:"status,omitempty"`
 	}
@@ -194,7 +230,7 @@ func ConstructPolicy(u *unstructured.Unstructured, inherited bool) (Policy, erro
 	}
 
 	if structuredPolicy.Spec.TargetRef.Name != "" {
-		structuredPolicy.Spec.TargetRefs = []gatewayv1.NamespacedPolicyTargetReference{structuredPolicy.Spec.TargetRef}
+		structuredPolicy.Spec.TargetRefs = []policyTargetReference{structuredPolicy.Spec.TargetRef}
 	}
 
 	for _, targetRef := range structuredPolicy.Spec.TargetRefs {
@@ -212,6 +248,11 @@ func ConstructPolicy(u *unstructured.Unstructured, inherited bool) (Policy, erro
 		}
 
 		result.TargetRefs = append(result.TargetRefs, gknn)
+		var sectionName string
+		if targetRef.SectionName != nil {
+			sectionName = string(*targetRef.SectionName)
+		}
+		result.targetSectionNames = append(result.targetSectionNames, sectionName)
 	}
 
 	result.Inheritable = inherited
@@ -221,6 +262,14 @@ func ConstructPolicy(u *unstructured.Unstructured, inherited bool) (Policy, erro
 	return result, nil
 }
 
+// policyTargetReference is a targetRef of a policy, which may additionally
+// reference a section of the target object, like a listener of a Gateway or a
+// rule of a Route.
+type policyTargetReference struct {
+	gatewayv1.NamespacedPolicyTargetReference `json:",inline"`
+	SectionName                               *gatewayv1.SectionName `json:"sectionName,omitempty"`
+}
+
 func (p Policy) GKNN() common.GKNN {
 	return common.GKNN{
 		Group:     p.Unstructured.GroupVersionKind().Group,
@@ -245,31 +294,57 @@ func (p Policy) IsDirect() bool {
 
 func (p Policy) IsAttachedTo(objRef common.GKNN) bool {
 	for _, targetRef := range p.TargetRefs {
-		if targetRef.Kind == "Namespace" && targetRef.Name == "" {
-			targetRef.Name = "default"
-		}
-		if objRef.Kind == "Namespace" && objRef.Name == "" {
-			objRef.Name = "default"
-		}
-		if targetRef.Kind != "Namespace" && targetRef.Namespace == "" {
-			targetRef.Namespace = "default"
+		if targetRefMatches(targetRef, objRef) {
+			return true
 		}
-		if objRef.Kind != "Namespace" && objRef.Namespace == "" {
-			objRef.Namespace = "default"
+	}
+	return false
+}
+
+// IsAttachedToSection returns true if the policy is attached to the object
+// either as a whole, or to the section of the object with the given name
+// through the sectionName of a targetRef.
+func (p Policy) IsAttachedToSection(objRef common.GKNN, sectionName string) bool {
+	for i, targetRef := range p.TargetRefs {
+		if !targetRefMatches(targetRef, objRef) {
+			continue
 		}
-		if targetRef == objRef {
+		if i >= len(p.targetSectionNames) || p.targetSectionNames[i] == "" || p.targetSectionNames[i] == sectionName {
 			return true
 		}
 	}
-
 	return false
 }
 
+func targetRefMatches(targetRef, objRef common.GKNN) bool {
+	if targetRef.Kind == "Namespace" && targetRef.Name == "" {
+		targetRef.Name = "default"
+	}
+	if objRef.Kind == "Namespace" && objRef.Name == "" {
+		objRef.Name = "default"
+	}
+	if targetRef.Kind != "Namespace" && targetRef.Namespace == "" {
+		targetRef.Namespace = "default"
+	}
+	if objRef.Kind != "Namespace" && objRef.Namespace == "" {
+		objRef.Namespace = "default"
+	}
+	return targetRef == objRef
+}
+
 func (p Policy) DeepCopy() *Policy {
 	clone := &Policy{
 		Unstructured: p.Unstructured.DeepCopy(),
 		TargetRefs:   p.TargetRefs,
 		Inheritable:  p.Inheritable,
+
+		targetSectionNames: p.targetSectionNames,
+	}
+	if p.fieldSources != nil {
+		clone.fieldSources = make(map[string]FieldSource, len(p.fieldSources))
+		for path, source := range p.fieldSources {
+			clone.fieldSources[path] = source
+		}
 	}
 	return clone
 }
@@ -324,6 +399,83 @@ func (p Policy) EffectiveSpec() (map[string]interface{}, error) {
 	return result, nil
 }
 
+// SpecFields returns the paths of the fields set within the spec of the
+// policy, excluding its targetRefs. Only leaf fields are returned, and lists
+// are considered to be leaf fields. For inheritable policies, the fields within
+// spec.default and spec.override are returned separately, relative to them.
+func (p Policy) SpecFields() (fields, overrideFields []string) {
+	spec := p.Spec()
+	if !p.IsInheritable() {
+		delete(spec, "targetRef")
+		delete(spec, "targetRefs")
+		return fieldPaths("", spec), nil
+	}
+
+	defaultSpec, _ := spec["default"].(map[string]interface{})
+	overrideSpec, _ := spec["override"].(map[string]interface{})
+	return fieldPaths("", defaultSpec), fieldPaths("", overrideSpec)
+}
+
+// FieldSources returns the source of each field within the effective spec of
+// the policy, keyed by the path of the field as returned by SpecFields. For
+// policies resulting from merging other policies, the sources are the original
+// policies which were merged.
+func (p Policy) FieldSources() map[string]FieldSource {
+	if p.fieldSources != nil {
+		return p.fieldSources
+	}
+
+	var levels []string
+	for _, targetRef := range p.TargetRefs {
+		if !slices.Contains(levels, targetRef.Kind) {
+			levels = append(levels, targetRef.Kind)
+		}
+	}
+	source := FieldSource{Policy: p.GKNN(), Level: strings.Join(levels, ",")}
+
+	result := make(map[string]FieldSource)
+	fields, overrideFields := p.SpecFields()
+	for _, field := range fields {
+		setFieldSource(result, field, source)
+	}
+	source.Override = true
+	for _, field := range overrideFields {
+		setFieldSource(result, field, source)
+	}
+	return result
+}
+
+// setFieldSource sets the source of the field, replacing the sources of any
+// fields nested within it, or which it is nested within, since the value of
+// the field replaces theirs.
+func setFieldSource(fieldSources map[string]FieldSource, field string, source FieldSource) {
+	for existing := range fieldSources {
+		if strings.HasPrefix(existing, field+".") || strings.HasPrefix(field, existing+".") {
+			delete(fieldSources, existing)
+		}
+	}
+	fieldSources[field] = source
+}
+
+// fieldPaths returns the sorted paths of the leaf fields within obj, each
+// prefixed with prefix.
+func fieldPaths(prefix string, obj map[string]interface{}) []string {
+	var result []string
+	for key, value := range obj {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) != 0 {
+			result = append(result, fieldPaths(path, nested)...)
+			continue
+		}
+		result = append(result, path)
+	}
+	sort.Strings(result)
+	return result
+}
+
 func (p *Policy) MarshalJSON() ([]byte, error) {
 	effectiveSpec, err := p.EffectiveSpec()
 	if err != nil {
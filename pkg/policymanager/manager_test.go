@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policymanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+func TestSpecFields(t *testing.T) {
+	testCases := []struct {
+		name               string
+		policy             *Policy
+		wantFields         []string
+		wantOverrideFields []string
+	}{
+		{
+			name: "direct policy",
+			policy: &Policy{
+				Unstructured: &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"spec": map[string]interface{}{
+							"targetRef": map[string]interface{}{
+								"kind": "Service",
+								"name": "svc-1",
+							},
+							"interval": "10s",
+							"http": map[string]interface{}{
+								"path":  "/healthz",
+								"codes": []interface{}{int64(200), int64(204)},
+							},
+						},
+					},
+				},
+			},
+			wantFields: []string{"http.codes", "http.path", "interval"},
+		},
+		{
+			name: "inheritable policy",
+			policy: &Policy{
+				Unstructured: &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"spec": map[string]interface{}{
+							"targetRefs": []interface{}{
+								map[string]interface{}{
+									"kind": "Gateway",
+									"name": "gateway-1",
+								},
+							},
+							"default": map[string]interface{}{
+								"timeout": map[string]interface{}{
+									"idle":    "30s",
+									"request": "5s",
+								},
+							},
+							"override": map[string]interface{}{
+								"timeout": map[string]interface{}{
+									"request": "10s",
+								},
+							},
+						},
+					},
+				},
+				Inheritable: true,
+			},
+			wantFields:         []string{"timeout.idle", "timeout.request"},
+			wantOverrideFields: []string{"timeout.request"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotFields, gotOverrideFields := tc.policy.SpecFields()
+			if diff := cmp.Diff(tc.wantFields, gotFields); diff != "" {
+				t.Errorf("SpecFields() returned unexpected fields (-want, +got):\n%v", diff)
+			}
+			if diff := cmp.Diff(tc.wantOverrideFields, gotOverrideFields); diff != "" {
+				t.Errorf("SpecFields() returned unexpected override fields (-want, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestIsAttachedToSection(t *testing.T) {
+	u := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "foo.com/v1",
+			"kind":       "TimeoutPolicy",
+			"metadata": map[string]interface{}{
+				"name":      "timeout-policy-1",
+				"namespace": "default",
+			},
+			"spec": map[string]interface{}{
+				"targetRefs": []interface{}{
+					map[string]interface{}{
+						"group":       "gateway.networking.k8s.io",
+						"kind":        "HTTPRoute",
+						"name":        "route-1",
+						"sectionName": "rule-1",
+					},
+					map[string]interface{}{
+						"group": "gateway.networking.k8s.io",
+						"kind":  "HTTPRoute",
+						"name":  "route-2",
+					},
+				},
+			},
+		},
+	}
+	policy, err := ConstructPolicy(u, true)
+	if err != nil {
+		t.Fatalf("ConstructPolicy() failed: %v", err)
+	}
+
+	route1 := common.GKNN{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "default", Name: "route-1"}
+	route2 := common.GKNN{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "default", Name: "route-2"}
+	route3 := common.GKNN{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "default", Name: "route-3"}
+
+	testCases := []struct {
+		objRef      common.GKNN
+		sectionName string
+		want        bool
+	}{
+		{objRef: route1, sectionName: "rule-1", want: true},
+		{objRef: route1, sectionName: "rule-2", want: false},
+		// A targetRef without a sectionName applies to all sections.
+		{objRef: route2, sectionName: "rule-2", want: true},
+		{objRef: route3, sectionName: "rule-1", want: false},
+	}
+	for _, tc := range testCases {
+		if got := policy.IsAttachedToSection(tc.objRef, tc.sectionName); got != tc.want {
+			t.Errorf("IsAttachedToSection(%v, %q) = %v, want %v", tc.objRef, tc.sectionName, got, tc.want)
+		}
+	}
+}
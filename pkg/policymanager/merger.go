@@ -19,6 +19,8 @@ package policymanager
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -147,12 +149,37 @@ func mergePolicy(parent, child *Policy) (*Policy, error) {
 
 	result := child.DeepCopy()
 	result.Unstructured.SetUnstructuredContent(resultUnstructured)
+	result.fieldSources = mergeFieldSources(parent.FieldSources(), child.FieldSources())
 	// Merging two policies means the targetRef no longer makes any sense since
 	// since they can be conflicting. So we unset the targetRef.
 	result.TargetRefs = []common.GKNN{}
 	return result, nil
 }
 
+// mergeFieldSources returns the sources of the fields of the policy resulting
+// from mergePolicy. The defaults from the child replace those from the parent,
+// overrides replace all defaults, and overrides from the parent replace those
+// from the child.
+func mergeFieldSources(parent, child map[string]FieldSource) map[string]FieldSource {
+	result := make(map[string]FieldSource)
+	for _, step := range []struct {
+		fieldSources map[string]FieldSource
+		override     bool
+	}{
+		{fieldSources: parent, override: false},
+		{fieldSources: child, override: false},
+		{fieldSources: child, override: true},
+		{fieldSources: parent, override: true},
+	} {
+		for _, field := range slices.Sorted(maps.Keys(step.fieldSources)) {
+			if source := step.fieldSources[field]; source.Override == step.override {
+				setFieldSource(result, field, source)
+			}
+		}
+	}
+	return result
+}
+
 func mergeUnstructured(parent, patch map[string]interface{}) (map[string]interface{}, error) {
 	currentJSON, err := json.Marshal(parent)
 	if err != nil {
@@ -183,22 +210,38 @@ func mergeUnstructured(parent, patch map[string]interface{}) (map[string]interfa
 //
 // [Gateway Specification]: https://gateway-api.sigs.k8s.io/geps/gep-713/#conflict-resolution
 func orderPolicyByPrecedence(a, b *Policy) (*Policy, *Policy) {
-	lowerPolicy := a.DeepCopy()  // lowerPolicy will have lower precedence.
-	higherPolicy := b.DeepCopy() // higherPolicy will have higher precedence.
-
-	if lowerPolicy.Unstructured.GetCreationTimestamp() == higherPolicy.Unstructured.GetCreationTimestamp() {
-		// Policies have the same creation time, so precedence is decided based
-		// on alphabetical ordering.
-		higherNN := fmt.Sprintf("%v/%v", higherPolicy.Unstructured.GetNamespace(), higherPolicy.Unstructured.GetName())
-		lowerNN := fmt.Sprintf("%v/%v", lowerPolicy.Unstructured.GetNamespace(), lowerPolicy.Unstructured.GetName())
-		if higherNN > lowerNN {
-			higherPolicy, lowerPolicy = lowerPolicy, higherPolicy
-		}
-	} else if higherPolicy.Unstructured.GetCreationTimestamp().After(lowerPolicy.Unstructured.GetCreationTimestamp().Time) {
-		// Policies have difference creation time, so this will decide the precedence
-		higherPolicy, lowerPolicy = lowerPolicy, higherPolicy
+	if HigherPrecedencePolicy(a, b) == a {
+		return b.DeepCopy(), a.DeepCopy()
 	}
+	return a.DeepCopy(), b.DeepCopy()
+}
 
-	// At this point, higherPolicy will have precedence over lowerPolicy.
-	return lowerPolicy, higherPolicy
+// HigherPrecedencePolicy returns whichever of the two policies takes precedence
+// as per the [Gateway Specification]. The older policy takes precedence, and
+// policies created at the same time are ordered alphabetically by their
+// namespace and name. A policy without a creation timestamp has not been
+// created yet (for example, when it is being analyzed before being applied),
+// so it is considered to be newer than any policy which has one.
+//
+// [Gateway Specification]: https://gateway-api.sigs.k8s.io/geps/gep-713/#conflict-resolution
+func HigherPrecedencePolicy(a, b *Policy) *Policy {
+	aTime, bTime := a.Unstructured.GetCreationTimestamp(), b.Unstructured.GetCreationTimestamp()
+	if aTime.IsZero() != bTime.IsZero() {
+		if aTime.IsZero() {
+			return b
+		}
+		return a
+	}
+	if aTime.Equal(&bTime) {
+		aNN := fmt.Sprintf("%v/%v", a.Unstructured.GetNamespace(), a.Unstructured.GetName())
+		bNN := fmt.Sprintf("%v/%v", b.Unstructured.GetNamespace(), b.Unstructured.GetName())
+		if aNN < bNN {
+			return a
+		}
+		return b
+	}
+	if aTime.Before(&bTime) {
+		return a
+	}
+	return b
 }
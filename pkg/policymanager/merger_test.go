@@ -143,6 +143,13 @@ func TestMergePoliciesOfSimilarKind(t *testing.T) {
 			},
 			TargetRefs:  []common.GKNN{},
 			Inheritable: true,
+			fieldSources: map[string]FieldSource{
+				"key1": {Policy: common.GKNN{Group: "foo.com", Kind: "HealthCheckPolicy", Name: "health-check-2"}, Override: true},
+				"key2": {Policy: common.GKNN{Group: "foo.com", Kind: "HealthCheckPolicy", Name: "health-check-1"}},
+				"key3": {Policy: common.GKNN{Group: "foo.com", Kind: "HealthCheckPolicy", Name: "health-check-1"}, Override: true},
+				"key4": {Policy: common.GKNN{Group: "foo.com", Kind: "HealthCheckPolicy", Name: "health-check-1"}},
+				"key5": {Policy: common.GKNN{Group: "foo.com", Kind: "HealthCheckPolicy", Name: "health-check-1"}},
+			},
 		},
 		PolicyCrdID("TimeoutPolicy.bar.com"): {
 			Unstructured: &unstructured.Unstructured{
@@ -163,6 +170,10 @@ func TestMergePoliciesOfSimilarKind(t *testing.T) {
 				},
 			},
 			TargetRefs: []common.GKNN{},
+			fieldSources: map[string]FieldSource{
+				"condition": {Policy: common.GKNN{Group: "bar.com", Kind: "TimeoutPolicy", Name: "timeout-policy-1"}},
+				"seconds":   {Policy: common.GKNN{Group: "bar.com", Kind: "TimeoutPolicy", Name: "timeout-policy-1"}},
+			},
 		},
 	}
 
@@ -449,3 +460,121 @@ func policySliceToMap(policies []*Policy) map[PolicyCrdID]*Policy {
 	}
 	return res
 }
+
+func TestHigherPrecedencePolicy(t *testing.T) {
+	newPolicy := func(namespace, name string, creationTimestamp *metav1.Time) *Policy {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("foo.com/v1")
+		u.SetKind("HealthCheckPolicy")
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		if creationTimestamp != nil {
+			u.SetCreationTimestamp(*creationTimestamp)
+		}
+		return &Policy{Unstructured: u}
+	}
+	older := &metav1.Time{Time: time.Now().Add(-1 * time.Hour).Truncate(time.Second)}
+	newer := &metav1.Time{Time: time.Now().Truncate(time.Second)}
+
+	testCases := []struct {
+		name string
+		a, b *Policy
+		// wantA is true if a should take precedence.
+		wantA bool
+	}{
+		{
+			name:  "older policy takes precedence",
+			a:     newPolicy("ns", "b", older),
+			b:     newPolicy("ns", "a", newer),
+			wantA: true,
+		},
+		{
+			name: "alphabetical order breaks ties",
+			a:    newPolicy("ns", "b", older),
+			b:    newPolicy("ns", "a", older),
+		},
+		{
+			name:  "policy without creation timestamp is newer",
+			a:     newPolicy("ns", "b", newer),
+			b:     newPolicy("ns", "a", nil),
+			wantA: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			want := tc.b
+			if tc.wantA {
+				want = tc.a
+			}
+			if got := HigherPrecedencePolicy(tc.a, tc.b); got != want {
+				t.Errorf("HigherPrecedencePolicy() = %v, want %v", got.GKNN(), want.GKNN())
+			}
+			if got := HigherPrecedencePolicy(tc.b, tc.a); got != want {
+				t.Errorf("HigherPrecedencePolicy() with swapped arguments = %v, want %v", got.GKNN(), want.GKNN())
+			}
+		})
+	}
+}
+
+func TestMergedFieldSources(t *testing.T) {
+	gatewayPolicy := &Policy{
+		Inheritable: true,
+		TargetRefs:  []common.GKNN{{Group: "gateway.networking.k8s.io", Kind: "Gateway", Namespace: "infra", Name: "gateway-1"}},
+		Unstructured: &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name":      "gateway-timeouts",
+					"namespace": "infra",
+				},
+				"spec": map[string]interface{}{
+					"override": map[string]interface{}{
+						"timeout": map[string]interface{}{"request": "10s"},
+					},
+					"default": map[string]interface{}{
+						"timeout": map[string]interface{}{"idle": "30s"},
+						"retries": int64(3),
+					},
+				},
+			},
+		},
+	}
+	routePolicy := &Policy{
+		Inheritable: true,
+		TargetRefs:  []common.GKNN{{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "apps", Name: "route-1"}},
+		Unstructured: &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name":      "route-timeouts",
+					"namespace": "apps",
+				},
+				"spec": map[string]interface{}{
+					"default": map[string]interface{}{
+						"timeout": map[string]interface{}{"idle": "60s", "request": "5s"},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := MergePoliciesOfDifferentHierarchy(
+		policySliceToMap([]*Policy{gatewayPolicy}),
+		policySliceToMap([]*Policy{routePolicy}),
+	)
+	if err != nil {
+		t.Fatalf("MergePoliciesOfDifferentHierarchy(...) returned err=%v; want no error", err)
+	}
+
+	want := map[string]FieldSource{
+		"retries":         {Policy: gatewayPolicy.GKNN(), Level: "Gateway"},
+		"timeout.idle":    {Policy: routePolicy.GKNN(), Level: "HTTPRoute"},
+		"timeout.request": {Policy: gatewayPolicy.GKNN(), Level: "Gateway", Override: true},
+	}
+	if diff := cmp.Diff(want, got[PolicyCrdID("TimeoutPolicy.bar.com")].FieldSources()); diff != "" {
+		t.Errorf("FieldSources() returned unexpected diff (-want, +got):\n%v", diff)
+	}
+}
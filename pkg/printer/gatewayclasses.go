@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/duration"
 
 	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
+	extensionutils "sigs.k8s.io/gwctl/pkg/extension/utils"
 	"sigs.k8s.io/gwctl/pkg/policymanager"
 	"sigs.k8s.io/gwctl/pkg/topology"
 	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
@@ -137,6 +138,15 @@ func (p *DescriptionPrinter) printGatewayClass(gatewayClassNode *topology.Node,
 	policies := policymanager.ConvertPoliciesMapToSlice(policiesMap)
 	pairs = append(pairs, &DescriberKV{Key: "DirectlyAttachedPolicies", Value: convertPoliciesToRefsTable(policies, false)})
 
+	// Analysis
+	analysisErrors, err := extensionutils.AggregateAnalysisErrors(gatewayClassNode)
+	if err != nil {
+		return err
+	}
+	if len(analysisErrors) != 0 {
+		pairs = append(pairs, &DescriberKV{Key: "Analysis", Value: convertErrorsToString(analysisErrors)})
+	}
+
 	// Events
 	events, err := p.EventFetcher.FetchEventsFor(gatewayClass)
 	if err != nil {
@@ -144,6 +154,6 @@ func (p *DescriptionPrinter) printGatewayClass(gatewayClassNode *topology.Node,
 	}
 	pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(events, p.Clock)})
 
-	Describe(w, pairs)
+	p.describe(w, pairs)
 	return nil
 }
@@ -17,17 +17,22 @@ limitations under the License.
 package printer //nolint:revive
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
 	"golang.org/x/exp/maps"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/duration"
 
+	"sigs.k8s.io/gwctl/pkg/common"
 	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
 	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
 	extensionutils "sigs.k8s.io/gwctl/pkg/extension/utils"
 	"sigs.k8s.io/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gwctl/pkg/routing"
 	"sigs.k8s.io/gwctl/pkg/topology"
 	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
 
@@ -127,8 +132,8 @@ func (p *DescriptionPrinter) printGateway(gatewayNode *topology.Node, w io.Write
 	}
 
 	const (
-		maxHTTPRoutes = 10
-		maxBackends   = 10
+		maxRoutes   = 10
+		maxBackends = 10
 	)
 
 	// AttachedRoutes
@@ -141,21 +146,21 @@ func (p *DescriptionPrinter) printGateway(gatewayNode *topology.Node, w io.Write
 		ColumnNames:  []string{"Kind", "Name"},
 		UseSeparator: true,
 	}
-	httpRouteCount, backendsCount := 0, 0
-	httpRouteNodes := maps.Values(topologygw.GatewayNode(gatewayNode).HTTPRoutes())
-	for _, httpRouteNode := range topology.SortedNodes(httpRouteNodes) {
-		httpRouteCount++
-		if httpRouteCount > maxHTTPRoutes {
+	routeCount, backendsCount := 0, 0
+	routeNodes := maps.Values(topologygw.GatewayNode(gatewayNode).Routes())
+	for _, routeNode := range topology.SortedNodes(routeNodes) {
+		routeCount++
+		if routeCount > maxRoutes {
 			attachedRoutes.Rows = append(attachedRoutes.Rows, []string{"(Truncated)"})
 			break
 		}
 		row := []string{
-			httpRouteNode.GKNN().Kind,                      // Kind
-			httpRouteNode.GKNN().NamespacedName().String(), // Name
+			routeNode.GKNN().Kind,                      // Kind
+			routeNode.GKNN().NamespacedName().String(), // Name
 		}
 		attachedRoutes.Rows = append(attachedRoutes.Rows, row)
 
-		backendNodes := maps.Values(topologygw.HTTPRouteNode(httpRouteNode).Backends())
+		backendNodes := maps.Values(topologygw.RouteNode(routeNode).Backends())
 		for _, backendNode := range topology.SortedNodes(backendNodes) {
 			backendsCount++
 			if backendsCount > maxBackends {
@@ -172,6 +177,69 @@ func (p *DescriptionPrinter) printGateway(gatewayNode *topology.Node, w io.Write
 	pairs = append(pairs, &DescriberKV{Key: "AttachedRoutes", Value: attachedRoutes})
 	pairs = append(pairs, &DescriberKV{Key: "Backends", Value: backends})
 
+	// RoutesByListener
+	routesByListener := &Table{
+		ColumnNames:  []string{"Listener", "Port", "Protocol", "Kind", "Name"},
+		UseSeparator: true,
+	}
+	listenerNodes := topologygw.GatewayNode(gatewayNode).Listeners()
+	for _, listener := range gateway.Spec.Listeners {
+		rowPrefix := []string{
+			string(listener.Name),            // Listener
+			fmt.Sprintf("%d", listener.Port), // Port
+			string(listener.Protocol),        // Protocol
+		}
+
+		var routeNodes []*topology.Node
+		listenerGKNN := common.ListenerGKNN(types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}, listener.Name)
+		if listenerNode, ok := listenerNodes[listenerGKNN]; ok {
+			routeNodes = maps.Values(topologygw.ListenerNode(listenerNode).Routes())
+		}
+		if len(routeNodes) == 0 {
+			routesByListener.Rows = append(routesByListener.Rows, append(rowPrefix, "<none>", ""))
+			continue
+		}
+		for _, routeNode := range topology.SortedNodes(routeNodes) {
+			row := append(slices.Clone(rowPrefix),
+				routeNode.GKNN().Kind,                      // Kind
+				routeNode.GKNN().NamespacedName().String(), // Name
+			)
+			routesByListener.Rows = append(routesByListener.Rows, row)
+		}
+	}
+	pairs = append(pairs, &DescriberKV{Key: "RoutesByListener", Value: routesByListener})
+
+	// RoutingTable
+	if p.RoutingTable {
+		pairs = append(pairs, &DescriberKV{Key: "RoutingTable", Value: convertRoutingTable(gateway, listenerNodes)})
+	}
+
+	// CertificateRefs
+	certificateRefs := &Table{
+		ColumnNames:  []string{"Listener", "Kind", "Name", "Found"},
+		UseSeparator: true,
+	}
+	secretNodes := topologygw.GatewayNode(gatewayNode).Secrets()
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.TLS == nil {
+			continue
+		}
+		for _, certificateRef := range listener.TLS.CertificateRefs {
+			gknn := topologygw.CertificateRefToGKNN(gateway.Namespace, certificateRef)
+			_, found := secretNodes[gknn]
+			row := []string{
+				string(listener.Name),          // Listener
+				gknn.Kind,                      // Kind
+				gknn.NamespacedName().String(), // Name
+				fmt.Sprintf("%v", found),       // Found
+			}
+			certificateRefs.Rows = append(certificateRefs.Rows, row)
+		}
+	}
+	if len(certificateRefs.Rows) != 0 {
+		pairs = append(pairs, &DescriberKV{Key: "CertificateRefs", Value: certificateRefs})
+	}
+
 	// DirectlyAttachedPolicies
 	policiesMap, err := directlyattachedpolicy.Access(gatewayNode)
 	if err != nil {
@@ -191,6 +259,16 @@ func (p *DescriptionPrinter) printGateway(gatewayNode *topology.Node, w io.Write
 	// EffectivePolicies``
 	if len(effectivePolicies.GatewayEffectivePolicies) != 0 {
 		pairs = append(pairs, &DescriberKV{Key: "EffectivePolicies", Value: effectivePolicies.GatewayEffectivePolicies})
+
+		effectivePolicySources, err := convertEffectivePolicySourcesToTable(map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy{
+			gatewayNode.GKNN(): effectivePolicies.GatewayEffectivePolicies,
+		}, false)
+		if err != nil {
+			return err
+		}
+		if len(effectivePolicySources.Rows) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "EffectivePolicySources", Value: effectivePolicySources})
+		}
 	}
 
 	// // Analysis
@@ -198,6 +276,9 @@ func (p *DescriptionPrinter) printGateway(gatewayNode *topology.Node, w io.Write
 	if err != nil {
 		return err
 	}
+	if listenerConflicts := convertListenerErrorsToTable(gateway, analysisErrors); len(listenerConflicts.Rows) != 0 {
+		pairs = append(pairs, &DescriberKV{Key: "ListenerConflicts", Value: listenerConflicts})
+	}
 	if len(analysisErrors) != 0 {
 		pairs = append(pairs, &DescriberKV{Key: "Analysis", Value: convertErrorsToString(analysisErrors)})
 	}
@@ -209,6 +290,110 @@ func (p *DescriptionPrinter) printGateway(gatewayNode *topology.Node, w io.Write
 	}
 	pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(events, p.Clock)})
 
-	Describe(w, pairs)
+	p.describe(w, pairs)
 	return nil
 }
+
+// convertListenerErrorsToTable lists the reasons of the errors caused by each
+// listener, in the order of the listeners within the Gateway.
+func convertListenerErrorsToTable(gateway *gatewayv1.Gateway, analysisErrors []error) *Table {
+	table := &Table{
+		ColumnNames:  []string{"Listener", "Reason"},
+		UseSeparator: true,
+	}
+	errorsByListener := make(map[string][]common.ListenerError)
+	for _, analysisErr := range analysisErrors {
+		var listenerErr common.ListenerError
+		if errors.As(analysisErr, &listenerErr) {
+			errorsByListener[listenerErr.Listener()] = append(errorsByListener[listenerErr.Listener()], listenerErr)
+		}
+	}
+	for _, listener := range gateway.Spec.Listeners {
+		for _, listenerErr := range errorsByListener[string(listener.Name)] {
+			table.Rows = append(table.Rows, []string{string(listener.Name), listenerErr.Reason()})
+		}
+		// Listeners with duplicate names are only listed once.
+		delete(errorsByListener, string(listener.Name))
+	}
+	return table
+}
+
+// convertRoutingTable flattens the rules of the HTTPRoutes attached to each
+// listener of the Gateway into the order in which they are evaluated. Within a
+// listener, rules are grouped by the hostname they match, from the most
+// specific hostname to the least specific one, and ordered by precedence
+// within each hostname.
+func convertRoutingTable(gateway *gatewayv1.Gateway, listenerNodes map[common.GKNN]*topology.Node) *Table {
+	table := &Table{
+		ColumnNames:  []string{"Listener", "Hostname", "Order", "Route", "Rule", "Match", "Backends"},
+		UseSeparator: true,
+	}
+	for _, listener := range gateway.Spec.Listeners {
+		var matches []routing.RuleMatch
+		listenerGKNN := common.ListenerGKNN(types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}, listener.Name)
+		if listenerNode, ok := listenerNodes[listenerGKNN]; ok {
+			matches = routing.ListenerRuleMatches(listenerNode)
+		}
+		if len(matches) == 0 {
+			table.Rows = append(table.Rows, []string{string(listener.Name), "", "", "<none>", "", "", ""})
+			continue
+		}
+
+		var hostnames []string
+		matchesByHostname := make(map[string][]routing.RuleMatch)
+		for _, m := range matches {
+			if _, ok := matchesByHostname[m.Hostname]; !ok {
+				hostnames = append(hostnames, m.Hostname)
+			}
+			matchesByHostname[m.Hostname] = append(matchesByHostname[m.Hostname], m)
+		}
+		for _, hostname := range hostnames {
+			hostnameOutput := hostname
+			if hostnameOutput == "" {
+				hostnameOutput = "*"
+			}
+			for i, m := range matchesByHostname[hostname] {
+				row := []string{
+					string(listener.Name),  // Listener
+					hostnameOutput,         // Hostname
+					fmt.Sprintf("%d", i+1), // Order
+					fmt.Sprintf("%v/%v", m.Route.Namespace, m.Route.Name), // Route
+					m.RuleName(),   // Rule
+					m.Conditions(), // Match
+					ruleBackendsOutput(m.Route.Namespace, m.Rule()), // Backends
+				}
+				table.Rows = append(table.Rows, row)
+			}
+		}
+	}
+	return table
+}
+
+// ruleBackendsOutput formats the backendRefs of an HTTPRoute rule. Weights are
+// only included when they affect how requests are split, or differ from the
+// default.
+func ruleBackendsOutput(routeNamespace string, rule gatewayv1.HTTPRouteRule) string {
+	var backends []string
+	for _, backendRef := range rule.BackendRefs {
+		namespace := routeNamespace
+		if backendRef.Namespace != nil {
+			namespace = string(*backendRef.Namespace)
+		}
+		output := fmt.Sprintf("%v/%v", namespace, backendRef.Name)
+		if backendRef.Kind != nil && *backendRef.Kind != "Service" {
+			output = fmt.Sprintf("%v %v", *backendRef.Kind, output)
+		}
+		if backendRef.Port != nil {
+			output = fmt.Sprintf("%v:%d", output, *backendRef.Port)
+		}
+		weight := int32(1)
+		if backendRef.Weight != nil {
+			weight = *backendRef.Weight
+		}
+		if len(rule.BackendRefs) > 1 || weight != 1 {
+			output = fmt.Sprintf("%v (%d)", output, weight)
+		}
+		backends = append(backends, output)
+	}
+	return strings.Join(backends, ", ")
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer //nolint:revive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+func TestTablePrinter_printGRPCRoute(t *testing.T) {
+	tests := []struct {
+		name    string
+		options PrinterOptions
+		wantOut string
+	}{
+		{
+			name:    "default",
+			options: PrinterOptions{},
+			wantOut: `
+NAME          HOSTNAMES     PARENT REFS  ACCEPTED  RESOLVED  AGE
+grpc-route-1  grpc.foo.com  1            Partial   True      <unknown>
+`,
+		},
+		{
+			name: "all namespaces",
+			options: PrinterOptions{
+				AllNamespaces: true,
+			},
+			wantOut: `
+NAMESPACE  NAME          HOSTNAMES     PARENT REFS  ACCEPTED  RESOLVED  AGE
+ns-1       grpc-route-1  grpc.foo.com  1            Partial   True      <unknown>
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &TablePrinter{PrinterOptions: tt.options}
+			out := &bytes.Buffer{}
+
+			for _, ns := range testData(t)[common.GRPCRouteGK] {
+				p.printGRPCRoute(ns, out)
+				p.Flush(out)
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tt.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v",
+					got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
@@ -27,7 +27,9 @@ import (
 	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
 	extensionutils "sigs.k8s.io/gwctl/pkg/extension/utils"
 	"sigs.k8s.io/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gwctl/pkg/routing"
 	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -51,69 +53,10 @@ func (p *TablePrinter) printHTTPRoute(httpRouteNode *topology.Node, w io.Writer)
 
 	httpRoute := topology.MustAccessObject(httpRouteNode, &gatewayv1.HTTPRoute{})
 
-	var hostNames []string
-	for _, hostName := range httpRoute.Spec.Hostnames {
-		hostNames = append(hostNames, string(hostName))
-	}
-	hostNamesOutput := "None"
-	if hostNamesCount := len(hostNames); hostNamesCount > 0 {
-		if hostNamesCount > 2 {
-			hostNamesOutput = fmt.Sprintf("%v + %v more", strings.Join(hostNames[:2], ","), hostNamesCount-2)
-		} else {
-			hostNamesOutput = strings.Join(hostNames, ",")
-		}
-	}
-
+	hostNamesOutput := routeHostnamesOutput(httpRoute.Spec.Hostnames)
 	parentRefsCount := fmt.Sprintf("%d", len(httpRoute.Spec.ParentRefs))
-
-	// Calculate accepted status
-	acceptedStatus := "Unknown"
-	acceptedCount := 0
-	totalParents := len(httpRoute.Status.Parents)
-
-	for _, parentStatus := range httpRoute.Status.Parents {
-		for _, condition := range parentStatus.Conditions {
-			if condition.Type == "Accepted" && condition.Status == "True" {
-				acceptedCount++
-				break
-			}
-		}
-	}
-
-	if totalParents > 0 {
-		switch {
-		case acceptedCount == totalParents:
-			acceptedStatus = "True"
-		case acceptedCount > 0:
-			acceptedStatus = "Partial"
-		default:
-			acceptedStatus = "False"
-		}
-	}
-
-	// Calculate resolved status for backend references
-	resolvedStatus := "Unknown"
-	resolvedCount := 0
-
-	for _, parentStatus := range httpRoute.Status.Parents {
-		for _, condition := range parentStatus.Conditions {
-			if condition.Type == "ResolvedRefs" && condition.Status == "True" {
-				resolvedCount++
-				break
-			}
-		}
-	}
-
-	if totalParents > 0 {
-		switch {
-		case acceptedCount == totalParents:
-			acceptedStatus = "True"
-		case acceptedCount > 0:
-			acceptedStatus = "Partial"
-		default:
-			acceptedStatus = "False"
-		}
-	}
+	acceptedStatus := routeParentsConditionStatus(httpRoute.Status.Parents, gatewayv1.RouteConditionAccepted)
+	resolvedStatus := routeParentsConditionStatus(httpRoute.Status.Parents, gatewayv1.RouteConditionResolvedRefs)
 
 	age := "<unknown>"
 	creationTimestamp := httpRoute.GetCreationTimestamp()
@@ -161,6 +104,11 @@ func (p *DescriptionPrinter) printHTTPRoute(httpRouteNode *topology.Node, w io.W
 		{"Status", httpRoute.Status},
 	}
 
+	// Rules
+	if rules := topologygw.HTTPRouteNode(httpRouteNode).Rules(); len(rules) != 0 {
+		pairs = append(pairs, &DescriberKV{Key: "Rules", Value: convertHTTPRouteRulesToTable(rules)})
+	}
+
 	// DirectlyAttachedPolicies
 	policiesMap, err := directlyattachedpolicy.Access(httpRouteNode)
 	if err != nil {
@@ -174,12 +122,20 @@ func (p *DescriptionPrinter) printHTTPRoute(httpRouteNode *topology.Node, w io.W
 	if err != nil {
 		return err
 	}
-	policies = policymanager.ConvertPoliciesMapToSlice(effectivePolicies.HTTPRouteInheritedPolicies)
+	policies = policymanager.ConvertPoliciesMapToSlice(effectivePolicies.RouteInheritedPolicies)
 	pairs = append(pairs, &DescriberKV{Key: "InheritedPolicies", Value: convertPoliciesToRefsTable(policies, true)})
 
 	// EffectivePolicies
-	if len(effectivePolicies.HTTPRouteEffectivePolicies) != 0 {
-		pairs = append(pairs, &DescriberKV{Key: "EffectivePolicies", Value: effectivePolicies.HTTPRouteEffectivePolicies})
+	if len(effectivePolicies.RouteEffectivePolicies) != 0 {
+		pairs = append(pairs, &DescriberKV{Key: "EffectivePolicies", Value: effectivePolicies.RouteEffectivePolicies})
+
+		effectivePolicySources, err := convertEffectivePolicySourcesToTable(effectivePolicies.RouteEffectivePolicies, true)
+		if err != nil {
+			return err
+		}
+		if len(effectivePolicySources.Rows) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "EffectivePolicySources", Value: effectivePolicySources})
+		}
 	}
 
 	// Analysis
@@ -198,6 +154,55 @@ func (p *DescriptionPrinter) printHTTPRoute(httpRouteNode *topology.Node, w io.W
 	}
 	pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(events, p.Clock)})
 
-	Describe(w, pairs)
+	p.describe(w, pairs)
 	return nil
 }
+
+// convertHTTPRouteRulesToTable shows the matches, filters and backends of each
+// rule, along with the percentage of the requests matching the rule which each
+// backend receives.
+func convertHTTPRouteRulesToTable(rules []topologygw.HTTPRouteRule) *Table {
+	table := &Table{
+		ColumnNames:  []string{"Rule", "Matches", "Filters", "Backends"},
+		UseSeparator: true,
+	}
+	for _, rule := range rules {
+		matches := rule.Rule.Matches
+		if len(matches) == 0 {
+			matches = []gatewayv1.HTTPRouteMatch{{}}
+		}
+		var matchesOutput []string
+		for _, match := range matches {
+			matchesOutput = append(matchesOutput, routing.MatchConditions(match))
+		}
+
+		var filters []string
+		for _, filter := range rule.Rule.Filters {
+			filters = append(filters, string(filter.Type))
+		}
+
+		var backends []string
+		for _, backend := range rule.Backends {
+			backends = append(backends, fmt.Sprintf("%v (%v)", backend, topologygw.FormatPercent(backend.Percent)))
+		}
+		for _, mirror := range rule.Mirrors {
+			backends = append(backends, fmt.Sprintf("%v (mirror %v)", mirror, topologygw.FormatPercent(mirror.Percent)))
+		}
+
+		row := []string{
+			rule.Name(),                       // Rule
+			strings.Join(matchesOutput, ", "), // Matches
+			joinOrNone(filters),               // Filters
+			joinOrNone(backends),              // Backends
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+	return strings.Join(values, ", ")
+}
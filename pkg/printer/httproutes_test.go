@@ -22,8 +22,11 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"sigs.k8s.io/gwctl/pkg/common"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func TestTablePrinter_printHTTPRoute(t *testing.T) {
@@ -72,3 +75,56 @@ ns-1       http-route-1  foo.com,bar.com + 5 more  1            Unknown   Unknow
 		})
 	}
 }
+
+func TestTablePrinter_printHTTPRoute_ParentsStatus(t *testing.T) {
+	httpRouteNode := mustNewNode(t, &gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1.GroupVersion.String(),
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "http-route-2",
+			Namespace: "ns-1",
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "gateway-1"}, {Name: "gateway-2"}},
+			},
+		},
+		Status: gatewayv1.HTTPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{
+					{
+						ParentRef: gatewayv1.ParentReference{Name: "gateway-1"},
+						Conditions: []metav1.Condition{
+							{Type: "Accepted", Status: "True"},
+							{Type: "ResolvedRefs", Status: "True"},
+						},
+					},
+					{
+						ParentRef: gatewayv1.ParentReference{Name: "gateway-2"},
+						Conditions: []metav1.Condition{
+							{Type: "Accepted", Status: "True"},
+							{Type: "ResolvedRefs", Status: "False"},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	p := &TablePrinter{}
+	out := &bytes.Buffer{}
+	p.printHTTPRoute(httpRouteNode, out)
+	p.Flush(out)
+
+	got := common.MultiLine(out.String())
+	want := common.MultiLine(strings.TrimPrefix(`
+NAME          HOSTNAMES  PARENT REFS  ACCEPTED  RESOLVED  AGE
+http-route-2  None       2            True      Partial   <unknown>
+`, "\n"))
+	if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+		t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v",
+			got, want, common.MultiLine(diff))
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer //nolint:revive
+
+import (
+	"fmt"
+	"io"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
+	"sigs.k8s.io/gwctl/pkg/extension/gatewayeffectivepolicy"
+	extensionutils "sigs.k8s.io/gwctl/pkg/extension/utils"
+	"sigs.k8s.io/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gwctl/pkg/topology"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// l4Route holds the fields which TLSRoutes, TCPRoutes and UDPRoutes have in
+// common, so that they can be printed the same way.
+type l4Route struct {
+	client.Object
+	metadata *metav1.ObjectMeta
+
+	// hasHostnames is true for TLSRoutes, which are the only one of these
+	// kinds with hostnames.
+	hasHostnames bool
+	hostnames    []gatewayv1.Hostname
+	parentRefs   []gatewayv1.ParentReference
+	parents      []gatewayv1.RouteParentStatus
+
+	spec   any
+	status any
+}
+
+func accessTLSRoute(node *topology.Node) *l4Route {
+	tlsRoute := topology.MustAccessObject(node, &gatewayv1.TLSRoute{})
+	return &l4Route{
+		Object:       tlsRoute,
+		metadata:     tlsRoute.ObjectMeta.DeepCopy(),
+		hasHostnames: true,
+		hostnames:    tlsRoute.Spec.Hostnames,
+		parentRefs:   tlsRoute.Spec.ParentRefs,
+		parents:      tlsRoute.Status.Parents,
+		spec:         tlsRoute.Spec,
+		status:       tlsRoute.Status,
+	}
+}
+
+func accessTCPRoute(node *topology.Node) *l4Route {
+	tcpRoute := topology.MustAccessObject(node, &gatewayv1.TCPRoute{})
+	return &l4Route{
+		Object:     tcpRoute,
+		metadata:   tcpRoute.ObjectMeta.DeepCopy(),
+		parentRefs: tcpRoute.Spec.ParentRefs,
+		parents:    tcpRoute.Status.Parents,
+		spec:       tcpRoute.Spec,
+		status:     tcpRoute.Status,
+	}
+}
+
+func accessUDPRoute(node *topology.Node) *l4Route {
+	udpRoute := topology.MustAccessObject(node, &gatewayv1.UDPRoute{})
+	return &l4Route{
+		Object:     udpRoute,
+		metadata:   udpRoute.ObjectMeta.DeepCopy(),
+		parentRefs: udpRoute.Spec.ParentRefs,
+		parents:    udpRoute.Status.Parents,
+		spec:       udpRoute.Spec,
+		status:     udpRoute.Status,
+	}
+}
+
+func (p *TablePrinter) printTLSRoute(tlsRouteNode *topology.Node, w io.Writer) error {
+	return p.printL4Route(tlsRouteNode, "TLSRoute", accessTLSRoute(tlsRouteNode), w)
+}
+
+func (p *TablePrinter) printTCPRoute(tcpRouteNode *topology.Node, w io.Writer) error {
+	return p.printL4Route(tcpRouteNode, "TCPRoute", accessTCPRoute(tcpRouteNode), w)
+}
+
+func (p *TablePrinter) printUDPRoute(udpRouteNode *topology.Node, w io.Writer) error {
+	return p.printL4Route(udpRouteNode, "UDPRoute", accessUDPRoute(udpRouteNode), w)
+}
+
+func (p *TablePrinter) printL4Route(routeNode *topology.Node, kind string, route *l4Route, w io.Writer) error {
+	if err := p.checkTypeChange(kind, w); err != nil {
+		return err
+	}
+
+	if p.table == nil {
+		columnNames := namespacedBaseColumnNames(p.AllNamespaces)
+		if route.hasHostnames {
+			columnNames = append(columnNames, "HOSTNAMES")
+		}
+		columnNames = append(columnNames, "PARENT REFS", "ACCEPTED", "RESOLVED", "AGE")
+		if p.OutputFormat == OutputFormatWide {
+			columnNames = append(columnNames, "POLICIES")
+		}
+		p.table = &Table{
+			ColumnNames:  columnNames,
+			UseSeparator: false,
+		}
+	}
+
+	row := rowPrefixNamespaced(route, p.AllNamespaces)
+	if route.hasHostnames {
+		row = append(row, routeHostnamesOutput(route.hostnames))
+	}
+	parentRefsCount := fmt.Sprintf("%d", len(route.parentRefs))
+	acceptedStatus := routeParentsConditionStatus(route.parents, gatewayv1.RouteConditionAccepted)
+	resolvedStatus := routeParentsConditionStatus(route.parents, gatewayv1.RouteConditionResolvedRefs)
+
+	age := "<unknown>"
+	creationTimestamp := route.GetCreationTimestamp()
+	if !creationTimestamp.IsZero() {
+		age = duration.HumanDuration(p.Clock.Since(creationTimestamp.Time))
+	}
+
+	row = append(row, parentRefsCount, acceptedStatus, resolvedStatus, age)
+	if p.OutputFormat == OutputFormatWide {
+		policiesMap, err := directlyattachedpolicy.Access(routeNode)
+		if err != nil {
+			return err
+		}
+		policiesCount := fmt.Sprintf("%d", len(policiesMap))
+		row = append(row, policiesCount)
+	}
+	p.table.Rows = append(p.table.Rows, row)
+	return nil
+}
+
+func (p *DescriptionPrinter) printTLSRoute(tlsRouteNode *topology.Node, w io.Writer) error {
+	return p.printL4Route(tlsRouteNode, accessTLSRoute(tlsRouteNode), w)
+}
+
+func (p *DescriptionPrinter) printTCPRoute(tcpRouteNode *topology.Node, w io.Writer) error {
+	return p.printL4Route(tcpRouteNode, accessTCPRoute(tcpRouteNode), w)
+}
+
+func (p *DescriptionPrinter) printUDPRoute(udpRouteNode *topology.Node, w io.Writer) error {
+	return p.printL4Route(udpRouteNode, accessUDPRoute(udpRouteNode), w)
+}
+
+func (p *DescriptionPrinter) printL4Route(routeNode *topology.Node, route *l4Route, w io.Writer) error {
+	if p.printSeparator {
+		fmt.Fprintf(w, "\n\n")
+	}
+	p.printSeparator = true
+
+	gvk := route.GetObjectKind().GroupVersionKind()
+
+	metadata := route.metadata
+	metadata.Labels = nil
+	metadata.Annotations = nil
+	metadata.Name = ""
+	metadata.Namespace = ""
+	metadata.ManagedFields = nil
+
+	pairs := []*DescriberKV{
+		{"Name", route.GetName()},
+		{"Namespace", route.GetNamespace()},
+		{"Label", route.GetLabels()},
+		{"Annotations", route.GetAnnotations()},
+		{"APIVersion", gvk.GroupVersion().String()},
+		{"Kind", gvk.Kind},
+		{"Metadata", metadata},
+		{"Spec", route.spec},
+		{"Status", route.status},
+	}
+
+	// DirectlyAttachedPolicies
+	policiesMap, err := directlyattachedpolicy.Access(routeNode)
+	if err != nil {
+		return err
+	}
+	policies := policymanager.ConvertPoliciesMapToSlice(policiesMap)
+	pairs = append(pairs, &DescriberKV{Key: "DirectlyAttachedPolicies", Value: convertPoliciesToRefsTable(policies, false)})
+
+	// InheritedPolicies
+	effectivePolicies, err := gatewayeffectivepolicy.Access(routeNode)
+	if err != nil {
+		return err
+	}
+	policies = policymanager.ConvertPoliciesMapToSlice(effectivePolicies.RouteInheritedPolicies)
+	pairs = append(pairs, &DescriberKV{Key: "InheritedPolicies", Value: convertPoliciesToRefsTable(policies, true)})
+
+	// EffectivePolicies
+	if len(effectivePolicies.RouteEffectivePolicies) != 0 {
+		pairs = append(pairs, &DescriberKV{Key: "EffectivePolicies", Value: effectivePolicies.RouteEffectivePolicies})
+
+		effectivePolicySources, err := convertEffectivePolicySourcesToTable(effectivePolicies.RouteEffectivePolicies, true)
+		if err != nil {
+			return err
+		}
+		if len(effectivePolicySources.Rows) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "EffectivePolicySources", Value: effectivePolicySources})
+		}
+	}
+
+	// Analysis
+	analysisErrors, err := extensionutils.AggregateAnalysisErrors(routeNode)
+	if err != nil {
+		return err
+	}
+	if len(analysisErrors) != 0 {
+		pairs = append(pairs, &DescriberKV{Key: "Analysis", Value: convertErrorsToString(analysisErrors)})
+	}
+
+	// Events
+	events, err := p.EventFetcher.FetchEventsFor(route.Object)
+	if err != nil {
+		return err
+	}
+	pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(events, p.Clock)})
+
+	p.describe(w, pairs)
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer //nolint:revive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestTablePrinter_printL4Routes(t *testing.T) {
+	parentRefs := []gatewayv1.ParentReference{{Name: "gateway-1"}}
+	parents := []gatewayv1.RouteParentStatus{
+		{
+			ParentRef: gatewayv1.ParentReference{Name: "gateway-1"},
+			Conditions: []metav1.Condition{
+				{Type: "Accepted", Status: "True"},
+				{Type: "ResolvedRefs", Status: "False"},
+			},
+		},
+	}
+
+	tlsRouteNode := mustNewNode(t, &gatewayv1.TLSRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1.GroupVersion.String(),
+			Kind:       "TLSRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tls-route-1",
+			Namespace: "ns-1",
+		},
+		Spec: gatewayv1.TLSRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+			Hostnames:       []gatewayv1.Hostname{"tls.foo.com"},
+		},
+		Status: gatewayv1.TLSRouteStatus{RouteStatus: gatewayv1.RouteStatus{Parents: parents}},
+	})
+	tcpRouteNode := mustNewNode(t, &gatewayv1.TCPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1.GroupVersion.String(),
+			Kind:       "TCPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tcp-route-1",
+			Namespace: "ns-1",
+		},
+		Spec: gatewayv1.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+		},
+		Status: gatewayv1.TCPRouteStatus{RouteStatus: gatewayv1.RouteStatus{Parents: parents}},
+	})
+	udpRouteNode := mustNewNode(t, &gatewayv1.UDPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1.GroupVersion.String(),
+			Kind:       "UDPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "udp-route-1",
+			Namespace: "ns-1",
+		},
+		Spec: gatewayv1.UDPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+		},
+	})
+
+	tests := []struct {
+		name    string
+		options PrinterOptions
+		nodes   []*topology.Node
+		wantOut string
+	}{
+		{
+			name:    "tlsroute",
+			options: PrinterOptions{},
+			nodes:   []*topology.Node{tlsRouteNode},
+			wantOut: `
+NAME         HOSTNAMES    PARENT REFS  ACCEPTED  RESOLVED  AGE
+tls-route-1  tls.foo.com  1            True      False     <unknown>
+`,
+		},
+		{
+			name: "tcproute and udproute in all namespaces",
+			options: PrinterOptions{
+				AllNamespaces: true,
+			},
+			nodes: []*topology.Node{tcpRouteNode, udpRouteNode},
+			wantOut: `
+NAMESPACE  NAME         PARENT REFS  ACCEPTED  RESOLVED  AGE
+ns-1       tcp-route-1  1            True      False     <unknown>
+
+NAMESPACE  NAME         PARENT REFS  ACCEPTED  RESOLVED  AGE
+ns-1       udp-route-1  1            Unknown   Unknown   <unknown>
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &TablePrinter{PrinterOptions: tt.options}
+			out := &bytes.Buffer{}
+
+			for _, node := range tt.nodes {
+				p.PrintNode(node, out)
+			}
+			p.Flush(out)
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tt.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v",
+					got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/gwctl/pkg/topology"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 func TestMain(m *testing.M) {
@@ -164,6 +165,66 @@ func testData(t *testing.T) map[schema.GroupKind][]*topology.Node {
 		},
 	)
 
+	grpcRoute1 := mustNewNode(t,
+		&gatewayv1.GRPCRoute{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: gatewayv1.GroupVersion.String(),
+				Kind:       "GRPCRoute",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "grpc-route-1",
+				Namespace: "ns-1",
+			},
+			Spec: gatewayv1.GRPCRouteSpec{
+				Hostnames: []gatewayv1.Hostname{"grpc.foo.com"},
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{
+						{
+							Kind:      ptr.To(gatewayv1.Kind("Gateway")),
+							Group:     ptr.To(gatewayv1.Group("gateway.networking.k8s.io")),
+							Namespace: ptr.To(gatewayv1.Namespace("ns-1")),
+							Name:      "gateway-1",
+						},
+					},
+				},
+				Rules: []gatewayv1.GRPCRouteRule{
+					{
+						BackendRefs: []gatewayv1.GRPCBackendRef{
+							{
+								BackendRef: gatewayv1.BackendRef{
+									BackendObjectReference: gatewayv1.BackendObjectReference{
+										Port: ptr.To(gatewayv1.PortNumber(9090)),
+										Name: gatewayv1.ObjectName("service-1"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: gatewayv1.GRPCRouteStatus{
+				RouteStatus: gatewayv1.RouteStatus{
+					Parents: []gatewayv1.RouteParentStatus{
+						{
+							ParentRef: gatewayv1.ParentReference{Name: "gateway-1"},
+							Conditions: []metav1.Condition{
+								{Type: "Accepted", Status: "True"},
+								{Type: "ResolvedRefs", Status: "True"},
+							},
+						},
+						{
+							ParentRef: gatewayv1.ParentReference{Name: "gateway-2"},
+							Conditions: []metav1.Condition{
+								{Type: "Accepted", Status: "False"},
+								{Type: "ResolvedRefs", Status: "True"},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+
 	service1 := mustNewNode(t, &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Service",
@@ -175,12 +236,35 @@ func testData(t *testing.T) map[schema.GroupKind][]*topology.Node {
 		},
 	})
 
+	referenceGrant1 := mustNewNode(t, &gatewayv1beta1.ReferenceGrant{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1beta1.GroupVersion.String(),
+			Kind:       "ReferenceGrant",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reference-grant-1",
+			Namespace: "ns-1",
+		},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{
+				{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "ns-2"},
+				{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "ns-3"},
+			},
+			To: []gatewayv1beta1.ReferenceGrantTo{
+				{Group: "", Kind: "Service", Name: ptr.To(gatewayv1.ObjectName("svc-1"))},
+				{Group: "", Kind: "Secret"},
+			},
+		},
+	})
+
 	graph := &topology.Graph{}
 	graph.AddNode(ns1)
 	graph.AddNode(gatewayClass1)
 	graph.AddNode(gateway1)
 	graph.AddNode(httpRoute1)
+	graph.AddNode(grpcRoute1)
 	graph.AddNode(service1)
+	graph.AddNode(referenceGrant1)
 
 	result := map[schema.GroupKind][]*topology.Node{}
 	for gk, nodes := range graph.Nodes {
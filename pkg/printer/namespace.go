@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/duration"
 
 	"sigs.k8s.io/gwctl/pkg/extension/directlyattachedpolicy"
+	extensionutils "sigs.k8s.io/gwctl/pkg/extension/utils"
 	"sigs.k8s.io/gwctl/pkg/policymanager"
 	"sigs.k8s.io/gwctl/pkg/topology"
 )
@@ -101,6 +102,15 @@ func (p *DescriptionPrinter) printNamespace(namespaceNode *topology.Node, w io.W
 	policies := policymanager.ConvertPoliciesMapToSlice(policiesMap)
 	pairs = append(pairs, &DescriberKV{Key: "DirectlyAttachedPolicies", Value: convertPoliciesToRefsTable(policies, false)})
 
+	// Analysis
+	analysisErrors, err := extensionutils.AggregateAnalysisErrors(namespaceNode)
+	if err != nil {
+		return err
+	}
+	if len(analysisErrors) != 0 {
+		pairs = append(pairs, &DescriberKV{Key: "Analysis", Value: convertErrorsToString(analysisErrors)})
+	}
+
 	// Events
 	events, err := p.EventFetcher.FetchEventsFor(namespace)
 	if err != nil {
@@ -108,6 +118,6 @@ func (p *DescriptionPrinter) printNamespace(namespaceNode *topology.Node, w io.W
 	}
 	pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(events, p.Clock)})
 
-	Describe(w, pairs)
+	p.describe(w, pairs)
 	return nil
 }
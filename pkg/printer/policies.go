@@ -151,7 +151,7 @@ func (p *DescriptionPrinter) printPolicy(policyNode *topology.Node, w io.Writer)
 		{Key: "Spec", Value: policy.Spec()},
 	}
 
-	Describe(w, pairs)
+	p.describe(w, pairs)
 	return nil
 }
 
@@ -186,7 +186,7 @@ func (p *DescriptionPrinter) printPolicyCRD(policyCRDNode *topology.Node, w io.W
 		{Key: "Spec", Value: crd.Spec},
 		{Key: "Status", Value: crd.Status},
 	}
-	Describe(w, pairs)
+	p.describe(w, pairs)
 	return nil
 }
 
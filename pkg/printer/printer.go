@@ -17,6 +17,8 @@ limitations under the License.
 package printer //nolint:revive
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 
@@ -25,6 +27,7 @@ import (
 
 	"sigs.k8s.io/gwctl/pkg/common"
 	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
 )
 
 type OutputFormat string
@@ -35,6 +38,10 @@ const (
 	OutputFormatYAML  OutputFormat = "yaml"
 	OutputFormatGraph OutputFormat = "graph"
 	OutputFormatTable OutputFormat = ""
+
+	OutputFormatMermaid   OutputFormat = "mermaid"
+	OutputFormatD2        OutputFormat = "d2"
+	OutputFormatGraphJSON OutputFormat = "graph-json"
 )
 
 func ValidateAndReturnOutputFormat(format string) (OutputFormat, error) {
@@ -47,6 +54,12 @@ func ValidateAndReturnOutputFormat(format string) (OutputFormat, error) {
 		return OutputFormatYAML, nil
 	case "graph":
 		return OutputFormatGraph, nil
+	case "mermaid":
+		return OutputFormatMermaid, nil
+	case "d2":
+		return OutputFormatD2, nil
+	case "graph-json":
+		return OutputFormatGraphJSON, nil
 	case "":
 		return OutputFormatTable, nil
 	default:
@@ -56,7 +69,18 @@ func ValidateAndReturnOutputFormat(format string) (OutputFormat, error) {
 }
 
 func AllowedOutputFormatsForHelp() []string {
-	return []string{string(OutputFormatWide), string(OutputFormatJSON), string(OutputFormatYAML), string(OutputFormatGraph)}
+	return []string{string(OutputFormatWide), string(OutputFormatJSON), string(OutputFormatYAML), string(OutputFormatGraph), string(OutputFormatMermaid), string(OutputFormatD2), string(OutputFormatGraphJSON)}
+}
+
+// IsGraphOutputFormat returns true if the output format renders the
+// relationships between resources as a graph.
+func IsGraphOutputFormat(format OutputFormat) bool {
+	switch format {
+	case OutputFormatGraph, OutputFormatMermaid, OutputFormatD2, OutputFormatGraphJSON:
+		return true
+	default:
+		return false
+	}
 }
 
 type PrinterOptions struct { //nolint:revive
@@ -65,6 +89,9 @@ type PrinterOptions struct { //nolint:revive
 	Clock         clock.Clock
 	EventFetcher  eventFetcher
 	AllNamespaces bool
+	// RoutingTable includes the routing table of Gateways in their
+	// descriptions.
+	RoutingTable bool
 }
 
 type Printer interface {
@@ -74,12 +101,12 @@ type Printer interface {
 
 func NewPrinter(options PrinterOptions) Printer {
 	switch {
+	case options.Description:
+		return &DescriptionPrinter{PrinterOptions: options}
 	case options.OutputFormat == OutputFormatJSON:
 		return NewJSONPrinter()
 	case options.OutputFormat == OutputFormatYAML:
 		return NewYAMLPrinter()
-	case options.Description:
-		return &DescriptionPrinter{PrinterOptions: options}
 	default:
 		return &TablePrinter{PrinterOptions: options}
 	}
@@ -121,24 +148,48 @@ func (p *TablePrinter) checkTypeChange(newType string, w io.Writer) error {
 	return err
 }
 
+// DescriptionPrinter describes the nodes in a human readable format, or as a
+// JSON array with an object for each node when the output format is JSON.
 type DescriptionPrinter struct {
 	PrinterOptions
 
 	printSeparator bool
+	// described contains the descriptions of the nodes which are written on
+	// Flush when the output format is JSON.
+	described []any
 }
 
 func (p *DescriptionPrinter) PrintNode(node *topology.Node, w io.Writer) error {
+	if p.OutputFormat == OutputFormatJSON {
+		// Descriptions are collected by describe and written on Flush.
+		return parseAndPrint(node, io.Discard, p)
+	}
 	return parseAndPrint(node, w, p)
 }
 
+// describe writes the description of a node, or collects it to be written on
+// Flush when the output format is JSON.
+func (p *DescriptionPrinter) describe(w io.Writer, pairs []*DescriberKV) {
+	if p.OutputFormat == OutputFormatJSON {
+		p.described = append(p.described, describedObject(pairs))
+		return
+	}
+	Describe(w, pairs)
+}
+
 type typedPrinter interface {
 	printBackend(*topology.Node, io.Writer) error
 	printGatewayClass(*topology.Node, io.Writer) error
 	printGateway(*topology.Node, io.Writer) error
 	printHTTPRoute(*topology.Node, io.Writer) error
+	printGRPCRoute(*topology.Node, io.Writer) error
+	printTLSRoute(*topology.Node, io.Writer) error
+	printTCPRoute(*topology.Node, io.Writer) error
+	printUDPRoute(*topology.Node, io.Writer) error
 	printNamespace(*topology.Node, io.Writer) error
 	printPolicy(*topology.Node, io.Writer) error
 	printPolicyCRD(*topology.Node, io.Writer) error
+	printReferenceGrant(*topology.Node, io.Writer) error
 	printUnknown(*topology.Node, io.Writer) error
 }
 
@@ -157,22 +208,79 @@ func parseAndPrint(node *topology.Node, w io.Writer, p typedPrinter) error {
 		return p.printGatewayClass(node, w)
 	case common.HTTPRouteGK:
 		return p.printHTTPRoute(node, w)
+	case common.GRPCRouteGK:
+		return p.printGRPCRoute(node, w)
+	case common.TLSRouteGK:
+		return p.printTLSRoute(node, w)
+	case common.TCPRouteGK:
+		return p.printTCPRoute(node, w)
+	case common.UDPRouteGK:
+		return p.printUDPRoute(node, w)
 	case common.NamespaceGK:
 		return p.printNamespace(node, w)
 	case common.ServiceGK:
 		return p.printBackend(node, w)
+	case common.ReferenceGrantGK:
+		return p.printReferenceGrant(node, w)
 	default:
+		if topologygw.IsBackend(node.GKNN().GroupKind()) {
+			return p.printBackend(node, w)
+		}
 		return p.printUnknown(node, w)
 	}
 }
 
-func (p *DescriptionPrinter) Flush(io.Writer) error { return nil }
+func (p *DescriptionPrinter) Flush(w io.Writer) error {
+	if p.OutputFormat != OutputFormatJSON {
+		return nil
+	}
+	if p.described == nil {
+		p.described = []any{}
+	}
+	b, err := json.MarshalIndent(p.described, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
 
 func (p *DescriptionPrinter) printUnknown(node *topology.Node, w io.Writer) error {
+	if p.OutputFormat == OutputFormatJSON {
+		p.described = append(p.described, node.Object)
+		return nil
+	}
 	printer := &printers.YAMLPrinter{}
 	return printer.PrintObj(node.Object, w)
 }
 
+// describedObject is the description of a node, which is marshalled as a JSON
+// object with the keys in the same order as the pairs.
+type describedObject []*DescriberKV
+
+func (d describedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, pair := range d {
+		if i != 0 {
+			buf.WriteString(",")
+		}
+		key, err := json.Marshal(pair.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(pair.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
 type JSONPrinter struct {
 	Delegate *printers.OmitManagedFieldsPrinter
 }
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer //nolint:revive
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"sigs.k8s.io/gwctl/pkg/extension/refgrantvalidator"
+	extensionutils "sigs.k8s.io/gwctl/pkg/extension/utils"
+	"sigs.k8s.io/gwctl/pkg/topology"
+
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func (p *TablePrinter) printReferenceGrant(referenceGrantNode *topology.Node, w io.Writer) error {
+	if err := p.checkTypeChange("ReferenceGrant", w); err != nil {
+		return err
+	}
+
+	if p.table == nil {
+		columnNames := namespacedBaseColumnNames(p.AllNamespaces)
+		columnNames = append(columnNames, "FROM", "TO", "AGE")
+		if p.OutputFormat == OutputFormatWide {
+			columnNames = append(columnNames, "REFERENCES")
+		}
+		p.table = &Table{
+			ColumnNames:  columnNames,
+			UseSeparator: false,
+		}
+	}
+
+	referenceGrant := topology.MustAccessObject(referenceGrantNode, &gatewayv1beta1.ReferenceGrant{})
+
+	var from []string
+	for _, f := range referenceGrant.Spec.From {
+		from = append(from, fmt.Sprintf("%v/%v", f.Kind, f.Namespace))
+	}
+	var to []string
+	for _, t := range referenceGrant.Spec.To {
+		if t.Name != nil && len(*t.Name) != 0 {
+			to = append(to, fmt.Sprintf("%v/%v", t.Kind, *t.Name))
+		} else {
+			to = append(to, string(t.Kind))
+		}
+	}
+
+	age := "<unknown>"
+	creationTimestamp := referenceGrant.GetCreationTimestamp()
+	if !creationTimestamp.IsZero() {
+		age = duration.HumanDuration(p.Clock.Since(creationTimestamp.Time))
+	}
+
+	row := append(rowPrefixNamespaced(referenceGrant, p.AllNamespaces), strings.Join(from, ","), strings.Join(to, ","), age)
+	if p.OutputFormat == OutputFormatWide {
+		metadata, err := refgrantvalidator.Access(referenceGrantNode)
+		if err != nil {
+			return err
+		}
+		var referencesCount int
+		if metadata != nil {
+			referencesCount = len(metadata.References)
+		}
+		row = append(row, fmt.Sprintf("%d", referencesCount))
+	}
+	p.table.Rows = append(p.table.Rows, row)
+
+	return nil
+}
+
+func (p *DescriptionPrinter) printReferenceGrant(referenceGrantNode *topology.Node, w io.Writer) error {
+	if p.printSeparator {
+		fmt.Fprintf(w, "\n\n")
+	}
+	p.printSeparator = true
+
+	referenceGrant := topology.MustAccessObject(referenceGrantNode, &gatewayv1beta1.ReferenceGrant{})
+
+	metadata := referenceGrant.ObjectMeta.DeepCopy()
+	metadata.Labels = nil
+	metadata.Annotations = nil
+	metadata.Name = ""
+	metadata.Namespace = ""
+	metadata.ManagedFields = nil
+
+	pairs := []*DescriberKV{
+		{Key: "Name", Value: referenceGrant.GetName()},
+		{Key: "Namespace", Value: referenceGrant.GetNamespace()},
+		{Key: "Labels", Value: referenceGrant.Labels},
+		{Key: "Annotations", Value: referenceGrant.Annotations},
+		{Key: "APIVersion", Value: referenceGrant.APIVersion},
+		{Key: "Kind", Value: referenceGrant.Kind},
+		{Key: "Metadata", Value: metadata},
+		{Key: "Spec", Value: &referenceGrant.Spec},
+	}
+
+	// References
+	refGrantMetadata, err := refgrantvalidator.Access(referenceGrantNode)
+	if err != nil {
+		return err
+	}
+	referencesTable := &Table{
+		ColumnNames:  []string{"From Kind", "From Name", "To Kind", "To Name"},
+		UseSeparator: true,
+	}
+	if refGrantMetadata != nil {
+		for _, reference := range refGrantMetadata.References {
+			row := []string{
+				reference.ReferringObject.Kind,                      // From Kind
+				reference.ReferringObject.NamespacedName().String(), // From Name
+				reference.ReferredObject.Kind,                       // To Kind
+				reference.ReferredObject.NamespacedName().String(),  // To Name
+			}
+			referencesTable.Rows = append(referencesTable.Rows, row)
+		}
+	}
+	if len(referencesTable.Rows) == 0 {
+		pairs = append(pairs, &DescriberKV{Key: "References", Value: "<none>"})
+	} else {
+		pairs = append(pairs, &DescriberKV{Key: "References", Value: referencesTable})
+	}
+
+	// Analysis
+	analysisErrors, err := extensionutils.AggregateAnalysisErrors(referenceGrantNode)
+	if err != nil {
+		return err
+	}
+	if len(analysisErrors) != 0 {
+		pairs = append(pairs, &DescriberKV{Key: "Analysis", Value: convertErrorsToString(analysisErrors)})
+	}
+
+	// Events
+	events, err := p.EventFetcher.FetchEventsFor(referenceGrant)
+	if err != nil {
+		return err
+	}
+	pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(events, p.Clock)})
+
+	p.describe(w, pairs)
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer //nolint:revive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+func TestTablePrinter_printReferenceGrant(t *testing.T) {
+	testCases := []struct {
+		name         string
+		outputFormat OutputFormat
+		wantOut      string
+	}{
+		{
+			name: "normal output",
+			wantOut: `
+NAME               FROM                         TO                    AGE
+reference-grant-1  HTTPRoute/ns-2,Gateway/ns-3  Service/svc-1,Secret  <unknown>
+`,
+		},
+		{
+			name:         "wide output",
+			outputFormat: OutputFormatWide,
+			wantOut: `
+NAME               FROM                         TO                    AGE        REFERENCES
+reference-grant-1  HTTPRoute/ns-2,Gateway/ns-3  Service/svc-1,Secret  <unknown>  0
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &TablePrinter{PrinterOptions: PrinterOptions{OutputFormat: tc.outputFormat}}
+			out := &bytes.Buffer{}
+
+			for _, referenceGrantNode := range testData(t)[common.ReferenceGrantGK] {
+				if err := p.printReferenceGrant(referenceGrantNode, out); err != nil {
+					t.Fatal(err)
+				}
+			}
+			p.Flush(out)
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
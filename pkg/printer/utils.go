@@ -17,14 +17,18 @@ limitations under the License.
 package printer //nolint:revive
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 	"text/tabwriter"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/duration"
@@ -36,6 +40,8 @@ import (
 
 	"sigs.k8s.io/gwctl/pkg/common"
 	"sigs.k8s.io/gwctl/pkg/policymanager"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // DescriberKV stores key-value pairs that are used with Describing a resource.
@@ -83,6 +89,22 @@ type Table struct {
 	UseSeparator bool
 }
 
+// MarshalJSON marshals the table as a list with an object for each row, keyed
+// by the column names.
+func (t Table) MarshalJSON() ([]byte, error) {
+	rows := make([]map[string]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		obj := make(map[string]string, len(row))
+		for i, value := range row {
+			if i < len(t.ColumnNames) {
+				obj[t.ColumnNames[i]] = value
+			}
+		}
+		rows = append(rows, obj)
+	}
+	return json.Marshal(rows)
+}
+
 // Write will write a formatted table to the writer. indent controls the
 // number of spaces at the beginning of each row.
 func (t *Table) Write(w io.Writer, indent int) error {
@@ -202,6 +224,79 @@ func convertPoliciesToRefsTable(policies []*policymanager.Policy, includeTarget
 	return table
 }
 
+// convertEffectivePolicySourcesToTable returns a table with the value of every
+// field of the effective policies, along with the policy which the value comes
+// from. The effective policies are partitioned by Gateway, which is only shown
+// if includeGateway is true.
+func convertEffectivePolicySourcesToTable(effectivePolicies map[common.GKNN]map[policymanager.PolicyCrdID]*policymanager.Policy, includeGateway bool) (*Table, error) {
+	table := &Table{
+		ColumnNames:  []string{"Type", "Field", "Value", "Policy", "Level", "From"},
+		UseSeparator: true,
+	}
+	if includeGateway {
+		table.ColumnNames = append([]string{"Gateway"}, table.ColumnNames...)
+	}
+
+	gateways := slices.SortedFunc(maps.Keys(effectivePolicies), func(a, b common.GKNN) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	for _, gateway := range gateways {
+		policies := effectivePolicies[gateway]
+		for _, policyCrdID := range slices.Sorted(maps.Keys(policies)) {
+			policy := policies[policyCrdID]
+			effectiveSpec, err := policy.EffectiveSpec()
+			if err != nil {
+				return nil, err
+			}
+			fieldSources := policy.FieldSources()
+			for _, field := range slices.Sorted(maps.Keys(fieldSources)) {
+				source := fieldSources[field]
+				value, _, err := unstructured.NestedFieldNoCopy(effectiveSpec, strings.Split(field, ".")...)
+				if err != nil {
+					return nil, err
+				}
+				from := "default"
+				if source.Override {
+					from = "override"
+				}
+				policyName := source.Policy.Name
+				if source.Policy.Namespace != "" {
+					policyName = fmt.Sprintf("%v/%v", source.Policy.Namespace, policyName)
+				}
+
+				row := []string{
+					string(policyCrdID),     // Type
+					field,                   // Field
+					fieldValueOutput(value), // Value
+					policyName,              // Policy
+					source.Level,            // Level
+					from,                    // From
+				}
+				if includeGateway {
+					row = append([]string{gateway.NamespacedName().String()}, row...)
+				}
+				table.Rows = append(table.Rows, row)
+			}
+		}
+	}
+	return table, nil
+}
+
+// fieldValueOutput formats the value of a field of a policy for the table view.
+// Lists and objects are formatted as JSON.
+func fieldValueOutput(value any) string {
+	switch value.(type) {
+	case []any, map[string]any:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprintf("%v", value)
+		}
+		return string(b)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
 func convertErrorsToString(errors []error) []string {
 	var result []string
 	for _, err := range errors {
@@ -210,6 +305,54 @@ func convertErrorsToString(errors []error) []string {
 	return result
 }
 
+// routeHostnamesOutput formats the hostnames of a route for the table view,
+// truncating the list after the first two entries.
+func routeHostnamesOutput(hostnames []gatewayv1.Hostname) string {
+	var hostNames []string
+	for _, hostName := range hostnames {
+		hostNames = append(hostNames, string(hostName))
+	}
+	hostNamesOutput := "None"
+	if hostNamesCount := len(hostNames); hostNamesCount > 0 {
+		if hostNamesCount > 2 {
+			hostNamesOutput = fmt.Sprintf("%v + %v more", strings.Join(hostNames[:2], ","), hostNamesCount-2)
+		} else {
+			hostNamesOutput = strings.Join(hostNames, ",")
+		}
+	}
+	return hostNamesOutput
+}
+
+// routeParentsConditionStatus summarizes whether the condition of the given
+// type is True across all parents of a route. It returns "True" if the
+// condition is True for all parents, "Partial" if it is True for only some of
+// them, "False" if it is True for none, and "Unknown" if the route has no
+// parent statuses.
+func routeParentsConditionStatus(parents []gatewayv1.RouteParentStatus, conditionType gatewayv1.RouteConditionType) string {
+	if len(parents) == 0 {
+		return "Unknown"
+	}
+
+	count := 0
+	for _, parentStatus := range parents {
+		for _, condition := range parentStatus.Conditions {
+			if condition.Type == string(conditionType) && condition.Status == metav1.ConditionTrue {
+				count++
+				break
+			}
+		}
+	}
+
+	switch {
+	case count == len(parents):
+		return "True"
+	case count > 0:
+		return "Partial"
+	default:
+		return "False"
+	}
+}
+
 type eventFetcher interface {
 	FetchEventsFor(client.Object) ([]*corev1.Event, error)
 }
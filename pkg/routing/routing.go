@@ -0,0 +1,485 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package routing implements the matching semantics and precedence rules which
+// Gateway API defines for HTTPRoutes, so that the routing decisions of a
+// Gateway can be simulated.
+package routing
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/extension/routeattachmentvalidator"
+	"sigs.k8s.io/gwctl/pkg/topology"
+	topologygw "sigs.k8s.io/gwctl/pkg/topology/gateway"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Request is an HTTP request which is matched against the rules of HTTPRoutes.
+type Request struct {
+	// Host is the host of the request, without the port.
+	Host   string
+	Path   string
+	Method string
+	// Headers are keyed by the canonical form of the header name.
+	Headers     map[string]string
+	QueryParams map[string]string
+}
+
+// RuleMatch is a single match of a rule of an HTTPRoute, for one of the
+// hostnames with which the HTTPRoute is attached to a listener. A rule without
+// matches has a single RuleMatch which matches all requests.
+type RuleMatch struct {
+	RouteNode *topology.Node
+	Route     *gatewayv1.HTTPRoute
+	// RuleIndex and MatchIndex are the indexes of the rule within the
+	// HTTPRoute, and of the match within the rule.
+	RuleIndex  int
+	MatchIndex int
+	// Match has the defaults of the API applied, so the path is always set.
+	Match gatewayv1.HTTPRouteMatch
+	// Hostname is the most specific hostname matched by both the HTTPRoute and
+	// the listener. It is empty if neither of them restrict the hostnames.
+	Hostname string
+}
+
+// Rule returns the rule of the HTTPRoute which the match belongs to.
+func (m RuleMatch) Rule() gatewayv1.HTTPRouteRule {
+	return m.Route.Spec.Rules[m.RuleIndex]
+}
+
+// RuleName returns the name of the rule, or its index if it has no name.
+func (m RuleMatch) RuleName() string {
+	if name := m.Rule().Name; name != nil && *name != "" {
+		return string(*name)
+	}
+	return fmt.Sprintf("%d", m.RuleIndex)
+}
+
+// String describes the conditions of the match, like
+// "Host=*.example.com PathPrefix=/foo Method=GET Header[X-Env]=canary".
+func (m RuleMatch) String() string {
+	if m.Hostname == "" {
+		return m.Conditions()
+	}
+	return "Host=" + m.Hostname + " " + m.Conditions()
+}
+
+// Conditions describes the conditions of the match other than the hostname,
+// like "PathPrefix=/foo Method=GET Header[X-Env]=canary".
+func (m RuleMatch) Conditions() string {
+	return MatchConditions(m.Match)
+}
+
+// MatchConditions describes the conditions of a match of an HTTPRoute rule,
+// like "PathPrefix=/foo Method=GET Header[X-Env]=canary", after applying the
+// defaults of the API.
+func MatchConditions(match gatewayv1.HTTPRouteMatch) string {
+	match = withDefaults(match)
+	parts := []string{fmt.Sprintf("%v=%v", *match.Path.Type, *match.Path.Value)}
+	if match.Method != nil {
+		parts = append(parts, fmt.Sprintf("Method=%v", *match.Method))
+	}
+	for _, header := range match.Headers {
+		op := "="
+		if headerMatchType(header) == gatewayv1.HeaderMatchRegularExpression {
+			op = "~"
+		}
+		parts = append(parts, fmt.Sprintf("Header[%v]%v%v", http.CanonicalHeaderKey(string(header.Name)), op, header.Value))
+	}
+	for _, queryParam := range match.QueryParams {
+		op := "="
+		if queryParamMatchType(queryParam) == gatewayv1.QueryParamMatchRegularExpression {
+			op = "~"
+		}
+		parts = append(parts, fmt.Sprintf("Query[%v]%v%v", queryParam.Name, op, queryParam.Value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// ListenerRuleMatches returns the matches of the rules of all HTTPRoutes which
+// are attached to the listener, ordered by precedence. Routes which select the
+// listener through their parentRefs, but which the listener does not allow,
+// are skipped.
+func ListenerRuleMatches(listenerNode *topology.Node) []RuleMatch {
+	gatewayNode := topologygw.ListenerNode(listenerNode).Gateway()
+	if gatewayNode == nil {
+		return nil
+	}
+	gateway := topology.MustAccessObject(gatewayNode, &gatewayv1.Gateway{})
+	_, listenerName := common.SplitListenerName(listenerNode.GKNN().Name)
+	index := slices.IndexFunc(gateway.Spec.Listeners, func(l gatewayv1.Listener) bool { return l.Name == listenerName })
+	if index < 0 {
+		return nil
+	}
+	listener := gateway.Spec.Listeners[index]
+	var listenerHostname string
+	if listener.Hostname != nil {
+		listenerHostname = string(*listener.Hostname)
+	}
+
+	var result []RuleMatch
+	for _, routeNode := range topologygw.ListenerNode(listenerNode).Routes() {
+		if routeNode.GKNN().GroupKind() != common.HTTPRouteGK {
+			continue
+		}
+		if routeattachmentvalidator.ValidateAttachment(routeNode, gatewayNode, listener) != nil {
+			continue
+		}
+		route := topology.MustAccessObject(routeNode, &gatewayv1.HTTPRoute{})
+		for _, hostname := range effectiveHostnames(route.Spec.Hostnames, listenerHostname) {
+			for i, rule := range route.Spec.Rules {
+				matches := rule.Matches
+				if len(matches) == 0 {
+					matches = []gatewayv1.HTTPRouteMatch{{}}
+				}
+				for j, match := range matches {
+					result = append(result, RuleMatch{
+						RouteNode:  routeNode,
+						Route:      route,
+						RuleIndex:  i,
+						MatchIndex: j,
+						Match:      withDefaults(match),
+						Hostname:   hostname,
+					})
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(result, ComparePrecedence)
+	return result
+}
+
+// MatchingListeners returns the HTTP and HTTPS listeners which would receive a
+// request for the host. When several listeners on the same port match the
+// host, only the one with the most specific hostname receives the request.
+func MatchingListeners(listeners []gatewayv1.Listener, host string) []gatewayv1.Listener {
+	var result []gatewayv1.Listener
+	bestByPort := make(map[gatewayv1.PortNumber]int)
+	for _, listener := range listeners {
+		if listener.Protocol != gatewayv1.HTTPProtocolType && listener.Protocol != gatewayv1.HTTPSProtocolType {
+			continue
+		}
+		var hostname string
+		if listener.Hostname != nil {
+			hostname = string(*listener.Hostname)
+		}
+		if !hostnameMatches(hostname, host) {
+			continue
+		}
+
+		index, ok := bestByPort[listener.Port]
+		if !ok {
+			bestByPort[listener.Port] = len(result)
+			result = append(result, listener)
+			continue
+		}
+		var bestHostname string
+		if result[index].Hostname != nil {
+			bestHostname = string(*result[index].Hostname)
+		}
+		if hostnameRank(hostname) > hostnameRank(bestHostname) ||
+			(hostnameRank(hostname) == hostnameRank(bestHostname) && len(hostname) > len(bestHostname)) {
+			result[index] = listener
+		}
+	}
+	return result
+}
+
+// effectiveHostnames returns the hostnames with which a Route is attached to
+// a listener. For each hostname of the Route which intersects with the
+// hostname of the listener, the more specific of the two is used.
+func effectiveHostnames(routeHostnames []gatewayv1.Hostname, listenerHostname string) []string {
+	if len(routeHostnames) == 0 {
+		return []string{listenerHostname}
+	}
+	var result []string
+	for _, routeHostname := range routeHostnames {
+		hostname := string(routeHostname)
+		switch {
+		case listenerHostname == "" || hostname == listenerHostname:
+		case hostnameMatches(listenerHostname, hostname):
+			// The Route hostname is within the wildcard listener hostname, or
+			// is a longer wildcard.
+		case hostnameMatches(hostname, listenerHostname):
+			hostname = listenerHostname
+		default:
+			continue
+		}
+		if !slices.Contains(result, hostname) {
+			result = append(result, hostname)
+		}
+	}
+	return result
+}
+
+// hostnameMatches returns true if the host matches the hostname. A wildcard
+// hostname like "*.example.com" matches hosts with one or more additional
+// labels, like "foo.example.com" and "foo.bar.example.com", and any wildcard
+// hostname which is more specific, like "*.foo.example.com".
+func hostnameMatches(hostname, host string) bool {
+	if hostname == "" || hostname == host {
+		return true
+	}
+	if suffix, ok := strings.CutPrefix(hostname, "*"); ok {
+		return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+	}
+	return false
+}
+
+// withDefaults returns the match with the defaults of the API applied.
+func withDefaults(match gatewayv1.HTTPRouteMatch) gatewayv1.HTTPRouteMatch {
+	match = *match.DeepCopy()
+	if match.Path == nil {
+		match.Path = &gatewayv1.HTTPPathMatch{}
+	}
+	if match.Path.Type == nil {
+		pathType := gatewayv1.PathMatchPathPrefix
+		match.Path.Type = &pathType
+	}
+	if match.Path.Value == nil {
+		value := "/"
+		match.Path.Value = &value
+	}
+	return match
+}
+
+// Matches returns true if the request matches all the conditions of the match.
+func (r Request) Matches(m RuleMatch) bool {
+	if !hostnameMatches(m.Hostname, r.Host) {
+		return false
+	}
+	if !pathMatches(*m.Match.Path, r.Path) {
+		return false
+	}
+	if m.Match.Method != nil && string(*m.Match.Method) != r.Method {
+		return false
+	}
+	for _, header := range m.Match.Headers {
+		value, ok := r.Headers[http.CanonicalHeaderKey(string(header.Name))]
+		regex := headerMatchType(header) == gatewayv1.HeaderMatchRegularExpression
+		if !ok || !valueMatches(header.Value, value, regex) {
+			return false
+		}
+	}
+	for _, queryParam := range m.Match.QueryParams {
+		value, ok := r.QueryParams[string(queryParam.Name)]
+		regex := queryParamMatchType(queryParam) == gatewayv1.QueryParamMatchRegularExpression
+		if !ok || !valueMatches(queryParam.Value, value, regex) {
+			return false
+		}
+	}
+	return true
+}
+
+func pathMatches(pathMatch gatewayv1.HTTPPathMatch, path string) bool {
+	value := *pathMatch.Value
+	switch *pathMatch.Type {
+	case gatewayv1.PathMatchExact:
+		return path == value
+	case gatewayv1.PathMatchRegularExpression:
+		return valueMatches(value, path, true)
+	default:
+		// Prefixes match complete path elements, so /foo matches /foo and
+		// /foo/bar, but not /foobar.
+		prefix := strings.TrimSuffix(value, "/")
+		return prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+}
+
+// valueMatches returns true if the value is equal to expected, or fully
+// matches it as a regular expression if regex is true.
+func valueMatches(expected, value string, regex bool) bool {
+	if !regex {
+		return expected == value
+	}
+	re, err := regexp.Compile("^(?:" + expected + ")$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// Covers returns true if all the requests matched by other are also matched by
+// m. This is decided conservatively, such that regular expressions only cover
+// identical regular expressions.
+func (m RuleMatch) Covers(other RuleMatch) bool {
+	if !hostnameMatches(m.Hostname, other.Hostname) {
+		return false
+	}
+	if !pathCovers(*m.Match.Path, *other.Match.Path) {
+		return false
+	}
+	if m.Match.Method != nil && (other.Match.Method == nil || *m.Match.Method != *other.Match.Method) {
+		return false
+	}
+	for _, header := range m.Match.Headers {
+		if !slices.ContainsFunc(other.Match.Headers, func(h gatewayv1.HTTPHeaderMatch) bool {
+			return http.CanonicalHeaderKey(string(h.Name)) == http.CanonicalHeaderKey(string(header.Name)) &&
+				headerMatchType(h) == headerMatchType(header) && h.Value == header.Value
+		}) {
+			return false
+		}
+	}
+	for _, queryParam := range m.Match.QueryParams {
+		if !slices.ContainsFunc(other.Match.QueryParams, func(q gatewayv1.HTTPQueryParamMatch) bool {
+			return q.Name == queryParam.Name && queryParamMatchType(q) == queryParamMatchType(queryParam) && q.Value == queryParam.Value
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// pathCovers returns true if all the paths matched by other are also matched
+// by pathMatch.
+func pathCovers(pathMatch, other gatewayv1.HTTPPathMatch) bool {
+	switch *pathMatch.Type {
+	case gatewayv1.PathMatchExact:
+		return *other.Type == gatewayv1.PathMatchExact && *other.Value == *pathMatch.Value
+	case gatewayv1.PathMatchPathPrefix:
+		if *other.Type == gatewayv1.PathMatchRegularExpression {
+			return strings.TrimSuffix(*pathMatch.Value, "/") == ""
+		}
+		return pathMatches(pathMatch, strings.TrimSuffix(*other.Value, "/"))
+	default:
+		return *other.Type == *pathMatch.Type && *other.Value == *pathMatch.Value
+	}
+}
+
+func headerMatchType(header gatewayv1.HTTPHeaderMatch) gatewayv1.HeaderMatchType {
+	if header.Type == nil {
+		return gatewayv1.HeaderMatchExact
+	}
+	return *header.Type
+}
+
+func queryParamMatchType(queryParam gatewayv1.HTTPQueryParamMatch) gatewayv1.QueryParamMatchType {
+	if queryParam.Type == nil {
+		return gatewayv1.QueryParamMatchExact
+	}
+	return *queryParam.Type
+}
+
+// ComparePrecedence orders the matches by the precedence which Gateway API
+// defines for HTTPRoute rules, such that the match with the highest precedence
+// comes first. Precedence is given, in order, to the match with:
+//
+//  1. The most characters in a non-wildcard hostname.
+//  2. The most characters in a hostname.
+//  3. An Exact path match, followed by the PathPrefix match with the most
+//     characters. Regular expressions are implementation specific, and are
+//     ordered after both.
+//  4. A method match.
+//  5. The most header matches.
+//  6. The most query param matches.
+//
+// Remaining ties go to the oldest Route, then to the Route which is
+// alphabetically first by "{namespace}/{name}", and then to the first rule and
+// match within the Route. Routes without a creationTimestamp have not been
+// created yet, so they are considered to be the newest.
+func ComparePrecedence(a, b RuleMatch) int {
+	if c := cmp.Compare(hostnameRank(b.Hostname), hostnameRank(a.Hostname)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(len(b.Hostname), len(a.Hostname)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(pathTypeRank(*b.Match.Path.Type), pathTypeRank(*a.Match.Path.Type)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(pathLength(*b.Match.Path), pathLength(*a.Match.Path)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(methodRank(b.Match.Method), methodRank(a.Match.Method)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(len(b.Match.Headers), len(a.Match.Headers)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(len(b.Match.QueryParams), len(a.Match.QueryParams)); c != 0 {
+		return c
+	}
+	if c := CompareCreationTimestamps(a.Route, b.Route); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Route.Namespace+"/"+a.Route.Name, b.Route.Namespace+"/"+b.Route.Name); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.RuleIndex, b.RuleIndex); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.MatchIndex, b.MatchIndex)
+}
+
+// CompareCreationTimestamps orders the Routes by their creationTimestamp, such
+// that the oldest Route comes first. Routes without a creationTimestamp come
+// last.
+func CompareCreationTimestamps(a, b *gatewayv1.HTTPRoute) int {
+	aTime, bTime := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	switch {
+	case aTime.Equal(&bTime):
+		return 0
+	case aTime.IsZero():
+		return 1
+	case bTime.IsZero():
+		return -1
+	case aTime.Before(&bTime):
+		return -1
+	}
+	return 1
+}
+
+func hostnameRank(hostname string) int {
+	if hostname == "" || strings.HasPrefix(hostname, "*") {
+		return 0
+	}
+	return 1
+}
+
+// pathLength returns the number of characters of the path. The trailing slash
+// of a path prefix is ignored, since it matches the same requests without it.
+func pathLength(pathMatch gatewayv1.HTTPPathMatch) int {
+	if *pathMatch.Type == gatewayv1.PathMatchPathPrefix {
+		return len(strings.TrimSuffix(*pathMatch.Value, "/"))
+	}
+	return len(*pathMatch.Value)
+}
+
+func methodRank(method *gatewayv1.HTTPMethod) int {
+	if method == nil {
+		return 0
+	}
+	return 1
+}
+
+func pathTypeRank(pathType gatewayv1.PathMatchType) int {
+	switch pathType {
+	case gatewayv1.PathMatchExact:
+		return 2
+	case gatewayv1.PathMatchPathPrefix:
+		return 1
+	default:
+		return 0
+	}
+}
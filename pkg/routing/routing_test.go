@@ -0,0 +1,332 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func pathMatch(pathType gatewayv1.PathMatchType, value string) *gatewayv1.HTTPPathMatch {
+	return &gatewayv1.HTTPPathMatch{Type: ptr.To(pathType), Value: ptr.To(value)}
+}
+
+func TestRequestMatches(t *testing.T) {
+	testCases := []struct {
+		name     string
+		hostname string
+		match    gatewayv1.HTTPRouteMatch
+		request  Request
+		want     bool
+	}{
+		{
+			name:    "default match",
+			request: Request{Host: "example.com", Path: "/foo", Method: "GET"},
+			want:    true,
+		},
+		{
+			name:     "exact hostname",
+			hostname: "example.com",
+			request:  Request{Host: "foo.example.com", Path: "/"},
+			want:     false,
+		},
+		{
+			name:     "wildcard hostname matches multiple labels",
+			hostname: "*.example.com",
+			request:  Request{Host: "foo.bar.example.com", Path: "/"},
+			want:     true,
+		},
+		{
+			name:     "wildcard hostname does not match parent domain",
+			hostname: "*.example.com",
+			request:  Request{Host: "example.com", Path: "/"},
+			want:     false,
+		},
+		{
+			name:    "path prefix matches path element",
+			match:   gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchPathPrefix, "/foo/")},
+			request: Request{Path: "/foo/bar"},
+			want:    true,
+		},
+		{
+			name:    "path prefix does not match partial path element",
+			match:   gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchPathPrefix, "/foo")},
+			request: Request{Path: "/foobar"},
+			want:    false,
+		},
+		{
+			name:    "exact path",
+			match:   gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchExact, "/foo")},
+			request: Request{Path: "/foo/"},
+			want:    false,
+		},
+		{
+			name:    "regular expression path must match fully",
+			match:   gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchRegularExpression, "/v[0-9]")},
+			request: Request{Path: "/v1/users"},
+			want:    false,
+		},
+		{
+			name:    "method",
+			match:   gatewayv1.HTTPRouteMatch{Method: ptr.To(gatewayv1.HTTPMethodPost)},
+			request: Request{Path: "/", Method: "GET"},
+			want:    false,
+		},
+		{
+			name: "header names are case insensitive",
+			match: gatewayv1.HTTPRouteMatch{Headers: []gatewayv1.HTTPHeaderMatch{
+				{Name: "x-env", Value: "canary"},
+				{Name: "X-Version", Type: ptr.To(gatewayv1.HeaderMatchRegularExpression), Value: "v[12]"},
+			}},
+			request: Request{Path: "/", Headers: map[string]string{"X-Env": "canary", "X-Version": "v2"}},
+			want:    true,
+		},
+		{
+			name:    "missing header",
+			match:   gatewayv1.HTTPRouteMatch{Headers: []gatewayv1.HTTPHeaderMatch{{Name: "X-Env", Value: "canary"}}},
+			request: Request{Path: "/"},
+			want:    false,
+		},
+		{
+			name:    "query param names are case sensitive",
+			match:   gatewayv1.HTTPRouteMatch{QueryParams: []gatewayv1.HTTPQueryParamMatch{{Name: "debug", Value: "true"}}},
+			request: Request{Path: "/", QueryParams: map[string]string{"Debug": "true"}},
+			want:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := RuleMatch{Match: withDefaults(tc.match), Hostname: tc.hostname}
+			if got := tc.request.Matches(m); got != tc.want {
+				t.Errorf("Matches(%v) = %v, want %v", m, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComparePrecedence(t *testing.T) {
+	older := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "b", CreationTimestamp: metav1.NewTime(time.Unix(0, 0))}}
+	newer := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "a", CreationTimestamp: metav1.NewTime(time.Unix(100, 0))}}
+	newerSibling := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "c", CreationTimestamp: metav1.NewTime(time.Unix(100, 0))}}
+	notCreated := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "0"}}
+
+	matches := map[string]RuleMatch{
+		"wildcard hostname": {Route: older, Hostname: "*.example.com", Match: withDefaults(gatewayv1.HTTPRouteMatch{
+			Path: pathMatch(gatewayv1.PathMatchExact, "/foo/bar"),
+		})},
+		"exact hostname":                  {Route: older, Hostname: "foo.example.com", Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+		"exact path":                      {Route: older, Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchExact, "/a")})},
+		"long prefix":                     {Route: older, Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchPathPrefix, "/foo/bar")})},
+		"long prefix with trailing slash": {Route: newer, Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchPathPrefix, "/foo/bar/")})},
+		"regex":                           {Route: older, Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchRegularExpression, "/foo/bar/.*")})},
+		"method":                          {Route: older, Match: withDefaults(gatewayv1.HTTPRouteMatch{Method: ptr.To(gatewayv1.HTTPMethodGet)})},
+		"headers": {Route: older, Match: withDefaults(gatewayv1.HTTPRouteMatch{
+			Headers: []gatewayv1.HTTPHeaderMatch{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}},
+		})},
+		"query param": {Route: older, Match: withDefaults(gatewayv1.HTTPRouteMatch{
+			QueryParams: []gatewayv1.HTTPQueryParamMatch{{Name: "a", Value: "1"}},
+		})},
+		"older route":         {Route: older, Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+		"alphabetical route":  {Route: newer, Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+		"later route":         {Route: newerSibling, Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+		"later rule in route": {Route: newerSibling, RuleIndex: 1, Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+		"uncreated route":     {Route: notCreated, Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+	}
+	want := []string{
+		"exact hostname",
+		"wildcard hostname",
+		"exact path",
+		"long prefix",
+		"long prefix with trailing slash",
+		"method",
+		"headers",
+		"query param",
+		"older route",
+		"alphabetical route",
+		"later route",
+		"later rule in route",
+		"uncreated route",
+		"regex",
+	}
+
+	got := slices.Clone(want)
+	slices.Reverse(got)
+	slices.SortStableFunc(got, func(a, b string) int { return ComparePrecedence(matches[a], matches[b]) })
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ComparePrecedence ordered matches incorrectly: (-want, +got)\n%v", diff)
+	}
+}
+
+func TestEffectiveHostnames(t *testing.T) {
+	testCases := []struct {
+		name             string
+		routeHostnames   []gatewayv1.Hostname
+		listenerHostname string
+		want             []string
+	}{
+		{
+			name: "no hostnames",
+			want: []string{""},
+		},
+		{
+			name:             "route without hostnames",
+			listenerHostname: "*.example.com",
+			want:             []string{"*.example.com"},
+		},
+		{
+			name:             "route hostnames within wildcard listener hostname",
+			routeHostnames:   []gatewayv1.Hostname{"foo.example.com", "*.bar.example.com", "foo.other.com"},
+			listenerHostname: "*.example.com",
+			want:             []string{"foo.example.com", "*.bar.example.com"},
+		},
+		{
+			name:             "wildcard route hostname matching listener hostname",
+			routeHostnames:   []gatewayv1.Hostname{"*.example.com"},
+			listenerHostname: "foo.example.com",
+			want:             []string{"foo.example.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := effectiveHostnames(tc.routeHostnames, tc.listenerHostname)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("effectiveHostnames(%v, %q) returned unexpected result: (-want, +got)\n%v", tc.routeHostnames, tc.listenerHostname, diff)
+			}
+		})
+	}
+}
+
+func TestMatchingListeners(t *testing.T) {
+	listeners := []gatewayv1.Listener{
+		{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+		{Name: "http-wildcard", Port: 80, Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("*.example.com"))},
+		{Name: "http-foo", Port: 80, Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("foo.example.com"))},
+		{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: ptr.To(gatewayv1.Hostname("*.example.com"))},
+		{Name: "tls", Port: 8443, Protocol: gatewayv1.TLSProtocolType},
+	}
+
+	testCases := []struct {
+		host string
+		want []gatewayv1.SectionName
+	}{
+		{host: "foo.example.com", want: []gatewayv1.SectionName{"http-foo", "https"}},
+		{host: "bar.example.com", want: []gatewayv1.SectionName{"http-wildcard", "https"}},
+		{host: "example.org", want: []gatewayv1.SectionName{"http"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.host, func(t *testing.T) {
+			var got []gatewayv1.SectionName
+			for _, listener := range MatchingListeners(listeners, tc.host) {
+				got = append(got, listener.Name)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("MatchingListeners(%q) returned unexpected listeners: (-want, +got)\n%v", tc.host, diff)
+			}
+		})
+	}
+}
+
+func TestCovers(t *testing.T) {
+	testCases := []struct {
+		name  string
+		m     RuleMatch
+		other RuleMatch
+		want  bool
+	}{
+		{
+			name:  "identical matches",
+			m:     RuleMatch{Hostname: "foo.example.com", Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchPathPrefix, "/api")})},
+			other: RuleMatch{Hostname: "foo.example.com", Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchPathPrefix, "/api/")})},
+			want:  true,
+		},
+		{
+			name:  "wildcard hostname covers hostname",
+			m:     RuleMatch{Hostname: "*.example.com", Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+			other: RuleMatch{Hostname: "foo.example.com", Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+			want:  true,
+		},
+		{
+			name:  "hostname does not cover wildcard hostname",
+			m:     RuleMatch{Hostname: "foo.example.com", Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+			other: RuleMatch{Hostname: "*.example.com", Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+			want:  false,
+		},
+		{
+			name:  "path prefix covers longer path prefix and exact path",
+			m:     RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchPathPrefix, "/api")})},
+			other: RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchExact, "/api/v1")})},
+			want:  true,
+		},
+		{
+			name:  "path prefix does not cover regular expression",
+			m:     RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchPathPrefix, "/api")})},
+			other: RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchRegularExpression, "/api/.*")})},
+			want:  false,
+		},
+		{
+			name:  "default match covers regular expression",
+			m:     RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+			other: RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{Path: pathMatch(gatewayv1.PathMatchRegularExpression, "/api/.*")})},
+			want:  true,
+		},
+		{
+			name:  "method does not cover any method",
+			m:     RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{Method: ptr.To(gatewayv1.HTTPMethodGet)})},
+			other: RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{})},
+			want:  false,
+		},
+		{
+			name: "headers cover more headers",
+			m: RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{
+				Headers: []gatewayv1.HTTPHeaderMatch{{Name: "x-env", Value: "canary"}},
+			})},
+			other: RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{
+				Headers: []gatewayv1.HTTPHeaderMatch{{Name: "X-Version", Value: "v2"}, {Name: "X-Env", Value: "canary"}},
+				Method:  ptr.To(gatewayv1.HTTPMethodPost),
+			})},
+			want: true,
+		},
+		{
+			name: "query params with different values",
+			m: RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{
+				QueryParams: []gatewayv1.HTTPQueryParamMatch{{Name: "debug", Value: "true"}},
+			})},
+			other: RuleMatch{Match: withDefaults(gatewayv1.HTTPRouteMatch{
+				QueryParams: []gatewayv1.HTTPQueryParamMatch{{Name: "debug", Value: "false"}},
+			})},
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.m.Covers(tc.other); got != tc.want {
+				t.Errorf("(%v).Covers(%v) = %v, want %v", tc.m, tc.other, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+var (
+	// DefaultRegistry contains AllRelations, along with any relations
+	// registered for additional backends and resources. It should be used
+	// to build graphs which are used with the extensions.
+	DefaultRegistry = topology.NewRegistry(AllRelations...)
+
+	// BackendGKs contains the GroupKinds of all objects which can be referenced
+	// as backends by Routes. Additional kinds can be added through
+	// RegisterBackendKind.
+	BackendGKs = []schema.GroupKind{
+		common.ServiceGK,
+	}
+
+	// backendNamespaceRelations contains the relation to the Namespace for
+	// each kind in BackendGKs.
+	backendNamespaceRelations = map[schema.GroupKind]*topology.Relation{
+		common.ServiceGK: BackendNamespace,
+	}
+
+	// backendRefRelations contains, for each route kind, the relations to the
+	// backends referenced by the route. The first relation connects the route
+	// to Services (and to backends of unknown kinds, so they can be reported
+	// as missing), and one more relation is added for each kind registered
+	// through RegisterBackendKind.
+	backendRefRelations = map[schema.GroupKind][]*topology.Relation{
+		common.HTTPRouteGK: {HTTPRouteChildBackendRefsRelation},
+		common.GRPCRouteGK: {GRPCRouteChildBackendRefsRelation},
+		common.TLSRouteGK:  {TLSRouteChildBackendRefsRelation},
+		common.TCPRouteGK:  {TCPRouteChildBackendRefsRelation},
+		common.UDPRouteGK:  {UDPRouteChildBackendRefsRelation},
+	}
+)
+
+// IsBackend returns true if the GroupKind is one of the backend types modelled
+// in the topology.
+func IsBackend(gk schema.GroupKind) bool {
+	return slices.Contains(BackendGKs, gk)
+}
+
+// RegisterBackendKind registers an additional kind of backend, like
+// ServiceImport or InferencePool, in DefaultRegistry. A BackendRef relation to
+// this kind is registered for each kind of route, so objects of this kind are
+// fetched and connected to the Routes referencing them, along with a To
+// relation from ReferenceGrants. They are treated like Services by the
+// extensions (for example for policy inheritance and ReferenceGrants).
+func RegisterBackendKind(gk schema.GroupKind) {
+	if IsBackend(gk) {
+		return
+	}
+	namespaceRelation := &topology.Relation{
+		From:     gk,
+		To:       common.NamespaceGK,
+		Name:     "Namespace",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			return []common.GKNN{{
+				Group: common.NamespaceGK.Group,
+				Kind:  common.NamespaceGK.Kind,
+				Name:  u.GetNamespace(),
+			}}
+		},
+	}
+	relations := []*topology.Relation{namespaceRelation}
+	for _, routeGK := range RouteGKs {
+		relation := routeBackendRefsRelation(routeGK, gk)
+		backendRefRelations[routeGK] = append(backendRefRelations[routeGK], relation)
+		relations = append(relations, relation)
+	}
+	referenceGrantRelation := referenceGrantToRelation(gk)
+	referenceGrantBackendsRelations = append(referenceGrantBackendsRelations, referenceGrantRelation)
+	relations = append(relations, referenceGrantRelation)
+	BackendGKs = append(BackendGKs, gk)
+	backendNamespaceRelations[gk] = namespaceRelation
+	DefaultRegistry.Register(relations...)
+}
+
+// routeBackendRefsRelation returns a Relation from the given route kind to the
+// backends of the given kind which are referenced by the route.
+func routeBackendRefsRelation(routeGK, backendGK schema.GroupKind) *topology.Relation {
+	return &topology.Relation{
+		From: routeGK,
+		To:   backendGK,
+		Name: "BackendRef",
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			var result []common.GKNN
+			for _, gknn := range routeBackendRefs(u) {
+				if gknn.GroupKind() == backendGK && !slices.Contains(result, gknn) {
+					result = append(result, gknn)
+				}
+			}
+			return result
+		},
+	}
+}
+
+// routeBackendRefs returns the backends referenced by a route of any kind,
+// including the backends of RequestMirror filters.
+func routeBackendRefs(u *unstructured.Unstructured) []common.GKNN {
+	route := &struct {
+		Spec struct {
+			Rules []struct {
+				BackendRefs []gatewayv1.BackendRef `json:"backendRefs,omitempty"`
+				Filters     []struct {
+					RequestMirror *gatewayv1.HTTPRequestMirrorFilter `json:"requestMirror,omitempty"`
+				} `json:"filters,omitempty"`
+			} `json:"rules,omitempty"`
+		} `json:"spec"`
+	}{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), route); err != nil {
+		panic(fmt.Sprintf("failed to convert unstructured %v to structured: %v", u.GroupVersionKind().Kind, err))
+	}
+	var result []common.GKNN
+	for _, rule := range route.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			result = append(result, backendRefToGKNN(u.GetNamespace(), backendRef.BackendObjectReference))
+		}
+		for _, filter := range rule.Filters {
+			if filter.RequestMirror != nil {
+				result = append(result, backendRefToGKNN(u.GetNamespace(), filter.RequestMirror.BackendRef))
+			}
+		}
+	}
+	return result
+}
+
+// backendRefNeighbors returns the neighbors of a node through the BackendRef
+// relations of the given route kind.
+func backendRefNeighbors(neighbors map[*topology.Relation]map[common.GKNN]*topology.Node, routeGK schema.GroupKind) map[common.GKNN]*topology.Node {
+	result := make(map[common.GKNN]*topology.Node)
+	for _, relation := range backendRefRelations[routeGK] {
+		maps.Copy(result, neighbors[relation])
+	}
+	return result
+}
+
+// IsBackendRefRelation returns true if the relation connects a route of the
+// given kind to the backends which it references.
+func IsBackendRefRelation(relation *topology.Relation, routeGK schema.GroupKind) bool {
+	return slices.Contains(backendRefRelations[routeGK], relation)
+}
+
+// RelationsConfig is the format of the file passed to LoadRelationsConfig.
+//
+// Example:
+//
+//	backends:
+//	- group: multicluster.x-k8s.io
+//	  kind: ServiceImport
+//	relations:
+//	- name: EndpointPicker
+//	  from:
+//	    group: inference.networking.k8s.io
+//	    kind: InferencePool
+//	  to:
+//	    kind: Service
+//	  namePath: "{.spec.endpointPickerRef.name}"
+type RelationsConfig struct {
+	// Backends are additional kinds which can be referenced as backends by
+	// Routes.
+	Backends []GroupKindConfig `json:"backends,omitempty"`
+	// Relations are additional relations between objects.
+	Relations []RelationConfig `json:"relations,omitempty"`
+}
+
+type GroupKindConfig struct {
+	Group string `json:"group,omitempty"`
+	Kind  string `json:"kind"`
+}
+
+func (c GroupKindConfig) GroupKind() schema.GroupKind {
+	return schema.GroupKind{Group: c.Group, Kind: c.Kind}
+}
+
+type RelationConfig struct {
+	Name string          `json:"name"`
+	From GroupKindConfig `json:"from"`
+	To   GroupKindConfig `json:"to"`
+	// NamePath is a JSONPath expression producing the names of the
+	// referenced objects.
+	NamePath string `json:"namePath"`
+	// NamespacePath is an optional JSONPath expression producing the
+	// namespaces of the referenced objects. By default, the referenced
+	// objects are assumed to be in the same namespace.
+	NamespacePath string `json:"namespacePath,omitempty"`
+	// ClusterScoped is true if the referenced objects are cluster scoped.
+	ClusterScoped bool `json:"clusterScoped,omitempty"`
+	// ToParent is true if the referenced objects are the ones which the
+	// object belongs to, like the target of a policy, rather than objects
+	// used by it.
+	ToParent bool `json:"toParent,omitempty"`
+}
+
+// LoadRelationsConfig reads the RelationsConfig from the file and registers the
+// backends and relations in DefaultRegistry.
+func LoadRelationsConfig(filename string) error {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	config := &RelationsConfig{}
+	if err := yaml.UnmarshalStrict(b, config); err != nil {
+		return fmt.Errorf("failed to parse relations config %v: %w", filename, err)
+	}
+	return RegisterRelationsConfig(config)
+}
+
+// RegisterRelationsConfig registers the backends and relations from the config
+// in DefaultRegistry.
+func RegisterRelationsConfig(config *RelationsConfig) error {
+	var relations []*topology.Relation
+	for _, relationConfig := range config.Relations {
+		if relationConfig.Name == "" || relationConfig.From.Kind == "" || relationConfig.To.Kind == "" || relationConfig.NamePath == "" {
+			return fmt.Errorf("relation %q must specify name, from.kind, to.kind and namePath", relationConfig.Name)
+		}
+		relation, err := topology.NewJSONPathRelation(topology.JSONPathRelationSpec{
+			Name:          relationConfig.Name,
+			From:          relationConfig.From.GroupKind(),
+			To:            relationConfig.To.GroupKind(),
+			NamePath:      relationConfig.NamePath,
+			NamespacePath: relationConfig.NamespacePath,
+			ClusterScoped: relationConfig.ClusterScoped,
+			ToParent:      relationConfig.ToParent,
+		})
+		if err != nil {
+			return err
+		}
+		relations = append(relations, relation)
+	}
+
+	for _, backend := range config.Backends {
+		if backend.Kind == "" {
+			return fmt.Errorf("backend must specify a kind")
+		}
+		RegisterBackendKind(backend.GroupKind())
+	}
+	DefaultRegistry.Register(relations...)
+	return nil
+}
+
+// BackendNodes returns the nodes of all backend kinds in the graph.
+func BackendNodes(graph *topology.Graph) []*topology.Node {
+	var result []*topology.Node
+	for _, backendGK := range BackendGKs {
+		for _, node := range graph.Nodes[backendGK] {
+			result = append(result, node)
+		}
+	}
+	return result
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+)
+
+func TestRegisterBackendKind(t *testing.T) {
+	serviceImportGK := schema.GroupKind{Group: "multicluster.x-k8s.io", Kind: "ServiceImport"}
+	RegisterBackendKind(serviceImportGK)
+
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+		"metadata": map[string]any{
+			"name":      "route-1",
+			"namespace": "default",
+		},
+		"spec": map[string]any{
+			"rules": []any{
+				map[string]any{
+					"backendRefs": []any{
+						map[string]any{"group": "multicluster.x-k8s.io", "kind": "ServiceImport", "name": "import-1", "port": int64(80)},
+						map[string]any{"name": "svc-1", "port": int64(80)},
+					},
+				},
+			},
+		},
+	}}
+	serviceImport := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "multicluster.x-k8s.io/v1alpha1",
+		"kind":       "ServiceImport",
+		"metadata": map[string]any{
+			"name":      "import-1",
+			"namespace": "default",
+		},
+	}}
+	service := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]any{
+			"name":      "svc-1",
+			"namespace": "default",
+		},
+	}}
+	referenceGrant := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1beta1",
+		"kind":       "ReferenceGrant",
+		"metadata": map[string]any{
+			"name":      "grant-1",
+			"namespace": "default",
+		},
+		"spec": map[string]any{
+			"from": []any{
+				map[string]any{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "namespace": "other"},
+			},
+			"to": []any{
+				map[string]any{"group": "multicluster.x-k8s.io", "kind": "ServiceImport"},
+				map[string]any{"group": "", "kind": "Service", "name": "svc-1"},
+			},
+		},
+	}}
+	fakeFetcher := &fakeGroupKindFetcher{
+		data: map[schema.GroupKind][]*unstructured.Unstructured{
+			common.HTTPRouteGK:      {route},
+			serviceImportGK:         {serviceImport},
+			common.ServiceGK:        {service},
+			common.ReferenceGrantGK: {referenceGrant},
+		},
+	}
+
+	// Leave out the relations to Namespaces, so that ServiceImports can only be
+	// discovered through the BackendRef relations of the HTTPRoute.
+	var relations []*topology.Relation
+	for _, relation := range DefaultRegistry.Relations() {
+		if relation.To != common.NamespaceGK {
+			relations = append(relations, relation)
+		}
+	}
+
+	graph, err := topology.NewBuilder(fakeFetcher).
+		StartFrom([]*unstructured.Unstructured{route}).
+		UseRelationships(relations).
+		WithMaxDepth(2).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() failed with error: %v", err)
+	}
+
+	routeNode := graph.Nodes[common.HTTPRouteGK][types.NamespacedName{Namespace: "default", Name: "route-1"}]
+	importGKNN := common.GKNN{Group: serviceImportGK.Group, Kind: serviceImportGK.Kind, Namespace: "default", Name: "import-1"}
+	serviceGKNN := common.GKNN{Group: common.ServiceGK.Group, Kind: common.ServiceGK.Kind, Namespace: "default", Name: "svc-1"}
+
+	var gotBackends []common.GKNN
+	for gknn := range HTTPRouteNode(routeNode).Backends() {
+		gotBackends = append(gotBackends, gknn)
+	}
+	wantBackends := []common.GKNN{importGKNN, serviceGKNN}
+	if diff := cmp.Diff(wantBackends, gotBackends, cmpopts.SortSlices(func(a, b common.GKNN) bool { return a.String() < b.String() })); diff != "" {
+		t.Errorf("Backends() returned unexpected diff (-want, +got):\n%v", diff)
+	}
+
+	importNode := graph.Nodes[serviceImportGK][importGKNN.NamespacedName()]
+	if importNode == nil {
+		t.Fatalf("ServiceImport %v was not added to the graph", importGKNN)
+	}
+	if _, ok := BackendNode(importNode).HTTPRoutes()[routeNode.GKNN()]; !ok {
+		t.Errorf("HTTPRoutes() of %v does not contain %v", importGKNN, routeNode.GKNN())
+	}
+
+	grantNode := graph.Nodes[common.ReferenceGrantGK][types.NamespacedName{Namespace: "default", Name: "grant-1"}]
+	if grantNode == nil {
+		t.Fatalf("ReferenceGrant default/grant-1 was not added to the graph")
+	}
+	for relation, neighbors := range grantNode.OutNeighbors {
+		for gknn := range neighbors {
+			if gknn.GroupKind() != relation.To {
+				t.Errorf("%v is connected to %v through a relation to %v", grantNode.GKNN(), gknn, relation.To)
+			}
+		}
+	}
+	var gotExposed []common.GKNN
+	for gknn := range ReferenceGrantNode(grantNode).Exposes() {
+		gotExposed = append(gotExposed, gknn)
+	}
+	if diff := cmp.Diff(wantBackends, gotExposed, cmpopts.SortSlices(func(a, b common.GKNN) bool { return a.String() < b.String() })); diff != "" {
+		t.Errorf("Exposes() returned unexpected diff (-want, +got):\n%v", diff)
+	}
+}
+
+type fakeGroupKindFetcher struct {
+	data map[schema.GroupKind][]*unstructured.Unstructured
+}
+
+func (f *fakeGroupKindFetcher) Fetch(gk schema.GroupKind) ([]*unstructured.Unstructured, error) {
+	return f.data[gk], nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/gwctl/pkg/topology"
+)
+
+// ToD2 returns a D2 (https://d2lang.com) representation of the graph. Like
+// ToDot, the output is deterministic.
+func ToD2(gwctlGraph *topology.Graph, options RenderOptions) (string, error) {
+	model := newRenderModel(gwctlGraph, options)
+
+	// Nodes within namespaces are placed in a container for each namespace,
+	// so they need to be referenced through the container.
+	clusterIDs := map[string]string{}
+	for i, ns := range model.namespaces {
+		clusterIDs[ns] = fmt.Sprintf("ns%d", i+1)
+	}
+	ids := map[*renderNode]string{}
+	for i, node := range model.nodes {
+		ids[node] = fmt.Sprintf("n%d", i+1)
+	}
+	path := func(node *renderNode) string {
+		if node.namespace == "" {
+			return ids[node]
+		}
+		return clusterIDs[node.namespace] + "." + ids[node]
+	}
+
+	var b strings.Builder
+	b.WriteString("direction: up\n")
+
+	writeNode := func(node *renderNode, indent string) {
+		label := node.kind + "\n" + node.name
+		if node.policy != nil {
+			label += "\n(" + node.policyType() + ")"
+		}
+		fmt.Fprintf(&b, "%s%s: %s {\n", indent, ids[node], strconv.Quote(label))
+		if node.policy != nil {
+			fmt.Fprintf(&b, "%s  shape: page\n", indent)
+		}
+		fmt.Fprintf(&b, "%s  style.fill: %s\n", indent, strconv.Quote(node.color))
+		fmt.Fprintf(&b, "%s}\n", indent)
+	}
+
+	for _, ns := range model.namespaces {
+		fmt.Fprintf(&b, "%s: %s {\n", clusterIDs[ns], strconv.Quote("Namespace: "+ns))
+		b.WriteString("  style.stroke-dash: 3\n")
+		for _, node := range model.nodes {
+			if node.namespace == ns {
+				writeNode(node, "  ")
+			}
+		}
+		b.WriteString("}\n")
+	}
+	for _, node := range model.nodes {
+		if node.namespace == "" {
+			writeNode(node, "")
+		}
+	}
+
+	for _, edge := range model.edges {
+		to := clusterIDs[edge.toNamespace]
+		if edge.to != nil {
+			to = path(edge.to)
+		}
+		fmt.Fprintf(&b, "%s -> %s: %s", path(edge.from), to, strconv.Quote(edge.fullLabel("\n")))
+		switch {
+		case edge.inherited:
+			fmt.Fprintf(&b, " {\n  style.stroke-dash: 3\n  style.stroke: %s\n}", strconv.Quote(inheritedEdgeColor))
+		case edge.mirror:
+			b.WriteString(" {\n  style.stroke-dash: 3\n}")
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String(), nil
+}
@@ -18,10 +18,12 @@ package gateway
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"sigs.k8s.io/gwctl/pkg/common"
 	"sigs.k8s.io/gwctl/pkg/topology"
@@ -34,16 +36,51 @@ var (
 		GatewayParentGatewayClassRelation,
 		HTTPRouteParentGatewaysRelation,
 		HTTPRouteChildBackendRefsRelation,
+		GRPCRouteParentGatewaysRelation,
+		GRPCRouteChildBackendRefsRelation,
+		TLSRouteParentGatewaysRelation,
+		TLSRouteChildBackendRefsRelation,
+		TCPRouteParentGatewaysRelation,
+		TCPRouteChildBackendRefsRelation,
+		UDPRouteParentGatewaysRelation,
+		UDPRouteChildBackendRefsRelation,
+		ListenerParentGatewayRelation,
+		HTTPRouteParentListenersRelation,
+		GRPCRouteParentListenersRelation,
+		TLSRouteParentListenersRelation,
+		TCPRouteParentListenersRelation,
+		UDPRouteParentListenersRelation,
 		GatewayNamespace,
 		HTTPRouteNamespace,
+		GRPCRouteNamespace,
+		TLSRouteNamespace,
+		TCPRouteNamespace,
+		UDPRouteNamespace,
 		BackendNamespace,
+		GatewayChildCertificateRefsRelation,
+		BackendTLSPolicyTargetRefsRelation,
+		BackendTLSPolicyChildConfigMapsRelation,
+		BackendTLSPolicyChildSecretsRelation,
+		ReferenceGrantChildBackendsRelation,
+		ReferenceGrantChildSecretsRelation,
+	}
+
+	// RouteGKs contains the GroupKinds of all route types which are modelled in
+	// the topology.
+	RouteGKs = []schema.GroupKind{
+		common.HTTPRouteGK,
+		common.GRPCRouteGK,
+		common.TLSRouteGK,
+		common.TCPRouteGK,
+		common.UDPRouteGK,
 	}
 
 	// GatewayParentGatewayClassRelation returns GatewayClass for the Gateway.
 	GatewayParentGatewayClassRelation = &topology.Relation{
-		From: common.GatewayGK,
-		To:   common.GatewayClassGK,
-		Name: "GatewayClass",
+		From:     common.GatewayGK,
+		To:       common.GatewayClassGK,
+		Name:     "GatewayClass",
+		ToParent: true,
 		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
 			gateway := &gatewayv1.Gateway{}
 			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), gateway); err != nil {
@@ -60,32 +97,16 @@ var (
 	// HTTPRouteParentGatewayRelation returns Gateways which the HTTPRoute is
 	// attached to.
 	HTTPRouteParentGatewaysRelation = &topology.Relation{
-		From: common.HTTPRouteGK,
-		To:   common.GatewayGK,
-		Name: "ParentRef",
+		From:     common.HTTPRouteGK,
+		To:       common.GatewayGK,
+		Name:     "ParentRef",
+		ToParent: true,
 		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
 			httpRoute := &gatewayv1.HTTPRoute{}
 			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), httpRoute); err != nil {
 				panic(fmt.Sprintf("failed to convert unstructured HTTPRoute to structured: %v", err))
 			}
-			result := []common.GKNN{}
-			for _, gatewayRef := range httpRoute.Spec.ParentRefs {
-				namespace := httpRoute.GetNamespace()
-				if namespace == "" {
-					namespace = metav1.NamespaceDefault
-				}
-				if gatewayRef.Namespace != nil {
-					namespace = string(*gatewayRef.Namespace)
-				}
-
-				result = append(result, common.GKNN{
-					Group:     common.GatewayGK.Group,
-					Kind:      common.GatewayGK.Kind,
-					Namespace: namespace,
-					Name:      string(gatewayRef.Name),
-				})
-			}
-			return result
+			return parentRefsToGKNNs(httpRoute.GetNamespace(), httpRoute.Spec.ParentRefs)
 		},
 	}
 
@@ -116,49 +137,174 @@ var (
 					backendRefs = append(backendRefs, filter.RequestMirror.BackendRef)
 				}
 			}
+			return backendRefsToGKNNs(httpRoute.GetNamespace(), backendRefs)
+		},
+	}
+
+	// GRPCRouteParentGatewaysRelation returns Gateways which the GRPCRoute is
+	// attached to.
+	GRPCRouteParentGatewaysRelation = &topology.Relation{
+		From:     common.GRPCRouteGK,
+		To:       common.GatewayGK,
+		Name:     "ParentRef",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			grpcRoute := &gatewayv1.GRPCRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), grpcRoute); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured GRPCRoute to structured: %v", err))
+			}
+			return parentRefsToGKNNs(grpcRoute.GetNamespace(), grpcRoute.Spec.ParentRefs)
+		},
+	}
 
-			// Convert each BackendRef to GKNN. GNKK does not use pointers and
-			// thus is easily comparable.
-			resultSet := make(map[common.GKNN]bool)
-			for _, backendRef := range backendRefs {
-				objRef := common.GKNN{
-					Name: string(backendRef.Name),
-					// Assume namespace is unspecified in the backendRef and
-					// check later to override the default value.
-					Namespace: httpRoute.GetNamespace(),
+	// GRPCRouteChildBackendRefsRelation returns Backends which the GRPCRoute
+	// references.
+	GRPCRouteChildBackendRefsRelation = &topology.Relation{
+		From: common.GRPCRouteGK,
+		To:   common.ServiceGK,
+		Name: "BackendRef",
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			grpcRoute := &gatewayv1.GRPCRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), grpcRoute); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured GRPCRoute to structured: %v", err))
+			}
+			// Aggregate all BackendRefs
+			var backendRefs []gatewayv1.BackendObjectReference
+			for _, rule := range grpcRoute.Spec.Rules {
+				for _, backendRef := range rule.BackendRefs {
+					backendRefs = append(backendRefs, backendRef.BackendObjectReference)
 				}
-				if backendRef.Group != nil {
-					objRef.Group = string(*backendRef.Group)
+				for _, filter := range rule.Filters {
+					if filter.Type != gatewayv1.GRPCRouteFilterRequestMirror {
+						continue
+					}
+					if filter.RequestMirror == nil {
+						continue
+					}
+					backendRefs = append(backendRefs, filter.RequestMirror.BackendRef)
 				}
-				if backendRef.Kind != nil {
-					objRef.Kind = string(*backendRef.Kind)
-				} else {
-					// Although for resources existing on the server, this value
-					// should have received a default before getting persisted.
-					// We still explicitly set this for the local analysis when
-					// the defaults do not get set automatically.
-					objRef.Kind = common.ServiceGK.Kind
+			}
+			return backendRefsToGKNNs(grpcRoute.GetNamespace(), backendRefs)
+		},
+	}
+
+	// TLSRouteParentGatewaysRelation returns Gateways which the TLSRoute is attached
+	// to.
+	TLSRouteParentGatewaysRelation = &topology.Relation{
+		From:     common.TLSRouteGK,
+		To:       common.GatewayGK,
+		Name:     "ParentRef",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			tlsRoute := &gatewayv1.TLSRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), tlsRoute); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured TLSRoute to structured: %v", err))
+			}
+			return parentRefsToGKNNs(tlsRoute.GetNamespace(), tlsRoute.Spec.ParentRefs)
+		},
+	}
+
+	// TLSRouteChildBackendRefsRelation returns Backends which the TLSRoute
+	// references.
+	TLSRouteChildBackendRefsRelation = &topology.Relation{
+		From: common.TLSRouteGK,
+		To:   common.ServiceGK,
+		Name: "BackendRef",
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			tlsRoute := &gatewayv1.TLSRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), tlsRoute); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured TLSRoute to structured: %v", err))
+			}
+			var backendRefs []gatewayv1.BackendObjectReference
+			for _, rule := range tlsRoute.Spec.Rules {
+				for _, backendRef := range rule.BackendRefs {
+					backendRefs = append(backendRefs, backendRef.BackendObjectReference)
 				}
-				if backendRef.Namespace != nil {
-					objRef.Namespace = string(*backendRef.Namespace)
+			}
+			return backendRefsToGKNNs(tlsRoute.GetNamespace(), backendRefs)
+		},
+	}
+
+	// TCPRouteParentGatewaysRelation returns Gateways which the TCPRoute is attached
+	// to.
+	TCPRouteParentGatewaysRelation = &topology.Relation{
+		From:     common.TCPRouteGK,
+		To:       common.GatewayGK,
+		Name:     "ParentRef",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			tcpRoute := &gatewayv1.TCPRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), tcpRoute); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured TCPRoute to structured: %v", err))
+			}
+			return parentRefsToGKNNs(tcpRoute.GetNamespace(), tcpRoute.Spec.ParentRefs)
+		},
+	}
+
+	// TCPRouteChildBackendRefsRelation returns Backends which the TCPRoute
+	// references.
+	TCPRouteChildBackendRefsRelation = &topology.Relation{
+		From: common.TCPRouteGK,
+		To:   common.ServiceGK,
+		Name: "BackendRef",
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			tcpRoute := &gatewayv1.TCPRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), tcpRoute); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured TCPRoute to structured: %v", err))
+			}
+			var backendRefs []gatewayv1.BackendObjectReference
+			for _, rule := range tcpRoute.Spec.Rules {
+				for _, backendRef := range rule.BackendRefs {
+					backendRefs = append(backendRefs, backendRef.BackendObjectReference)
 				}
-				resultSet[objRef] = true
 			}
+			return backendRefsToGKNNs(tcpRoute.GetNamespace(), backendRefs)
+		},
+	}
 
-			// Return unique objRefs
-			var result []common.GKNN
-			for objRef := range resultSet {
-				result = append(result, objRef)
+	// UDPRouteParentGatewaysRelation returns Gateways which the UDPRoute is attached
+	// to.
+	UDPRouteParentGatewaysRelation = &topology.Relation{
+		From:     common.UDPRouteGK,
+		To:       common.GatewayGK,
+		Name:     "ParentRef",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			udpRoute := &gatewayv1.UDPRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), udpRoute); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured UDPRoute to structured: %v", err))
 			}
-			return result
+			return parentRefsToGKNNs(udpRoute.GetNamespace(), udpRoute.Spec.ParentRefs)
+		},
+	}
+
+	// UDPRouteChildBackendRefsRelation returns Backends which the UDPRoute
+	// references.
+	UDPRouteChildBackendRefsRelation = &topology.Relation{
+		From: common.UDPRouteGK,
+		To:   common.ServiceGK,
+		Name: "BackendRef",
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			udpRoute := &gatewayv1.UDPRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), udpRoute); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured UDPRoute to structured: %v", err))
+			}
+			var backendRefs []gatewayv1.BackendObjectReference
+			for _, rule := range udpRoute.Spec.Rules {
+				for _, backendRef := range rule.BackendRefs {
+					backendRefs = append(backendRefs, backendRef.BackendObjectReference)
+				}
+			}
+			return backendRefsToGKNNs(udpRoute.GetNamespace(), backendRefs)
 		},
 	}
 
 	// GatewayNamespace returns the Namespace for the Gateway.
 	GatewayNamespace = &topology.Relation{
-		From: common.GatewayGK,
-		To:   common.NamespaceGK,
-		Name: "Namespace",
+		From:     common.GatewayGK,
+		To:       common.NamespaceGK,
+		Name:     "Namespace",
+		ToParent: true,
 		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
 			return []common.GKNN{{
 				Group: common.NamespaceGK.Group,
@@ -170,9 +316,10 @@ var (
 
 	// HTTPRouteNamespace returns the Namespace for the HTTPRoute.
 	HTTPRouteNamespace = &topology.Relation{
-		From: common.HTTPRouteGK,
-		To:   common.NamespaceGK,
-		Name: "Namespace",
+		From:     common.HTTPRouteGK,
+		To:       common.NamespaceGK,
+		Name:     "Namespace",
+		ToParent: true,
 		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
 			return []common.GKNN{{
 				Group: common.NamespaceGK.Group,
@@ -182,11 +329,73 @@ var (
 		},
 	}
 
-	// BackendNamespace returns the Namespace for the Gateway.
+	// GRPCRouteNamespace returns the Namespace for the GRPCRoute.
+	GRPCRouteNamespace = &topology.Relation{
+		From:     common.GRPCRouteGK,
+		To:       common.NamespaceGK,
+		Name:     "Namespace",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			return []common.GKNN{{
+				Group: common.NamespaceGK.Group,
+				Kind:  common.NamespaceGK.Kind,
+				Name:  u.GetNamespace(),
+			}}
+		},
+	}
+
+	// TLSRouteNamespace returns the Namespace for the TLSRoute.
+	TLSRouteNamespace = &topology.Relation{
+		From:     common.TLSRouteGK,
+		To:       common.NamespaceGK,
+		Name:     "Namespace",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			return []common.GKNN{{
+				Group: common.NamespaceGK.Group,
+				Kind:  common.NamespaceGK.Kind,
+				Name:  u.GetNamespace(),
+			}}
+		},
+	}
+
+	// TCPRouteNamespace returns the Namespace for the TCPRoute.
+	TCPRouteNamespace = &topology.Relation{
+		From:     common.TCPRouteGK,
+		To:       common.NamespaceGK,
+		Name:     "Namespace",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			return []common.GKNN{{
+				Group: common.NamespaceGK.Group,
+				Kind:  common.NamespaceGK.Kind,
+				Name:  u.GetNamespace(),
+			}}
+		},
+	}
+
+	// UDPRouteNamespace returns the Namespace for the UDPRoute.
+	UDPRouteNamespace = &topology.Relation{
+		From:     common.UDPRouteGK,
+		To:       common.NamespaceGK,
+		Name:     "Namespace",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			return []common.GKNN{{
+				Group: common.NamespaceGK.Group,
+				Kind:  common.NamespaceGK.Kind,
+				Name:  u.GetNamespace(),
+			}}
+		},
+	}
+
+	// BackendNamespace returns the Namespace for the Service. Backends of other
+	// kinds use the relation registered through RegisterBackendKind.
 	BackendNamespace = &topology.Relation{
-		From: common.ServiceGK,
-		To:   common.NamespaceGK,
-		Name: "Namespace",
+		From:     common.ServiceGK,
+		To:       common.NamespaceGK,
+		Name:     "Namespace",
+		ToParent: true,
 		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
 			return []common.GKNN{{
 				Group: common.NamespaceGK.Group,
@@ -197,6 +406,77 @@ var (
 	}
 )
 
+// parentRefsToGKNNs converts the parentRefs of a route to the GKNNs of the
+// referenced Gateways.
+func parentRefsToGKNNs(routeNamespace string, parentRefs []gatewayv1.ParentReference) []common.GKNN {
+	result := []common.GKNN{}
+	for _, gatewayRef := range parentRefs {
+		gatewayNN := ParentRefGatewayNamespacedName(routeNamespace, gatewayRef)
+		result = append(result, common.GKNN{
+			Group:     common.GatewayGK.Group,
+			Kind:      common.GatewayGK.Kind,
+			Namespace: gatewayNN.Namespace,
+			Name:      gatewayNN.Name,
+		})
+	}
+	return result
+}
+
+// backendRefsToGKNNs converts the backendRefs of a route to a unique set of
+// GKNNs of the referenced backends.
+func backendRefsToGKNNs(routeNamespace string, backendRefs []gatewayv1.BackendObjectReference) []common.GKNN {
+	// Convert each BackendRef to GKNN. GNKK does not use pointers and
+	// thus is easily comparable.
+	resultSet := make(map[common.GKNN]bool)
+	for _, backendRef := range backendRefs {
+		gknn := backendRefToGKNN(routeNamespace, backendRef)
+		if gknn.GroupKind() != common.ServiceGK && IsBackend(gknn.GroupKind()) {
+			// Backends of kinds added through RegisterBackendKind are connected
+			// through the relations registered for their kind.
+			continue
+		}
+		resultSet[gknn] = true
+	}
+
+	// Return unique objRefs
+	var result []common.GKNN
+	for objRef := range resultSet {
+		result = append(result, objRef)
+	}
+	return result
+}
+
+func backendRefToGKNN(routeNamespace string, backendRef gatewayv1.BackendObjectReference) common.GKNN {
+	objRef := common.GKNN{
+		Name: string(backendRef.Name),
+		// Assume namespace is unspecified in the backendRef and
+		// check later to override the default value.
+		Namespace: routeNamespace,
+	}
+	if backendRef.Group != nil {
+		objRef.Group = string(*backendRef.Group)
+	}
+	if backendRef.Kind != nil {
+		objRef.Kind = string(*backendRef.Kind)
+	} else {
+		// Although for resources existing on the server, this value
+		// should have received a default before getting persisted.
+		// We still explicitly set this for the local analysis when
+		// the defaults do not get set automatically.
+		objRef.Kind = common.ServiceGK.Kind
+	}
+	if backendRef.Namespace != nil {
+		objRef.Namespace = string(*backendRef.Namespace)
+	}
+	return objRef
+}
+
+// IsRoute returns true if the GroupKind is one of the route types modelled in
+// the topology.
+func IsRoute(gk schema.GroupKind) bool {
+	return slices.Contains(RouteGKs, gk)
+}
+
 type gatewayClassNode interface {
 	Gateways() map[common.GKNN]*topology.Node
 }
@@ -217,6 +497,16 @@ type gatewayNode interface {
 	Namespace() *topology.Node
 	GatewayClass() *topology.Node
 	HTTPRoutes() map[common.GKNN]*topology.Node
+	GRPCRoutes() map[common.GKNN]*topology.Node
+	TLSRoutes() map[common.GKNN]*topology.Node
+	TCPRoutes() map[common.GKNN]*topology.Node
+	UDPRoutes() map[common.GKNN]*topology.Node
+	// Routes returns all attached routes, irrespective of their kind.
+	Routes() map[common.GKNN]*topology.Node
+	Listeners() map[common.GKNN]*topology.Node
+	// Secrets returns the Secrets referenced by the certificateRefs of the
+	// listeners.
+	Secrets() map[common.GKNN]*topology.Node
 }
 
 type gatewayNodeImpl struct {
@@ -245,12 +535,71 @@ func (n *gatewayNodeImpl) HTTPRoutes() map[common.GKNN]*topology.Node {
 	return n.node.InNeighbors[HTTPRouteParentGatewaysRelation]
 }
 
-type httpRouteNode interface {
+func (n *gatewayNodeImpl) GRPCRoutes() map[common.GKNN]*topology.Node {
+	return n.node.InNeighbors[GRPCRouteParentGatewaysRelation]
+}
+
+func (n *gatewayNodeImpl) TLSRoutes() map[common.GKNN]*topology.Node {
+	return n.node.InNeighbors[TLSRouteParentGatewaysRelation]
+}
+
+func (n *gatewayNodeImpl) TCPRoutes() map[common.GKNN]*topology.Node {
+	return n.node.InNeighbors[TCPRouteParentGatewaysRelation]
+}
+
+func (n *gatewayNodeImpl) UDPRoutes() map[common.GKNN]*topology.Node {
+	return n.node.InNeighbors[UDPRouteParentGatewaysRelation]
+}
+
+func (n *gatewayNodeImpl) Listeners() map[common.GKNN]*topology.Node {
+	return n.node.InNeighbors[ListenerParentGatewayRelation]
+}
+
+func (n *gatewayNodeImpl) Secrets() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[GatewayChildCertificateRefsRelation]
+}
+
+func (n *gatewayNodeImpl) Routes() map[common.GKNN]*topology.Node {
+	result := make(map[common.GKNN]*topology.Node)
+	maps.Copy(result, n.HTTPRoutes())
+	maps.Copy(result, n.GRPCRoutes())
+	maps.Copy(result, n.TLSRoutes())
+	maps.Copy(result, n.TCPRoutes())
+	maps.Copy(result, n.UDPRoutes())
+	return result
+}
+
+// routeNode contains the accessors which are common to all route types.
+type routeNode interface {
 	Namespace() *topology.Node
 	Gateways() map[common.GKNN]*topology.Node
+	Listeners() map[common.GKNN]*topology.Node
 	Backends() map[common.GKNN]*topology.Node
 }
 
+// RouteNode returns the accessor corresponding to the kind of the route node.
+func RouteNode(node *topology.Node) routeNode {
+	switch node.GKNN().GroupKind() {
+	case common.GRPCRouteGK:
+		return GRPCRouteNode(node)
+	case common.TLSRouteGK:
+		return TLSRouteNode(node)
+	case common.TCPRouteGK:
+		return TCPRouteNode(node)
+	case common.UDPRouteGK:
+		return UDPRouteNode(node)
+	default:
+		return HTTPRouteNode(node)
+	}
+}
+
+type httpRouteNode interface {
+	routeNode
+	// Rules returns the rules of the HTTPRoute, along with the Backends which
+	// each rule forwards and mirrors requests to.
+	Rules() []HTTPRouteRule
+}
+
 type httpRouteNodeImpl struct {
 	node *topology.Node
 }
@@ -270,13 +619,163 @@ func (n *httpRouteNodeImpl) Gateways() map[common.GKNN]*topology.Node {
 	return n.node.OutNeighbors[HTTPRouteParentGatewaysRelation]
 }
 
+func (n *httpRouteNodeImpl) Listeners() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[HTTPRouteParentListenersRelation]
+}
+
 func (n *httpRouteNodeImpl) Backends() map[common.GKNN]*topology.Node {
-	return n.node.OutNeighbors[HTTPRouteChildBackendRefsRelation]
+	return backendRefNeighbors(n.node.OutNeighbors, common.HTTPRouteGK)
+}
+
+func (n *httpRouteNodeImpl) Rules() []HTTPRouteRule {
+	rules := HTTPRouteRules(topology.MustAccessObject(n.node, &gatewayv1.HTTPRoute{}))
+	backendNodes := n.Backends()
+	for i := range rules {
+		for j := range rules[i].Backends {
+			rules[i].Backends[j].Node = backendNodes[rules[i].Backends[j].GKNN]
+		}
+		for j := range rules[i].Mirrors {
+			rules[i].Mirrors[j].Node = backendNodes[rules[i].Mirrors[j].GKNN]
+		}
+	}
+	return rules
+}
+
+type grpcRouteNode interface {
+	routeNode
+}
+
+type grpcRouteNodeImpl struct {
+	node *topology.Node
+}
+
+func GRPCRouteNode(node *topology.Node) grpcRouteNode {
+	return &grpcRouteNodeImpl{node: node}
+}
+
+func (n *grpcRouteNodeImpl) Namespace() *topology.Node {
+	for _, namespaceNode := range n.node.OutNeighbors[GRPCRouteNamespace] {
+		return namespaceNode
+	}
+	return nil
+}
+
+func (n *grpcRouteNodeImpl) Gateways() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[GRPCRouteParentGatewaysRelation]
+}
+
+func (n *grpcRouteNodeImpl) Listeners() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[GRPCRouteParentListenersRelation]
+}
+
+func (n *grpcRouteNodeImpl) Backends() map[common.GKNN]*topology.Node {
+	return backendRefNeighbors(n.node.OutNeighbors, common.GRPCRouteGK)
+}
+
+type tlsRouteNode interface {
+	routeNode
+}
+
+type tlsRouteNodeImpl struct {
+	node *topology.Node
+}
+
+func TLSRouteNode(node *topology.Node) tlsRouteNode {
+	return &tlsRouteNodeImpl{node: node}
+}
+
+func (n *tlsRouteNodeImpl) Namespace() *topology.Node {
+	for _, namespaceNode := range n.node.OutNeighbors[TLSRouteNamespace] {
+		return namespaceNode
+	}
+	return nil
+}
+
+func (n *tlsRouteNodeImpl) Gateways() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[TLSRouteParentGatewaysRelation]
+}
+
+func (n *tlsRouteNodeImpl) Listeners() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[TLSRouteParentListenersRelation]
+}
+
+func (n *tlsRouteNodeImpl) Backends() map[common.GKNN]*topology.Node {
+	return backendRefNeighbors(n.node.OutNeighbors, common.TLSRouteGK)
+}
+
+type tcpRouteNode interface {
+	routeNode
+}
+
+type tcpRouteNodeImpl struct {
+	node *topology.Node
+}
+
+func TCPRouteNode(node *topology.Node) tcpRouteNode {
+	return &tcpRouteNodeImpl{node: node}
+}
+
+func (n *tcpRouteNodeImpl) Namespace() *topology.Node {
+	for _, namespaceNode := range n.node.OutNeighbors[TCPRouteNamespace] {
+		return namespaceNode
+	}
+	return nil
+}
+
+func (n *tcpRouteNodeImpl) Gateways() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[TCPRouteParentGatewaysRelation]
+}
+
+func (n *tcpRouteNodeImpl) Listeners() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[TCPRouteParentListenersRelation]
+}
+
+func (n *tcpRouteNodeImpl) Backends() map[common.GKNN]*topology.Node {
+	return backendRefNeighbors(n.node.OutNeighbors, common.TCPRouteGK)
+}
+
+type udpRouteNode interface {
+	routeNode
+}
+
+type udpRouteNodeImpl struct {
+	node *topology.Node
+}
+
+func UDPRouteNode(node *topology.Node) udpRouteNode {
+	return &udpRouteNodeImpl{node: node}
+}
+
+func (n *udpRouteNodeImpl) Namespace() *topology.Node {
+	for _, namespaceNode := range n.node.OutNeighbors[UDPRouteNamespace] {
+		return namespaceNode
+	}
+	return nil
+}
+
+func (n *udpRouteNodeImpl) Gateways() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[UDPRouteParentGatewaysRelation]
+}
+
+func (n *udpRouteNodeImpl) Listeners() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[UDPRouteParentListenersRelation]
+}
+
+func (n *udpRouteNodeImpl) Backends() map[common.GKNN]*topology.Node {
+	return backendRefNeighbors(n.node.OutNeighbors, common.UDPRouteGK)
 }
 
 type backendNode interface {
 	Namespace() *topology.Node
 	HTTPRoutes() map[common.GKNN]*topology.Node
+	GRPCRoutes() map[common.GKNN]*topology.Node
+	TLSRoutes() map[common.GKNN]*topology.Node
+	TCPRoutes() map[common.GKNN]*topology.Node
+	UDPRoutes() map[common.GKNN]*topology.Node
+	// Routes returns all routes referencing the backend, irrespective of their
+	// kind.
+	Routes() map[common.GKNN]*topology.Node
+	BackendTLSPolicies() map[common.GKNN]*topology.Node
 }
 
 type backendNodeImpl struct {
@@ -288,12 +787,42 @@ func BackendNode(node *topology.Node) backendNode {
 }
 
 func (n *backendNodeImpl) Namespace() *topology.Node {
-	for _, namespaceNode := range n.node.OutNeighbors[BackendNamespace] {
+	for _, namespaceNode := range n.node.OutNeighbors[backendNamespaceRelations[n.node.GKNN().GroupKind()]] {
 		return namespaceNode
 	}
 	return nil
 }
 
 func (n *backendNodeImpl) HTTPRoutes() map[common.GKNN]*topology.Node {
-	return n.node.InNeighbors[HTTPRouteChildBackendRefsRelation]
+	return backendRefNeighbors(n.node.InNeighbors, common.HTTPRouteGK)
+}
+
+func (n *backendNodeImpl) GRPCRoutes() map[common.GKNN]*topology.Node {
+	return backendRefNeighbors(n.node.InNeighbors, common.GRPCRouteGK)
+}
+
+func (n *backendNodeImpl) TLSRoutes() map[common.GKNN]*topology.Node {
+	return backendRefNeighbors(n.node.InNeighbors, common.TLSRouteGK)
+}
+
+func (n *backendNodeImpl) TCPRoutes() map[common.GKNN]*topology.Node {
+	return backendRefNeighbors(n.node.InNeighbors, common.TCPRouteGK)
+}
+
+func (n *backendNodeImpl) UDPRoutes() map[common.GKNN]*topology.Node {
+	return backendRefNeighbors(n.node.InNeighbors, common.UDPRouteGK)
+}
+
+func (n *backendNodeImpl) Routes() map[common.GKNN]*topology.Node {
+	result := make(map[common.GKNN]*topology.Node)
+	maps.Copy(result, n.HTTPRoutes())
+	maps.Copy(result, n.GRPCRoutes())
+	maps.Copy(result, n.TLSRoutes())
+	maps.Copy(result, n.TCPRoutes())
+	maps.Copy(result, n.UDPRoutes())
+	return result
+}
+
+func (n *backendNodeImpl) BackendTLSPolicies() map[common.GKNN]*topology.Node {
+	return n.node.InNeighbors[BackendTLSPolicyTargetRefsRelation]
 }
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"encoding/json"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+)
+
+// GraphJSON is the structured representation of the graph produced by
+// ToGraphJSON.
+type GraphJSON struct {
+	// Namespaces contains the namespaces of all nodes.
+	Namespaces []string        `json:"namespaces"`
+	Nodes      []GraphJSONNode `json:"nodes"`
+	Edges      []GraphJSONEdge `json:"edges"`
+}
+
+type GraphJSONNode struct {
+	// ID uniquely identifies the node, and is used to reference it in edges.
+	ID        string `json:"id"`
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// PolicyType is either Inherited or Direct for policies, and empty for
+	// all other objects.
+	PolicyType string `json:"policyType,omitempty"`
+}
+
+type GraphJSONEdge struct {
+	// From and To are the IDs of the nodes connected by the edge. Edges
+	// pointing to a Namespace use the ID of the Namespace, like
+	// "Namespace/default".
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Relation is the name of the relation, "targetRef" for edges from policies
+	// to their targets, or "inherited" for edges from inherited policies to
+	// the objects on which they take effect.
+	Relation string `json:"relation"`
+	// Details annotate edges from HTTPRoutes to Backends with the share of the
+	// requests of each rule which is sent to the Backend, like "api: 90%".
+	Details []string `json:"details,omitempty"`
+	// Mirror is true for edges to Backends which only receive mirrored
+	// requests.
+	Mirror bool `json:"mirror,omitempty"`
+}
+
+// ToGraphJSON returns a JSON representation of the nodes and edges of the
+// graph. Like ToDot, the output is deterministic.
+func ToGraphJSON(gwctlGraph *topology.Graph, options RenderOptions) (string, error) {
+	model := newRenderModel(gwctlGraph, options)
+
+	result := GraphJSON{
+		Namespaces: model.namespaces,
+		Nodes:      make([]GraphJSONNode, 0, len(model.nodes)),
+		Edges:      make([]GraphJSONEdge, 0, len(model.edges)),
+	}
+	if result.Namespaces == nil {
+		result.Namespaces = []string{}
+	}
+	for _, node := range model.nodes {
+		jsonNode := GraphJSONNode{
+			ID:        node.gknn.String(),
+			Group:     node.gknn.Group,
+			Kind:      node.gknn.Kind,
+			Namespace: node.gknn.Namespace,
+			Name:      node.gknn.Name,
+		}
+		if node.policy != nil {
+			jsonNode.PolicyType = node.policyType()
+		}
+		result.Nodes = append(result.Nodes, jsonNode)
+	}
+	for _, edge := range model.edges {
+		to := common.GKNN{Kind: common.NamespaceGK.Kind, Name: edge.toNamespace}
+		if edge.to != nil {
+			to = edge.to.gknn
+		}
+		result.Edges = append(result.Edges, GraphJSONEdge{
+			From:     edge.from.gknn.String(),
+			To:       to.String(),
+			Relation: edge.label,
+			Details:  edge.details,
+			Mirror:   edge.mirror,
+		})
+	}
+
+	b, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
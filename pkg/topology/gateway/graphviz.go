@@ -17,47 +17,28 @@ limitations under the License.
 package gateway
 
 import (
-	"cmp"
-	"fmt"
-	"maps"
-	"slices"
-
 	"github.com/emicklei/dot"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
 
 	"sigs.k8s.io/gwctl/pkg/common"
 	"sigs.k8s.io/gwctl/pkg/topology"
 )
 
 // The DOT graph generated here needs to be deterministic. This makes sure that integration tests
-// can validate the output produced. Since the graph is created using maps,
-// we can get determinism by converting map keys to slices and sorting them.
-// TODO:
-//   - Show policy nodes. Attempt to group policy nodes along with their target
-//     nodes in a single subgraph so they get rendered closer together.
-func ToDot(gwctlGraph *topology.Graph) (string, error) {
+// can validate the output produced.
+//
+// Policies are grouped along with their target in a single subgraph so they
+// get rendered closer together.
+func ToDot(gwctlGraph *topology.Graph, options RenderOptions) (string, error) {
+	model := newRenderModel(gwctlGraph, options)
+
 	dotGraph := dot.NewGraph(dot.Directed)
 	dotGraph.Attr("rankdir", "BT")
 	dotGraph.Attr("compound", "true")
 
-	// Collect all unique namespaces from nodes
-	namespaces := map[string]struct{}{}
-	for _, nodeMap := range gwctlGraph.Nodes {
-		for _, node := range nodeMap {
-			if node.GKNN().GroupKind() == common.NamespaceGK {
-				continue
-			}
-			if ns := node.GKNN().Namespace; ns != "" {
-				namespaces[ns] = struct{}{}
-			}
-		}
-	}
-
 	// Create subgraphs for each namespace
 	clusterMap := map[string]*dot.Graph{}
 
-	for _, ns := range slices.Sorted(maps.Keys(namespaces)) {
+	for _, ns := range model.namespaces {
 		cluster := dotGraph.Subgraph("cluster_"+ns, dot.ClusterOption{})
 		cluster.Attr("label", "Namespace: "+ns)
 		cluster.Attr("style", "dashed")
@@ -65,97 +46,98 @@ func ToDot(gwctlGraph *topology.Graph) (string, error) {
 		clusterMap[ns] = cluster
 	}
 
-	// Create nodes.
-	dotNodeMap := map[common.GKNN]dot.Node{}
-
-	for _, gk := range slices.SortedFunc(maps.Keys(gwctlGraph.Nodes), compareByString[schema.GroupKind]) {
-		nodeMap := gwctlGraph.Nodes[gk]
+	// Create subgraphs for each object targeted by policies.
+	groupMap := map[common.GKNN]*dot.Graph{}
 
-		for _, nn := range slices.SortedFunc(maps.Keys(nodeMap), compareByString[types.NamespacedName]) {
-			node := nodeMap[nn]
-
-			// Skip Namespace nodes - they will be represented as clusters
-			if node.GKNN().GroupKind() == common.NamespaceGK {
-				continue
-			}
-
-			var targetGraph *dot.Graph
-			if ns := node.GKNN().Namespace; ns != "" {
-				targetGraph = clusterMap[ns]
-			} else {
-				targetGraph = dotGraph
-			}
+	for _, target := range model.policyGroups {
+		parentGraph := dotGraph
+		if target.Namespace != "" {
+			parentGraph = clusterMap[target.Namespace]
+		}
+		group := parentGraph.Subgraph("policies_"+target.String(), dot.ClusterOption{})
+		group.Attr("label", "")
+		group.Attr("style", "dotted")
+		group.Attr("color", "#4c566a")
+		groupMap[target] = group
+	}
 
-			dotNode := targetGraph.Node(node.GKNN().String()).
-				Attr("style", "filled").
-				Attr("color", mapNodeColor(node))
+	// Create nodes.
+	dotNodeMap := map[*renderNode]dot.Node{}
+
+	for _, node := range model.nodes {
+		var targetGraph *dot.Graph
+		switch {
+		case node.group != (common.GKNN{}):
+			targetGraph = groupMap[node.group]
+		case node.namespace != "":
+			targetGraph = clusterMap[node.namespace]
+		default:
+			targetGraph = dotGraph
+		}
 
-			dotNodeMap[node.GKNN()] = dotNode
+		label := node.kind + "\n" + node.name
+		id := node.gknn.String()
+		if node.policy != nil {
+			label += "\n(" + node.policyType() + ")"
+			id = "Policy/" + id
+		}
 
-			// Set the Node label
-			gk := node.GKNN().GroupKind()
-			if gk.Group == common.GatewayGK.Group {
-				gk.Group = ""
-			}
-			dotNode.Label(gk.String() + "\n" + node.GKNN().Name)
+		dotNode := targetGraph.Node(id).
+			Attr("style", "filled").
+			Attr("color", node.color).
+			Label(label)
+		if node.policy != nil {
+			dotNode.Attr("shape", "note")
 		}
+		dotNodeMap[node] = dotNode
 	}
 
 	// Create edges.
-	for _, fromNodeGKNN := range slices.SortedFunc(maps.Keys(dotNodeMap), compareByString[common.GKNN]) {
-		dotFromNode := dotNodeMap[fromNodeGKNN]
-		fromNode := gwctlGraph.Nodes[fromNodeGKNN.GroupKind()][fromNodeGKNN.NamespacedName()]
-
-		for _, relation := range slices.SortedFunc(maps.Keys(fromNode.OutNeighbors), func(a, b *topology.Relation) int {
-			return cmp.Compare(a.Name, b.Name)
-		}) {
-			outNodeMap := fromNode.OutNeighbors[relation]
-
-			for _, toNodeGKNN := range slices.SortedFunc(maps.Keys(outNodeMap), compareByString[common.GKNN]) {
-				// Skip edges to Namespace nodes - namespace relationship are represented by cluster membership
-				if toNodeGKNN.GroupKind() == common.NamespaceGK {
-					continue
-				}
-
-				dotToNode := dotNodeMap[toNodeGKNN]
-
-				// If this is an edge from an HTTPRoute to a Service, then
-				// reverse the direction of the edge (to affect the rank), and
-				// then reverse the display again to show the correct direction.
-				// The end result being that Services now get assigned the
-				// correct rank.
-				reverse := fromNode.GKNN().GroupKind() == common.HTTPRouteGK && toNodeGKNN.GroupKind() == common.ServiceGK
-				u, v := dotFromNode, dotToNode
-				if reverse {
-					u, v = v, u
+	for _, edge := range model.edges {
+		dotFromNode := dotNodeMap[edge.from]
+
+		if edge.toNamespace != "" {
+			// Point the edge to the Namespace cluster, using any node within
+			// the cluster as the head.
+			for _, node := range model.nodes {
+				if node.policy == nil && node.namespace == edge.toNamespace {
+					dotGraph.Edge(dotFromNode, dotNodeMap[node], edge.label).Attr("lhead", clusterMap[edge.toNamespace].GetID())
+					break
 				}
+			}
+			continue
+		}
 
-				e := dotGraph.Edge(u, v, relation.Name)
+		dotToNode := dotNodeMap[edge.to]
 
-				if reverse {
-					e.Attr("dir", "back")
-				}
-			}
+		if edge.inherited {
+			dotGraph.Edge(dotFromNode, dotToNode, edge.label).
+				Attr("style", "dashed").
+				Attr("color", inheritedEdgeColor).
+				Attr("constraint", "false")
+			continue
 		}
-	}
 
-	return dotGraph.String(), nil
-}
+		// If this is an edge from a Route to a Backend, then
+		// reverse the direction of the edge (to affect the rank), and
+		// then reverse the display again to show the correct direction.
+		// The end result being that Backends now get assigned the
+		// correct rank.
+		reverse := IsRoute(edge.from.gknn.GroupKind()) && IsBackend(edge.to.gknn.GroupKind())
+		u, v := dotFromNode, dotToNode
+		if reverse {
+			u, v = v, u
+		}
 
-func compareByString[T fmt.Stringer](a, b T) int {
-	return cmp.Compare(a.String(), b.String())
-}
+		e := dotGraph.Edge(u, v, edge.fullLabel("\n"))
 
-func mapNodeColor(node *topology.Node) string {
-	switch node.GKNN().GroupKind() {
-	case common.GatewayClassGK:
-		return "#e5e9f0"
-	case common.GatewayGK:
-		return "#ebcb8b"
-	case common.HTTPRouteGK:
-		return "#a3be8c"
-	case common.ServiceGK:
-		return "#88c0d0"
+		if reverse {
+			e.Attr("dir", "back")
+		}
+		if edge.mirror {
+			e.Attr("style", "dashed")
+		}
 	}
-	return "#d8dee9"
+
+	return dotGraph.String(), nil
 }
@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"maps"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+var (
+	// ListenerParentGatewayRelation returns the Gateway which the Listener is a
+	// part of.
+	ListenerParentGatewayRelation = &topology.Relation{
+		From:     common.ListenerGK,
+		To:       common.GatewayGK,
+		Name:     "Gateway",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			gatewayName, _ := common.SplitListenerName(u.GetName())
+			return []common.GKNN{{
+				Group:     common.GatewayGK.Group,
+				Kind:      common.GatewayGK.Kind,
+				Namespace: u.GetNamespace(),
+				Name:      gatewayName,
+			}}
+		},
+	}
+
+	// HTTPRouteParentListenersRelation returns the Listeners which the HTTPRoute
+	// is attached to.
+	HTTPRouteParentListenersRelation = routeParentListenersRelation(common.HTTPRouteGK)
+	// GRPCRouteParentListenersRelation returns the Listeners which the GRPCRoute
+	// is attached to.
+	GRPCRouteParentListenersRelation = routeParentListenersRelation(common.GRPCRouteGK)
+	// TLSRouteParentListenersRelation returns the Listeners which the TLSRoute is
+	// attached to.
+	TLSRouteParentListenersRelation = routeParentListenersRelation(common.TLSRouteGK)
+	// TCPRouteParentListenersRelation returns the Listeners which the TCPRoute is
+	// attached to.
+	TCPRouteParentListenersRelation = routeParentListenersRelation(common.TCPRouteGK)
+	// UDPRouteParentListenersRelation returns the Listeners which the UDPRoute is
+	// attached to.
+	UDPRouteParentListenersRelation = routeParentListenersRelation(common.UDPRouteGK)
+
+	routeParentListenersRelations = []*topology.Relation{
+		HTTPRouteParentListenersRelation,
+		GRPCRouteParentListenersRelation,
+		TLSRouteParentListenersRelation,
+		TCPRouteParentListenersRelation,
+		UDPRouteParentListenersRelation,
+	}
+)
+
+// routeParentListenersRelation returns a Relation from the given route kind to
+// the Listeners which are selected by the parentRefs of the route.
+//
+// A parentRef which specifies a sectionName and/or port selects exactly the
+// listeners which match them. A parentRef which specifies neither selects all
+// listeners of the Gateway which support the kind of the route.
+func routeParentListenersRelation(routeGK schema.GroupKind) *topology.Relation {
+	return &topology.Relation{
+		From:     routeGK,
+		To:       common.ListenerGK,
+		Name:     "ParentRef",
+		ToParent: true,
+		GraphNeighborFunc: func(u *unstructured.Unstructured, graph *topology.Graph) []common.GKNN {
+			var result []common.GKNN
+			for _, parentRef := range RouteParentRefs(u) {
+				if !IsGatewayParentRef(parentRef) {
+					continue
+				}
+				gatewayNN := ParentRefGatewayNamespacedName(u.GetNamespace(), parentRef)
+				gatewayNode := graph.Nodes[common.GatewayGK][gatewayNN]
+				if gatewayNode == nil {
+					continue
+				}
+				gateway := topology.MustAccessObject(gatewayNode, &gatewayv1.Gateway{})
+
+				implicit := parentRef.SectionName == nil && parentRef.Port == nil
+				for _, listener := range gateway.Spec.Listeners {
+					if !ParentRefMatchesListener(parentRef, listener) {
+						continue
+					}
+					if implicit && !ListenerSupportsRouteKind(listener, routeGK) {
+						continue
+					}
+					result = append(result, common.ListenerGKNN(gatewayNN, listener.Name))
+				}
+			}
+			return result
+		},
+	}
+}
+
+// RouteParentRefs returns the parentRefs of a route of any kind.
+func RouteParentRefs(u *unstructured.Unstructured) []gatewayv1.ParentReference {
+	route := &struct {
+		Spec gatewayv1.CommonRouteSpec `json:"spec"`
+	}{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), route); err != nil {
+		panic(fmt.Sprintf("failed to convert unstructured %v to structured: %v", u.GroupVersionKind().Kind, err))
+	}
+	return route.Spec.ParentRefs
+}
+
+// IsGatewayParentRef returns true if the parentRef references a Gateway.
+func IsGatewayParentRef(parentRef gatewayv1.ParentReference) bool {
+	if parentRef.Group != nil && string(*parentRef.Group) != common.GatewayGK.Group {
+		return false
+	}
+	if parentRef.Kind != nil && string(*parentRef.Kind) != common.GatewayGK.Kind {
+		return false
+	}
+	return true
+}
+
+// ParentRefGatewayNamespacedName returns the NamespacedName of the Gateway
+// referenced by the parentRef of a route in the given namespace.
+func ParentRefGatewayNamespacedName(routeNamespace string, parentRef gatewayv1.ParentReference) types.NamespacedName {
+	namespace := routeNamespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	if parentRef.Namespace != nil {
+		namespace = string(*parentRef.Namespace)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: string(parentRef.Name)}
+}
+
+// ParentRefMatchesListener returns true if the sectionName and port of the
+// parentRef (whichever are specified) match the listener.
+func ParentRefMatchesListener(parentRef gatewayv1.ParentReference, listener gatewayv1.Listener) bool {
+	if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+		return false
+	}
+	if parentRef.Port != nil && *parentRef.Port != listener.Port {
+		return false
+	}
+	return true
+}
+
+// ListenerSupportsRouteKind returns true if the listener allows routes of the
+// given kind. If the listener does not restrict the kinds through
+// allowedRoutes, the kinds are determined by the protocol of the listener.
+func ListenerSupportsRouteKind(listener gatewayv1.Listener, routeGK schema.GroupKind) bool {
+	if listener.AllowedRoutes != nil && len(listener.AllowedRoutes.Kinds) != 0 {
+		for _, kind := range listener.AllowedRoutes.Kinds {
+			group := common.GatewayGK.Group
+			if kind.Group != nil {
+				group = string(*kind.Group)
+			}
+			if group == routeGK.Group && string(kind.Kind) == routeGK.Kind {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch listener.Protocol {
+	case gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType:
+		return routeGK == common.HTTPRouteGK || routeGK == common.GRPCRouteGK
+	case gatewayv1.TLSProtocolType:
+		return routeGK == common.TLSRouteGK || routeGK == common.TCPRouteGK
+	case gatewayv1.TCPProtocolType:
+		return routeGK == common.TCPRouteGK
+	case gatewayv1.UDPProtocolType:
+		return routeGK == common.UDPRouteGK
+	}
+	// Implementation specific protocols may support any kind of route.
+	return true
+}
+
+type listenerNode interface {
+	Gateway() *topology.Node
+	// Routes returns all routes attached to the listener, irrespective of
+	// their kind.
+	Routes() map[common.GKNN]*topology.Node
+}
+
+type listenerNodeImpl struct {
+	node *topology.Node
+}
+
+func ListenerNode(node *topology.Node) listenerNode { //nolint:revive
+	return &listenerNodeImpl{node: node}
+}
+
+func (n *listenerNodeImpl) Gateway() *topology.Node {
+	for _, gatewayNode := range n.node.OutNeighbors[ListenerParentGatewayRelation] {
+		return gatewayNode
+	}
+	return nil
+}
+
+func (n *listenerNodeImpl) Routes() map[common.GKNN]*topology.Node {
+	result := make(map[common.GKNN]*topology.Node)
+	for _, relation := range routeParentListenersRelations {
+		maps.Copy(result, n.node.InNeighbors[relation])
+	}
+	return result
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/gwctl/pkg/topology"
+)
+
+// ToMermaid returns a Mermaid flowchart representation of the graph, which
+// renders natively in Markdown. Like ToDot, the output is deterministic.
+func ToMermaid(gwctlGraph *topology.Graph, options RenderOptions) (string, error) {
+	model := newRenderModel(gwctlGraph, options)
+
+	ids := map[*renderNode]string{}
+	for i, node := range model.nodes {
+		ids[node] = fmt.Sprintf("n%d", i+1)
+	}
+	clusterIDs := map[string]string{}
+	for i, ns := range model.namespaces {
+		clusterIDs[ns] = fmt.Sprintf("ns%d", i+1)
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart BT\n")
+
+	writeNode := func(node *renderNode, indent string) {
+		label := mermaidEscape(node.kind) + "<br/>" + mermaidEscape(node.name)
+		if node.policy != nil {
+			// Asymmetric shape for policies.
+			fmt.Fprintf(&b, "%s%s>\"%s<br/>(%s)\"]\n", indent, ids[node], label, node.policyType())
+			return
+		}
+		fmt.Fprintf(&b, "%s%s[\"%s\"]\n", indent, ids[node], label)
+	}
+
+	// Nodes within namespaces are placed in a subgraph for each namespace.
+	for _, ns := range model.namespaces {
+		fmt.Fprintf(&b, "    subgraph %s[\"Namespace: %s\"]\n", clusterIDs[ns], mermaidEscape(ns))
+		for _, node := range model.nodes {
+			if node.namespace == ns {
+				writeNode(node, "        ")
+			}
+		}
+		b.WriteString("    end\n")
+	}
+	for _, node := range model.nodes {
+		if node.namespace == "" {
+			writeNode(node, "    ")
+		}
+	}
+
+	for _, edge := range model.edges {
+		to := clusterIDs[edge.toNamespace]
+		if edge.to != nil {
+			to = ids[edge.to]
+		}
+		arrow := "-->"
+		if edge.inherited || edge.mirror {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "    %s %s|%s| %s\n", ids[edge.from], arrow, strings.ReplaceAll(mermaidEscape(edge.fullLabel("\n")), "\n", "<br/>"), to)
+	}
+
+	for _, ns := range model.namespaces {
+		fmt.Fprintf(&b, "    style %s fill:none,stroke:black,stroke-dasharray:5 5\n", clusterIDs[ns])
+	}
+	for _, node := range model.nodes {
+		fmt.Fprintf(&b, "    style %s fill:%s\n", ids[node], node.color)
+	}
+	for i, edge := range model.edges {
+		if edge.inherited {
+			fmt.Fprintf(&b, "    linkStyle %d stroke:%s\n", i, inheritedEdgeColor)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// mermaidEscape escapes characters which have a special meaning within Mermaid
+// labels.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer("#", "#35;", `"`, "#quot;", "|", "#124;").Replace(s)
+}
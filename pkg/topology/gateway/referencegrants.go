@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+var (
+	// ReferenceGrantChildBackendsRelation returns the Services which the
+	// ReferenceGrant allows references to.
+	ReferenceGrantChildBackendsRelation = referenceGrantToRelation(common.ServiceGK)
+	// ReferenceGrantChildSecretsRelation returns the Secrets which the
+	// ReferenceGrant allows references to.
+	ReferenceGrantChildSecretsRelation = referenceGrantToRelation(common.SecretGK)
+
+	// referenceGrantBackendsRelations contains the relations from
+	// ReferenceGrants to the backends which they allow references to. One
+	// relation is added for each kind registered through RegisterBackendKind.
+	referenceGrantBackendsRelations = []*topology.Relation{ReferenceGrantChildBackendsRelation}
+)
+
+// referenceGrantToRelation returns a Relation from ReferenceGrants to the
+// objects of the given kind in the "To" fields. A "To" field without a name
+// refers to all objects of the kind within the namespace of the
+// ReferenceGrant.
+//
+// Only objects which exist in the graph are returned, since a ReferenceGrant
+// referring to an object which does not exist yet is not an error.
+func referenceGrantToRelation(to schema.GroupKind) *topology.Relation {
+	return &topology.Relation{
+		From:     common.ReferenceGrantGK,
+		To:       to,
+		Name:     "To",
+		ToParent: true,
+		GraphNeighborFunc: func(u *unstructured.Unstructured, graph *topology.Graph) []common.GKNN {
+			referenceGrant := &gatewayv1beta1.ReferenceGrant{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), referenceGrant); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured ReferenceGrant to structured: %v", err))
+			}
+
+			resultSet := make(map[common.GKNN]bool)
+			for _, grantTo := range referenceGrant.Spec.To {
+				if (schema.GroupKind{Group: string(grantTo.Group), Kind: string(grantTo.Kind)}) != to {
+					continue
+				}
+				for nn, node := range graph.Nodes[to] {
+					if nn.Namespace != referenceGrant.GetNamespace() {
+						continue
+					}
+					if grantTo.Name != nil && len(*grantTo.Name) != 0 && string(*grantTo.Name) != nn.Name {
+						continue
+					}
+					resultSet[node.GKNN()] = true
+				}
+			}
+			return slices.Collect(maps.Keys(resultSet))
+		},
+	}
+}
+
+type referenceGrantNode interface {
+	// Exposes returns the objects which the ReferenceGrant allows references
+	// to.
+	Exposes() map[common.GKNN]*topology.Node
+}
+
+type referenceGrantNodeImpl struct {
+	node *topology.Node
+}
+
+func ReferenceGrantNode(node *topology.Node) referenceGrantNode { //nolint:revive
+	return &referenceGrantNodeImpl{node: node}
+}
+
+func (n *referenceGrantNodeImpl) Exposes() map[common.GKNN]*topology.Node {
+	result := make(map[common.GKNN]*topology.Node)
+	for _, relation := range referenceGrantBackendsRelations {
+		maps.Copy(result, n.node.OutNeighbors[relation])
+	}
+	maps.Copy(result, n.node.OutNeighbors[ReferenceGrantChildSecretsRelation])
+	return result
+}
@@ -0,0 +1,385 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gwctl/pkg/topology"
+)
+
+// RenderOptions configures the graphs generated by ToDot, ToMermaid, ToD2 and
+// ToGraphJSON.
+type RenderOptions struct {
+	// Policies are shown as nodes next to the objects they target. Policies
+	// which do not target any object in the graph are not shown.
+	Policies []*policymanager.Policy
+	// InheritedPolicyEffects maps an inherited policy to the objects on which
+	// it takes effect through inheritance. If non-nil, a dashed edge is drawn
+	// from the policy to each of these objects.
+	InheritedPolicyEffects map[common.GKNN][]common.GKNN
+}
+
+// renderModel is the part of a topology.Graph which gets rendered, independent
+// of the output format. The graph is created using maps, so all slices here are
+// sorted to make the output of every renderer deterministic.
+type renderModel struct {
+	// namespaces are rendered as clusters containing the nodes within them.
+	namespaces []string
+	// policyGroups contains the objects which are grouped along with the
+	// policies targeting them, in the order in which the groups get created.
+	policyGroups []common.GKNN
+	nodes        []*renderNode
+	edges        []*renderEdge
+}
+
+type renderNode struct {
+	gknn common.GKNN
+	// kind and name form the label of the node.
+	kind string
+	name string
+	// namespace is the namespace cluster containing the node, if any.
+	namespace string
+	// group is the object with which the node is grouped, if any.
+	group common.GKNN
+	color string
+	// policy is only set for policy nodes.
+	policy *policymanager.Policy
+}
+
+type renderEdge struct {
+	from *renderNode
+	// Exactly one of to and toNamespace is set. toNamespace is used for
+	// edges pointing to the cluster of a Namespace.
+	to          *renderNode
+	toNamespace string
+	label       string
+	// inherited is true for edges from an inherited policy to an object on
+	// which it takes effect.
+	inherited bool
+	// details annotate edges from HTTPRoutes to Backends with the share of
+	// the requests of each rule which is sent to the Backend, like "api: 90%".
+	details []string
+	// mirror is true for edges to Backends which only receive requests
+	// mirrored by RequestMirror filters.
+	mirror bool
+}
+
+// fullLabel returns the label of the edge, followed by its details, separated
+// by sep.
+func (e *renderEdge) fullLabel(sep string) string {
+	return strings.Join(append([]string{e.label}, e.details...), sep)
+}
+
+func (n *renderNode) policyType() string {
+	if n.policy.IsInheritable() {
+		return "Inherited"
+	}
+	return "Direct"
+}
+
+func newRenderModel(gwctlGraph *topology.Graph, options RenderOptions) *renderModel {
+	model := &renderModel{}
+
+	// Collect all unique namespaces from nodes
+	namespaces := map[string]struct{}{}
+	for _, nodeMap := range gwctlGraph.Nodes {
+		for _, node := range nodeMap {
+			if node.GKNN().GroupKind() == common.NamespaceGK {
+				continue
+			}
+			if ns := node.GKNN().Namespace; ns != "" {
+				namespaces[ns] = struct{}{}
+			}
+		}
+	}
+	model.namespaces = slices.Sorted(maps.Keys(namespaces))
+
+	// Find the objects targeted by each policy. Policies are grouped along with
+	// the first object they target. Policies which only target Namespaces are
+	// not grouped (nor placed within a Namespace cluster), so that the edge can
+	// point to the cluster itself.
+	policies := slices.SortedFunc(slices.Values(options.Policies), func(a, b *policymanager.Policy) int {
+		return compareByString(a.GKNN(), b.GKNN())
+	})
+	policyTargets := map[common.GKNN][]common.GKNN{}
+	policyGroups := map[common.GKNN]common.GKNN{}
+	isGrouped := map[common.GKNN]bool{}
+	for _, policy := range policies {
+		targets := slices.SortedFunc(slices.Values(policy.TargetRefs), compareByString[common.GKNN])
+		for _, target := range slices.Compact(targets) {
+			if !gwctlGraph.HasNode(target) {
+				continue
+			}
+			if _, ok := namespaces[target.Name]; target.GroupKind() == common.NamespaceGK && !ok {
+				continue
+			}
+			policyTargets[policy.GKNN()] = append(policyTargets[policy.GKNN()], target)
+		}
+
+		i := slices.IndexFunc(policyTargets[policy.GKNN()], func(target common.GKNN) bool {
+			return target.GroupKind() != common.NamespaceGK
+		})
+		if i == -1 {
+			continue
+		}
+		group := policyTargets[policy.GKNN()][i]
+		policyGroups[policy.GKNN()] = group
+		if !isGrouped[group] {
+			isGrouped[group] = true
+			model.policyGroups = append(model.policyGroups, group)
+		}
+	}
+
+	// Create nodes.
+	nodeMap := map[common.GKNN]*renderNode{}
+	for _, gk := range slices.SortedFunc(maps.Keys(gwctlGraph.Nodes), compareByString[schema.GroupKind]) {
+		for _, nn := range slices.SortedFunc(maps.Keys(gwctlGraph.Nodes[gk]), compareByString[types.NamespacedName]) {
+			node := gwctlGraph.Nodes[gk][nn]
+
+			// Skip Namespace nodes - they will be represented as clusters
+			if node.GKNN().GroupKind() == common.NamespaceGK {
+				continue
+			}
+			// Skip policies which are also modelled in the graph (like
+			// BackendTLSPolicies) - they will be represented as policy nodes
+			if _, ok := policyTargets[node.GKNN()]; ok {
+				continue
+			}
+
+			renderNode := &renderNode{
+				gknn:      node.GKNN(),
+				kind:      displayKind(node.GKNN()),
+				name:      node.GKNN().Name,
+				namespace: node.GKNN().Namespace,
+				color:     mapNodeColor(node),
+			}
+			if isGrouped[node.GKNN()] {
+				renderNode.group = node.GKNN()
+			}
+			model.nodes = append(model.nodes, renderNode)
+			nodeMap[node.GKNN()] = renderNode
+		}
+	}
+
+	// Create policy nodes.
+	policyNodeMap := map[common.GKNN]*renderNode{}
+	for _, policy := range policies {
+		if len(policyTargets[policy.GKNN()]) == 0 {
+			continue
+		}
+		renderNode := &renderNode{
+			gknn:   policy.GKNN(),
+			kind:   displayKind(policy.GKNN()),
+			name:   policy.GKNN().Name,
+			color:  mapPolicyNodeColor(policy),
+			policy: policy,
+		}
+		if group, ok := policyGroups[policy.GKNN()]; ok {
+			renderNode.group = group
+			renderNode.namespace = group.Namespace
+		} else if policy.GKNN().Namespace != "" {
+			// The policy is outside of its Namespace cluster.
+			renderNode.name = policy.GKNN().NamespacedName().String()
+		}
+		model.nodes = append(model.nodes, renderNode)
+		policyNodeMap[policy.GKNN()] = renderNode
+		if gwctlGraph.HasNode(policy.GKNN()) {
+			nodeMap[policy.GKNN()] = renderNode
+		}
+	}
+
+	// Routes which are attached to specific Listeners of a Gateway are
+	// connected to those Listeners instead of the Gateway itself.
+	attachedThroughListeners := map[common.GKNN]map[common.GKNN]bool{}
+	for _, routeGK := range RouteGKs {
+		for _, routeNode := range gwctlGraph.Nodes[routeGK] {
+			for _, listenerNode := range RouteNode(routeNode).Listeners() {
+				gatewayNode := ListenerNode(listenerNode).Gateway()
+				if gatewayNode == nil {
+					continue
+				}
+				if attachedThroughListeners[routeNode.GKNN()] == nil {
+					attachedThroughListeners[routeNode.GKNN()] = map[common.GKNN]bool{}
+				}
+				attachedThroughListeners[routeNode.GKNN()][gatewayNode.GKNN()] = true
+			}
+		}
+	}
+
+	// Create edges.
+	for _, fromNodeGKNN := range slices.SortedFunc(maps.Keys(nodeMap), compareByString[common.GKNN]) {
+		fromNode := gwctlGraph.Nodes[fromNodeGKNN.GroupKind()][fromNodeGKNN.NamespacedName()]
+
+		for _, relation := range slices.SortedFunc(maps.Keys(fromNode.OutNeighbors), func(a, b *topology.Relation) int {
+			return cmp.Compare(a.Name, b.Name)
+		}) {
+			outNodeMap := fromNode.OutNeighbors[relation]
+
+			for _, toNodeGKNN := range slices.SortedFunc(maps.Keys(outNodeMap), compareByString[common.GKNN]) {
+				// Skip edges to Namespace nodes - namespace relationship are represented by cluster membership
+				if toNodeGKNN.GroupKind() == common.NamespaceGK {
+					continue
+				}
+
+				if attachedThroughListeners[fromNodeGKNN][toNodeGKNN] {
+					continue
+				}
+
+				// Edges from policies to their targets are created below.
+				if slices.Contains(policyTargets[fromNodeGKNN], toNodeGKNN) {
+					continue
+				}
+
+				edge := &renderEdge{
+					from:  nodeMap[fromNodeGKNN],
+					to:    nodeMap[toNodeGKNN],
+					label: relation.Name,
+				}
+				if IsBackendRefRelation(relation, common.HTTPRouteGK) {
+					edge.details, edge.mirror = httpRouteBackendEdgeDetails(fromNode, toNodeGKNN)
+				}
+				model.edges = append(model.edges, edge)
+			}
+		}
+	}
+
+	// Create edges from policies to their targets.
+	for _, policy := range policies {
+		for _, target := range policyTargets[policy.GKNN()] {
+			edge := &renderEdge{from: policyNodeMap[policy.GKNN()], label: "targetRef"}
+			if target.GroupKind() == common.NamespaceGK {
+				edge.toNamespace = target.Name
+			} else {
+				edge.to = nodeMap[target]
+			}
+			model.edges = append(model.edges, edge)
+		}
+	}
+
+	// Create edges from inherited policies to the objects on which they take
+	// effect.
+	for _, policyGKNN := range slices.SortedFunc(maps.Keys(options.InheritedPolicyEffects), compareByString[common.GKNN]) {
+		policyNode, ok := policyNodeMap[policyGKNN]
+		if !ok {
+			continue
+		}
+		effects := slices.SortedFunc(slices.Values(options.InheritedPolicyEffects[policyGKNN]), compareByString[common.GKNN])
+		for _, gknn := range slices.Compact(effects) {
+			node, ok := nodeMap[gknn]
+			if !ok {
+				continue
+			}
+			model.edges = append(model.edges, &renderEdge{from: policyNode, to: node, label: "inherited", inherited: true})
+		}
+	}
+
+	return model
+}
+
+// httpRouteBackendEdgeDetails returns the details of the edge from the
+// HTTPRoute to the Backend, and whether the Backend only receives mirrored
+// requests. The details list the share of the requests of each rule which is
+// sent to the Backend, like "api: 90%", or "api: mirror 50%" for mirrors. They
+// are omitted when the Backend receives all the requests of every rule
+// referencing it.
+func httpRouteBackendEdgeDetails(routeNode *topology.Node, backend common.GKNN) ([]string, bool) {
+	var details []string
+	mirror, split := true, false
+	for _, rule := range HTTPRouteNode(routeNode).Rules() {
+		for _, b := range rule.Backends {
+			if b.GKNN != backend {
+				continue
+			}
+			mirror = false
+			if b.Percent != 100 {
+				split = true
+			}
+			details = append(details, fmt.Sprintf("%v: %v", rule.Name(), FormatPercent(b.Percent)))
+		}
+		for _, b := range rule.Mirrors {
+			if b.GKNN != backend {
+				continue
+			}
+			split = true
+			details = append(details, fmt.Sprintf("%v: mirror %v", rule.Name(), FormatPercent(b.Percent)))
+		}
+	}
+	if !split {
+		details = nil
+	}
+	return details, mirror && len(details) != 0
+}
+
+// displayKind returns the kind shown in the label of a node. The group is
+// omitted for Gateway API kinds.
+func displayKind(gknn common.GKNN) string {
+	gk := gknn.GroupKind()
+	if gk.Group == common.GatewayGK.Group || gk == common.ListenerGK {
+		gk.Group = ""
+	}
+	return gk.String()
+}
+
+func compareByString[T fmt.Stringer](a, b T) int {
+	return cmp.Compare(a.String(), b.String())
+}
+
+func mapNodeColor(node *topology.Node) string {
+	switch node.GKNN().GroupKind() {
+	case common.GatewayClassGK:
+		return "#e5e9f0"
+	case common.GatewayGK:
+		return "#ebcb8b"
+	case common.ListenerGK:
+		return "#f3dfb5"
+	case common.HTTPRouteGK:
+		return "#a3be8c"
+	case common.GRPCRouteGK:
+		return "#b48ead"
+	case common.TLSRouteGK:
+		return "#d08770"
+	case common.TCPRouteGK:
+		return "#8fbcbb"
+	case common.UDPRouteGK:
+		return "#81a1c1"
+	}
+	if IsBackend(node.GKNN().GroupKind()) {
+		return "#88c0d0"
+	}
+	return "#d8dee9"
+}
+
+func mapPolicyNodeColor(policy *policymanager.Policy) string {
+	if policy.IsInheritable() {
+		return "#e8b4b8"
+	}
+	return "#eceff4"
+}
+
+// inheritedEdgeColor is the color of edges from inherited policies to the
+// objects on which they take effect.
+const inheritedEdgeColor = "#bf616a"
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// HTTPRouteRule is a rule of an HTTPRoute, along with the Backends which it
+// forwards and mirrors requests to. The BackendRef relations only connect the
+// HTTPRoute to the set of all its Backends, so this is used
+// wherever the rules, weights or mirrors matter.
+type HTTPRouteRule struct {
+	// Index is the index of the rule within the HTTPRoute.
+	Index int
+	Rule  gatewayv1.HTTPRouteRule
+	// Backends are the backendRefs of the rule, in order.
+	Backends []RuleBackend
+	// Mirrors are the backendRefs of the RequestMirror filters of the rule.
+	Mirrors []RuleBackend
+}
+
+// Name returns the name of the rule, or its index if it has no name.
+func (r HTTPRouteRule) Name() string {
+	if r.Rule.Name != nil && *r.Rule.Name != "" {
+		return string(*r.Rule.Name)
+	}
+	return fmt.Sprintf("%d", r.Index)
+}
+
+// TotalWeight returns the sum of the weights of the backendRefs of the rule.
+// When the rule has backendRefs and this is zero, all requests matching the
+// rule are rejected.
+func (r HTTPRouteRule) TotalWeight() int32 {
+	var total int32
+	for _, backend := range r.Backends {
+		total += backend.Weight
+	}
+	return total
+}
+
+// RuleBackend is a Backend referenced by a rule of a Route.
+type RuleBackend struct {
+	GKNN common.GKNN
+	Port *gatewayv1.PortNumber
+	// Weight is the weight of the backendRef, which defaults to 1. It is not
+	// set for mirrors.
+	Weight int32
+	// Percent is the percentage of the requests matching the rule which are
+	// sent to the Backend. For mirrors, it is the percentage of requests which
+	// are mirrored to the Backend.
+	Percent float64
+	// Node is the Backend in the Graph, or nil if it is not part of the Graph.
+	Node *topology.Node
+}
+
+// String returns the Backend in the form "namespace/name:port". The kind is
+// only included when the Backend is not a Service.
+func (b RuleBackend) String() string {
+	result := fmt.Sprintf("%v/%v", b.GKNN.Namespace, b.GKNN.Name)
+	if b.GKNN.GroupKind() != common.ServiceGK {
+		result = fmt.Sprintf("%v %v", b.GKNN.Kind, result)
+	}
+	if b.Port != nil {
+		result = fmt.Sprintf("%v:%d", result, *b.Port)
+	}
+	return result
+}
+
+// HTTPRouteRules returns the rules of the HTTPRoute, along with the Backends
+// which each rule forwards and mirrors requests to. The Node of the Backends is
+// not set, see httpRouteNode.Rules for that.
+func HTTPRouteRules(httpRoute *gatewayv1.HTTPRoute) []HTTPRouteRule {
+	var result []HTTPRouteRule
+	for i, rule := range httpRoute.Spec.Rules {
+		r := HTTPRouteRule{Index: i, Rule: rule}
+		for _, backendRef := range rule.BackendRefs {
+			weight := int32(1)
+			if backendRef.Weight != nil {
+				weight = *backendRef.Weight
+			}
+			r.Backends = append(r.Backends, RuleBackend{
+				GKNN:   backendRefToGKNN(httpRoute.GetNamespace(), backendRef.BackendObjectReference),
+				Port:   backendRef.Port,
+				Weight: weight,
+			})
+		}
+		if total := r.TotalWeight(); total != 0 {
+			for j := range r.Backends {
+				r.Backends[j].Percent = float64(r.Backends[j].Weight) * 100 / float64(total)
+			}
+		}
+
+		for _, filter := range rule.Filters {
+			if filter.Type != gatewayv1.HTTPRouteFilterRequestMirror || filter.RequestMirror == nil {
+				continue
+			}
+			r.Mirrors = append(r.Mirrors, RuleBackend{
+				GKNN:    backendRefToGKNN(httpRoute.GetNamespace(), filter.RequestMirror.BackendRef),
+				Port:    filter.RequestMirror.BackendRef.Port,
+				Percent: mirrorPercent(filter.RequestMirror),
+			})
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// mirrorPercent returns the percentage of requests which are mirrored by the
+// filter. All requests are mirrored if neither percent nor fraction are set.
+func mirrorPercent(mirror *gatewayv1.HTTPRequestMirrorFilter) float64 {
+	switch {
+	case mirror.Percent != nil:
+		return float64(*mirror.Percent)
+	case mirror.Fraction != nil:
+		denominator := int32(100)
+		if mirror.Fraction.Denominator != nil {
+			denominator = *mirror.Fraction.Denominator
+		}
+		if denominator == 0 {
+			return 0
+		}
+		return float64(mirror.Fraction.Numerator) * 100 / float64(denominator)
+	}
+	return 100
+}
+
+// FormatPercent formats a percentage with at most one decimal, like "33.3%".
+func FormatPercent(percent float64) string {
+	return strconv.FormatFloat(RoundPercent(percent), 'f', -1, 64) + "%"
+}
+
+// RoundPercent rounds a percentage to one decimal.
+func RoundPercent(percent float64) float64 {
+	return math.Round(percent*10) / 10
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+	"sigs.k8s.io/gwctl/pkg/topology"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+var (
+	// GatewayChildCertificateRefsRelation returns the Secrets referenced by
+	// the TLS configuration of the listeners of the Gateway.
+	GatewayChildCertificateRefsRelation = &topology.Relation{
+		From: common.GatewayGK,
+		To:   common.SecretGK,
+		Name: "CertificateRef",
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			gateway := &gatewayv1.Gateway{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), gateway); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured Gateway to structured: %v", err))
+			}
+			resultSet := make(map[common.GKNN]bool)
+			for _, listener := range gateway.Spec.Listeners {
+				if listener.TLS == nil {
+					continue
+				}
+				for _, certificateRef := range listener.TLS.CertificateRefs {
+					resultSet[CertificateRefToGKNN(gateway.GetNamespace(), certificateRef)] = true
+				}
+			}
+			return slices.Collect(maps.Keys(resultSet))
+		},
+	}
+
+	// BackendTLSPolicyTargetRefsRelation returns the Backends which the
+	// BackendTLSPolicy targets.
+	BackendTLSPolicyTargetRefsRelation = &topology.Relation{
+		From:     common.BackendTLSPolicyGK,
+		To:       common.ServiceGK,
+		Name:     "TargetRef",
+		ToParent: true,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			backendTLSPolicy := &gatewayv1.BackendTLSPolicy{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), backendTLSPolicy); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured BackendTLSPolicy to structured: %v", err))
+			}
+			resultSet := make(map[common.GKNN]bool)
+			for _, targetRef := range backendTLSPolicy.Spec.TargetRefs {
+				resultSet[common.GKNN{
+					Group:     string(targetRef.Group),
+					Kind:      string(targetRef.Kind),
+					Namespace: backendTLSPolicy.GetNamespace(),
+					Name:      string(targetRef.Name),
+				}] = true
+			}
+			return slices.Collect(maps.Keys(resultSet))
+		},
+	}
+
+	// BackendTLSPolicyChildConfigMapsRelation returns the ConfigMaps containing
+	// the CA certificates used by the BackendTLSPolicy.
+	BackendTLSPolicyChildConfigMapsRelation = backendTLSPolicyCACertificateRefsRelation(common.ConfigMapGK)
+	// BackendTLSPolicyChildSecretsRelation returns the Secrets containing the CA
+	// certificates used by the BackendTLSPolicy.
+	BackendTLSPolicyChildSecretsRelation = backendTLSPolicyCACertificateRefsRelation(common.SecretGK)
+)
+
+// backendTLSPolicyCACertificateRefsRelation returns a Relation from
+// BackendTLSPolicies to the objects of the given kind referenced through
+// caCertificateRefs. CA certificates can only be referenced within the
+// namespace of the BackendTLSPolicy.
+func backendTLSPolicyCACertificateRefsRelation(gk schema.GroupKind) *topology.Relation {
+	return &topology.Relation{
+		From: common.BackendTLSPolicyGK,
+		To:   gk,
+		Name: "CACertificateRef",
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			backendTLSPolicy := &gatewayv1.BackendTLSPolicy{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), backendTLSPolicy); err != nil {
+				panic(fmt.Sprintf("failed to convert unstructured BackendTLSPolicy to structured: %v", err))
+			}
+			var result []common.GKNN
+			for _, caCertificateRef := range backendTLSPolicy.Spec.Validation.CACertificateRefs {
+				if string(caCertificateRef.Group) != gk.Group || string(caCertificateRef.Kind) != gk.Kind {
+					continue
+				}
+				gknn := common.GKNN{
+					Group:     gk.Group,
+					Kind:      gk.Kind,
+					Namespace: backendTLSPolicy.GetNamespace(),
+					Name:      string(caCertificateRef.Name),
+				}
+				if !slices.Contains(result, gknn) {
+					result = append(result, gknn)
+				}
+			}
+			return result
+		},
+	}
+}
+
+// CertificateRefToGKNN returns the GKNN of the object referenced by the
+// certificateRef of a Gateway in the given namespace.
+func CertificateRefToGKNN(gatewayNamespace string, certificateRef gatewayv1.SecretObjectReference) common.GKNN {
+	result := common.GKNN{
+		Group:     common.SecretGK.Group,
+		Kind:      common.SecretGK.Kind,
+		Namespace: gatewayNamespace,
+		Name:      string(certificateRef.Name),
+	}
+	if certificateRef.Group != nil {
+		result.Group = string(*certificateRef.Group)
+	}
+	if certificateRef.Kind != nil {
+		result.Kind = string(*certificateRef.Kind)
+	}
+	if certificateRef.Namespace != nil {
+		result.Namespace = string(*certificateRef.Namespace)
+	}
+	return result
+}
+
+type backendTLSPolicyNode interface {
+	Backends() map[common.GKNN]*topology.Node
+	// CACertificates returns the ConfigMaps and Secrets containing the CA
+	// certificates used by the BackendTLSPolicy.
+	CACertificates() map[common.GKNN]*topology.Node
+}
+
+type backendTLSPolicyNodeImpl struct {
+	node *topology.Node
+}
+
+func BackendTLSPolicyNode(node *topology.Node) backendTLSPolicyNode { //nolint:revive
+	return &backendTLSPolicyNodeImpl{node: node}
+}
+
+func (n *backendTLSPolicyNodeImpl) Backends() map[common.GKNN]*topology.Node {
+	return n.node.OutNeighbors[BackendTLSPolicyTargetRefsRelation]
+}
+
+func (n *backendTLSPolicyNodeImpl) CACertificates() map[common.GKNN]*topology.Node {
+	result := make(map[common.GKNN]*topology.Node)
+	maps.Copy(result, n.node.OutNeighbors[BackendTLSPolicyChildConfigMapsRelation])
+	maps.Copy(result, n.node.OutNeighbors[BackendTLSPolicyChildSecretsRelation])
+	return result
+}
@@ -18,6 +18,7 @@ package topology
 
 import (
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -55,6 +56,8 @@ func (g *Graph) AddNode(node *Node) {
 	g.Nodes[node.GKNN().GroupKind()][node.GKNN().NamespacedName()] = node
 }
 
+// DeleteNode deletes the node from the Graph, along with all the edges to and
+// from the node.
 func (g *Graph) DeleteNode(node *Node) {
 	klog.V(3).InfoS("DeleteNode", "node", node.GKNN())
 	if g.Nodes == nil {
@@ -63,6 +66,16 @@ func (g *Graph) DeleteNode(node *Node) {
 	if g.Nodes[node.GKNN().GroupKind()] == nil {
 		return
 	}
+	for relation, neighbors := range node.OutNeighbors {
+		for _, neighbor := range neighbors {
+			g.RemoveEdge(node, neighbor, relation)
+		}
+	}
+	for relation, neighbors := range node.InNeighbors {
+		for _, neighbor := range neighbors {
+			g.RemoveEdge(neighbor, node, relation)
+		}
+	}
 	delete(g.Nodes[node.GKNN().GroupKind()], node.GKNN().NamespacedName())
 	if len(g.Nodes[node.GKNN().GroupKind()]) == 0 {
 		delete(g.Nodes, node.GKNN().GroupKind())
@@ -122,10 +135,7 @@ func (g *Graph) RemoveEdge(from *Node, to *Node, relation *Relation) {
 func (g *Graph) RemoveMetadata(category string) {
 	for gk := range g.Nodes {
 		for nn := range g.Nodes[gk] {
-			node := g.Nodes[gk][nn]
-			if node.Metadata != nil {
-				delete(node.Metadata, category)
-			}
+			g.Nodes[gk][nn].deleteMetadata(category)
 		}
 	}
 }
@@ -135,7 +145,10 @@ type Node struct {
 	InNeighbors  map[*Relation]map[common.GKNN]*Node
 	OutNeighbors map[*Relation]map[common.GKNN]*Node
 	Depth        int
-	Metadata     map[string]any
+	// Metadata holds the results of extensions, keyed by the extension name.
+	// Since extensions can execute concurrently, it should only be accessed
+	// through a MetadataKey while extensions are executing.
+	Metadata map[string]any
 }
 
 func (n *Node) GKNN() common.GKNN {
@@ -156,11 +169,32 @@ func MustAccessObject[T any](node *Node, concreteObj T) T {
 
 type NeighborFunc func(*unstructured.Unstructured) []common.GKNN
 
+// GraphNeighborFunc is similar to NeighborFunc, but additionally has access to
+// the Graph. It is used for relations whose neighbors cannot be determined
+// from the object alone, for example a Route attaching to Listeners which are
+// selected by port.
+type GraphNeighborFunc func(*unstructured.Unstructured, *Graph) []common.GKNN
+
 type Relation struct {
-	From         schema.GroupKind
-	To           schema.GroupKind
-	Name         string
+	From schema.GroupKind
+	To   schema.GroupKind
+	Name string
+	// ToParent is true if the relation leads up from an object to the objects
+	// it belongs to, like from an HTTPRoute to the Gateways it attaches to, or
+	// from a policy to its targets. Otherwise, the relation leads down to the
+	// objects used by the object, like from an HTTPRoute to its Backends.
+	ToParent     bool
 	NeighborFunc NeighborFunc
+	// GraphNeighborFunc is used instead of NeighborFunc, when set.
+	GraphNeighborFunc GraphNeighborFunc
+}
+
+// Neighbors returns the neighbors of the object for this relation.
+func (r *Relation) Neighbors(u *unstructured.Unstructured, graph *Graph) []common.GKNN {
+	if r.GraphNeighborFunc != nil {
+		return r.GraphNeighborFunc(u, graph)
+	}
+	return r.NeighborFunc(u)
 }
 
 type Builder struct {
@@ -168,6 +202,9 @@ type Builder struct {
 	Relations []*Relation
 	Fetcher   common.GroupKindFetcher
 	MaxDepth  int
+	// Namespaces are the namespaces of the objects which can be reached from
+	// the sources. If empty, objects in all namespaces are fetched.
+	Namespaces []string
 }
 
 func NewBuilder(fetcher common.GroupKindFetcher) *Builder {
@@ -197,6 +234,14 @@ func (b *Builder) WithMaxDepth(maxDepth int) *Builder {
 	return b
 }
 
+// WithinNamespaces restricts the fetched objects to the namespaces, along with
+// cluster scoped objects. It should only be used when the relations can not
+// lead from the sources to objects in other namespaces.
+func (b *Builder) WithinNamespaces(namespaces ...string) *Builder {
+	b.Namespaces = namespaces
+	return b
+}
+
 func (b *Builder) Build() (*Graph, error) {
 	graph := &Graph{
 		MaxDepth:  b.MaxDepth,
@@ -220,13 +265,15 @@ func (b *Builder) Build() (*Graph, error) {
 	// Fetch all relevant resources and add them to the graph as Nodes. At a
 	// later point, we will remove the resources which are not relevant.
 	const inf = 100000000
+	start := time.Now()
+	klog.V(3).InfoS("Fetching resources", "groupKinds", allGroupKinds)
+	resourcesByGK, err := b.fetch(allGroupKinds)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(2).InfoS("Fetched resources for graph", "groupKinds", len(allGroupKinds), "duration", time.Since(start))
 	for _, groupKind := range allGroupKinds {
-		klog.V(3).InfoS("Fetching resources", "groupKind", groupKind)
-		resources, err := b.Fetcher.Fetch(groupKind)
-		if err != nil {
-			return nil, err
-		}
-		for _, resource := range resources {
+		for _, resource := range resourcesByGK[groupKind] {
 			node := &Node{Object: resource, Depth: inf}
 			if !graph.HasNode(node.GKNN()) {
 				graph.AddNode(node)
@@ -237,7 +284,7 @@ func (b *Builder) Build() (*Graph, error) {
 	// Connect related resources.
 	for _, relation := range b.Relations {
 		for _, fromNode := range graph.Nodes[relation.From] {
-			for _, toNodeGKNN := range relation.NeighborFunc(fromNode.Object) {
+			for _, toNodeGKNN := range relation.Neighbors(fromNode.Object, graph) {
 				if _, ok := graph.Nodes[toNodeGKNN.GroupKind()]; !ok {
 					continue
 				}
@@ -327,8 +374,32 @@ func (b *Builder) Build() (*Graph, error) {
 	return graph, nil
 }
 
+// fetch returns the resources of the GroupKinds, within the namespaces of the
+// Builder if any.
+func (b *Builder) fetch(gks []schema.GroupKind) (map[schema.GroupKind][]*unstructured.Unstructured, error) {
+	if len(b.Namespaces) == 0 {
+		return common.FetchAll(b.Fetcher, gks)
+	}
+	result := make(map[schema.GroupKind][]*unstructured.Unstructured)
+	for _, gk := range gks {
+		// Cluster scoped resources are returned for every namespace, and are
+		// deduplicated when they are added to the graph.
+		for _, namespace := range b.Namespaces {
+			resources, err := common.FetchInNamespace(b.Fetcher, gk, namespace)
+			if err != nil {
+				return nil, err
+			}
+			result[gk] = append(result[gk], resources...)
+		}
+	}
+	return result, nil
+}
+
+// determineUniqueGroupKinds returns the GroupKinds which need to be fetched.
+// Since nodes can have a depth of MaxDepth+1, GroupKinds up to the same depth
+// are fetched, so that references from nodes <= MaxDepth can be validated.
 func (b *Builder) determineUniqueGroupKinds() []schema.GroupKind {
-	result := []schema.GroupKind{} // result is the set of unique GroupKinds having depth <= b.MaxDepth
+	result := []schema.GroupKind{} // result is the set of unique GroupKinds having depth <= b.MaxDepth+1
 
 	q := []schema.GroupKind{} // q is a Queue used in the BFS.
 	visited := map[schema.GroupKind]bool{}
@@ -365,7 +436,7 @@ func (b *Builder) determineUniqueGroupKinds() []schema.GroupKind {
 			visited[v] = true
 			depth[v] = depth[u] + 1
 			q = append(q, v)
-			if depth[v] <= b.MaxDepth {
+			if depth[v] <= b.MaxDepth+1 {
 				result = append(result, v)
 			} else {
 				return result
@@ -375,3 +446,135 @@ func (b *Builder) determineUniqueGroupKinds() []schema.GroupKind {
 
 	return result
 }
+
+// RelatedNodes returns all nodes in the graph which are related to the source
+// node. A node is related if it can be reached from the source by a path which
+// first goes up through zero or more relations, and then goes down through
+// zero or more relations. Going up means following a relation with ToParent
+// from its From to its To, or following a relation without ToParent in the
+// opposite direction.
+//
+// Relations with the same Name, like the ParentRef relations of all kinds of
+// routes, have the same meaning. A path never goes down through a relation
+// with the same meaning as one it went up through. This prevents walking
+// "back down" after having walked up; for example, going from an HTTPRoute to
+// its Gateway and then to every other route attached to that Gateway. The
+// source node itself is always included in the result.
+//
+// Similar to the Builder, the traversal does not expand from Namespaces, or
+// from GatewayClasses which are not the source. Listeners which are not the
+// source are not expanded either, since Routes are already related to their
+// Gateways directly.
+func (g *Graph) RelatedNodes(source *Node) map[common.GKNN]*Node {
+	result := map[common.GKNN]*Node{source.GKNN(): source}
+
+	// Each state of the traversal is a node along with the names of the
+	// relations used to go up on the way to it, and whether the path has
+	// started going down. A node only needs to be expanded again if it is
+	// reached in a state which is not dominated by a state it was already
+	// reached in, since fewer names used to go up and not having started to go
+	// down can only reach more nodes.
+	type state struct {
+		node *Node
+		up   relationSet
+		down bool
+	}
+	nameIndex := map[string]int{}
+	visited := map[*Node][]state{}
+	visit := func(s state) bool {
+		for _, other := range visited[s.node] {
+			if other.up.subsetOf(s.up) && (!other.down || s.down) {
+				return false
+			}
+		}
+		visited[s.node] = append(visited[s.node], s)
+		return true
+	}
+
+	queue := []state{{node: source}}
+	visit(queue[0])
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		u := s.node
+		result[u.GKNN()] = u
+
+		if u.GKNN().GroupKind() == common.NamespaceGK {
+			continue
+		}
+		if u.GKNN().GroupKind() == common.GatewayClassGK && u != source {
+			continue
+		}
+		if u.GKNN().GroupKind() == common.ListenerGK && u != source {
+			continue
+		}
+
+		for _, outgoing := range []bool{true, false} {
+			neighbors := u.InNeighbors
+			if outgoing {
+				neighbors = u.OutNeighbors
+			}
+			for relation, nodes := range neighbors {
+				i, ok := nameIndex[relation.Name]
+				if !ok {
+					i = len(nameIndex)
+					nameIndex[relation.Name] = i
+				}
+
+				var next state
+				if relation.ToParent == outgoing {
+					// Going up.
+					if s.down {
+						continue
+					}
+					next = state{up: s.up.with(i)}
+				} else {
+					// Going down.
+					if s.up.has(i) {
+						continue
+					}
+					next = state{up: s.up, down: true}
+				}
+				for _, v := range nodes {
+					next.node = v
+					if visit(next) {
+						queue = append(queue, next)
+					}
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// relationSet is a set of relation names, represented as a bitset of the
+// indices assigned to the names.
+type relationSet []uint64
+
+func (s relationSet) has(i int) bool {
+	return i/64 < len(s) && s[i/64]&(1<<(i%64)) != 0
+}
+
+// with returns a copy of the set with the name at index i added.
+func (s relationSet) with(i int) relationSet {
+	result := make(relationSet, max(len(s), i/64+1))
+	copy(result, s)
+	result[i/64] |= 1 << (i % 64)
+	return result
+}
+
+func (s relationSet) subsetOf(other relationSet) bool {
+	for i, word := range s {
+		if i >= len(other) {
+			if word != 0 {
+				return false
+			}
+			continue
+		}
+		if word&^other[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
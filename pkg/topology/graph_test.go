@@ -110,6 +110,40 @@ func TestGraph_AddEdge(t *testing.T) {
 	}
 }
 
+func TestGraph_DeleteNode(t *testing.T) {
+	graph := &Graph{}
+
+	gknn1 := common.GKNN{Group: "1", Kind: "2", Namespace: "3", Name: "4"}
+	node1 := &Node{Object: buildUnstructured(gknn1)}
+
+	gknn2 := common.GKNN{Group: "1", Kind: "2", Namespace: "3", Name: "5"}
+	node2 := &Node{Object: buildUnstructured(gknn2)}
+
+	gknn3 := common.GKNN{Group: "1", Kind: "8", Namespace: "3", Name: "4"}
+	node3 := &Node{Object: buildUnstructured(gknn3)}
+
+	childRelation := &Relation{Name: "child"}
+
+	graph.AddNode(node1)
+	graph.AddNode(node2)
+	graph.AddNode(node3)
+	graph.AddEdge(node1, node2, childRelation)
+	graph.AddEdge(node2, node3, childRelation)
+
+	graph.DeleteNode(node2)
+
+	if graph.HasNode(gknn2) {
+		t.Errorf("graph.HasNode(%v) = true after DeleteNode, want false", gknn2)
+	}
+	cmpopts := []cmp.Option{cmp.Transformer("NeighborsTransformer", NeighborsTransformer)}
+	if diff := cmp.Diff(&Node{Object: buildUnstructured(gknn1), OutNeighbors: map[*Relation]map[common.GKNN]*Node{}}, node1, cmpopts...); diff != "" {
+		t.Errorf("Unexpected diff in node1 after DeleteNode: (-want, +got)\n%v", diff)
+	}
+	if diff := cmp.Diff(&Node{Object: buildUnstructured(gknn3), InNeighbors: map[*Relation]map[common.GKNN]*Node{}}, node3, cmpopts...); diff != "" {
+		t.Errorf("Unexpected diff in node3 after DeleteNode: (-want, +got)\n%v", diff)
+	}
+}
+
 func NeighborsTransformer(neighbors map[*Relation]map[common.GKNN]*Node) map[*Relation]map[common.GKNN]bool {
 	result := make(map[*Relation]map[common.GKNN]bool)
 	for relation, nodeMap := range neighbors {
@@ -210,6 +244,248 @@ func TestBuilder(t *testing.T) {
 	}
 }
 
+func TestBuilder_WithinNamespaces(t *testing.T) {
+	gknn1 := common.GKNN{Group: "1", Kind: "1", Namespace: "ns-1", Name: "1"}
+	gknn2 := common.GKNN{Group: "2", Kind: "2", Namespace: "ns-1", Name: "2"}
+	gknn2Other := common.GKNN{Group: "2", Kind: "2", Namespace: "ns-2", Name: "2"}
+	gknn3 := common.GKNN{Group: "3", Kind: "3", Name: "3"} // Cluster scoped
+
+	relation2To1 := &Relation{
+		From: gknn2.GroupKind(),
+		To:   gknn1.GroupKind(),
+		Name: "gk2_to_gk1",
+		NeighborFunc: func(*unstructured.Unstructured) []common.GKNN {
+			return []common.GKNN{gknn1}
+		},
+	}
+	relation2To3 := &Relation{
+		From: gknn2.GroupKind(),
+		To:   gknn3.GroupKind(),
+		Name: "gk2_to_gk3",
+		NeighborFunc: func(*unstructured.Unstructured) []common.GKNN {
+			return []common.GKNN{gknn3}
+		},
+	}
+
+	u1 := buildUnstructured(gknn1)
+	u2 := buildUnstructured(gknn2)
+	u3 := buildUnstructured(gknn3)
+	fakeFetcher := &fakeNamespacedGroupKindFetcher{
+		fakeGroupKindFetcher: fakeGroupKindFetcher{
+			data: map[schema.GroupKind][]*unstructured.Unstructured{
+				gknn1.GroupKind(): {u1},
+				gknn2.GroupKind(): {u2, buildUnstructured(gknn2Other)},
+				gknn3.GroupKind(): {u3},
+			},
+		},
+	}
+
+	graph, err := NewBuilder(fakeFetcher).
+		StartFrom([]*unstructured.Unstructured{buildUnstructured(gknn1)}).
+		UseRelationship(relation2To1).
+		UseRelationship(relation2To3).
+		WithinNamespaces("ns-1").
+		Build()
+	if err != nil {
+		t.Fatalf("Builder...Build() failed with error %v; want no errors", err)
+	}
+
+	wantGraph := &Graph{}
+	node1 := &Node{Object: u1, Depth: 0}
+	node2 := &Node{Object: u2, Depth: 1}
+	node3 := &Node{Object: u3, Depth: 2}
+	wantGraph.AddNode(node1)
+	wantGraph.AddNode(node2)
+	wantGraph.AddNode(node3)
+	wantGraph.AddEdge(node2, node1, relation2To1)
+	wantGraph.AddEdge(node2, node3, relation2To3)
+
+	if diff := cmp.Diff(wantGraph.Nodes, graph.Nodes); diff != "" {
+		t.Fatalf("Builder...Build(): Unexpected diff in graph: (-want, +got)\n%v", diff)
+	}
+	if diff := cmp.Diff(map[string]bool{"ns-1": true}, fakeFetcher.namespaces); diff != "" {
+		t.Fatalf("Builder...Build(): Unexpected diff in fetched namespaces: (-want, +got)\n%v", diff)
+	}
+}
+
+func TestGraph_RelatedNodes(t *testing.T) {
+	graph := &Graph{}
+
+	parentGKNN := common.GKNN{Group: "1", Kind: "parent", Namespace: "ns", Name: "parent"}
+	child1GKNN := common.GKNN{Group: "1", Kind: "child", Namespace: "ns", Name: "child-1"}
+	child2GKNN := common.GKNN{Group: "1", Kind: "child", Namespace: "ns", Name: "child-2"}
+	leaf1GKNN := common.GKNN{Group: "1", Kind: "leaf", Namespace: "ns", Name: "leaf-1"}
+	leaf2GKNN := common.GKNN{Group: "1", Kind: "leaf", Namespace: "ns", Name: "leaf-2"}
+
+	parent := &Node{Object: buildUnstructured(parentGKNN)}
+	child1 := &Node{Object: buildUnstructured(child1GKNN)}
+	child2 := &Node{Object: buildUnstructured(child2GKNN)}
+	leaf1 := &Node{Object: buildUnstructured(leaf1GKNN)}
+	leaf2 := &Node{Object: buildUnstructured(leaf2GKNN)}
+	for _, node := range []*Node{parent, child1, child2, leaf1, leaf2} {
+		graph.AddNode(node)
+	}
+
+	childToParent := &Relation{Name: "child_to_parent", ToParent: true}
+	childToLeaf := &Relation{Name: "child_to_leaf"}
+	graph.AddEdge(child1, parent, childToParent)
+	graph.AddEdge(child2, parent, childToParent)
+	graph.AddEdge(child1, leaf1, childToLeaf)
+	graph.AddEdge(child2, leaf2, childToLeaf)
+
+	testCases := []struct {
+		name   string
+		source *Node
+		want   []common.GKNN
+	}{
+		{
+			name:   "from parent reaches all descendants",
+			source: parent,
+			want:   []common.GKNN{parentGKNN, child1GKNN, child2GKNN, leaf1GKNN, leaf2GKNN},
+		},
+		{
+			name:   "from child does not reach siblings through parent",
+			source: child1,
+			want:   []common.GKNN{child1GKNN, parentGKNN, leaf1GKNN},
+		},
+		{
+			name:   "from leaf reaches ancestors only",
+			source: leaf2,
+			want:   []common.GKNN{leaf2GKNN, child2GKNN, parentGKNN},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := map[common.GKNN]bool{}
+			for gknn := range graph.RelatedNodes(tc.source) {
+				got[gknn] = true
+			}
+			want := map[common.GKNN]bool{}
+			for _, gknn := range tc.want {
+				want[gknn] = true
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("RelatedNodes(%v): Unexpected diff (-want, +got)\n%v", tc.source.GKNN(), diff)
+			}
+		})
+	}
+}
+
+func TestGraph_RelatedNodes_MixedRouteKinds(t *testing.T) {
+	graph := &Graph{}
+
+	gatewayGKNN := common.GKNN{Group: common.GatewayGK.Group, Kind: common.GatewayGK.Kind, Namespace: "ns", Name: "gateway"}
+	h1GKNN := common.GKNN{Group: common.HTTPRouteGK.Group, Kind: common.HTTPRouteGK.Kind, Namespace: "ns", Name: "h1"}
+	h2GKNN := common.GKNN{Group: common.HTTPRouteGK.Group, Kind: common.HTTPRouteGK.Kind, Namespace: "ns", Name: "h2"}
+	g1GKNN := common.GKNN{Group: common.GRPCRouteGK.Group, Kind: common.GRPCRouteGK.Kind, Namespace: "ns", Name: "g1"}
+	svcH1GKNN := common.GKNN{Kind: common.ServiceGK.Kind, Namespace: "ns", Name: "svc-h1"}
+	svcH2GKNN := common.GKNN{Kind: common.ServiceGK.Kind, Namespace: "ns", Name: "svc-h2"}
+	svcG1GKNN := common.GKNN{Kind: common.ServiceGK.Kind, Namespace: "ns", Name: "svc-g1"}
+	grantGKNN := common.GKNN{Group: common.ReferenceGrantGK.Group, Kind: common.ReferenceGrantGK.Kind, Namespace: "ns", Name: "grant"}
+
+	nodes := map[common.GKNN]*Node{}
+	for _, gknn := range []common.GKNN{gatewayGKNN, h1GKNN, h2GKNN, g1GKNN, svcH1GKNN, svcH2GKNN, svcG1GKNN, grantGKNN} {
+		nodes[gknn] = &Node{Object: buildUnstructured(gknn)}
+		graph.AddNode(nodes[gknn])
+	}
+
+	// Each kind of route has its own relations, with the same names.
+	httpRouteParentRef := &Relation{From: common.HTTPRouteGK, To: common.GatewayGK, Name: "ParentRef", ToParent: true}
+	grpcRouteParentRef := &Relation{From: common.GRPCRouteGK, To: common.GatewayGK, Name: "ParentRef", ToParent: true}
+	httpRouteBackendRef := &Relation{From: common.HTTPRouteGK, To: common.ServiceGK, Name: "BackendRef"}
+	grpcRouteBackendRef := &Relation{From: common.GRPCRouteGK, To: common.ServiceGK, Name: "BackendRef"}
+	referenceGrantTo := &Relation{From: common.ReferenceGrantGK, To: common.ServiceGK, Name: "To", ToParent: true}
+	graph.AddEdge(nodes[h1GKNN], nodes[gatewayGKNN], httpRouteParentRef)
+	graph.AddEdge(nodes[h2GKNN], nodes[gatewayGKNN], httpRouteParentRef)
+	graph.AddEdge(nodes[g1GKNN], nodes[gatewayGKNN], grpcRouteParentRef)
+	graph.AddEdge(nodes[h1GKNN], nodes[svcH1GKNN], httpRouteBackendRef)
+	graph.AddEdge(nodes[h2GKNN], nodes[svcH2GKNN], httpRouteBackendRef)
+	graph.AddEdge(nodes[g1GKNN], nodes[svcG1GKNN], grpcRouteBackendRef)
+	graph.AddEdge(nodes[grantGKNN], nodes[svcH1GKNN], referenceGrantTo)
+	graph.AddEdge(nodes[grantGKNN], nodes[svcG1GKNN], referenceGrantTo)
+
+	testCases := []struct {
+		name   string
+		source common.GKNN
+		want   []common.GKNN
+	}{
+		{
+			name:   "from gateway reaches routes of all kinds",
+			source: gatewayGKNN,
+			want:   []common.GKNN{gatewayGKNN, h1GKNN, h2GKNN, g1GKNN, svcH1GKNN, svcH2GKNN, svcG1GKNN, grantGKNN},
+		},
+		{
+			name:   "from HTTPRoute does not reach routes of other kinds through gateway",
+			source: h1GKNN,
+			want:   []common.GKNN{h1GKNN, gatewayGKNN, svcH1GKNN, grantGKNN},
+		},
+		{
+			name:   "from Service does not reach routes of other kinds through gateway",
+			source: svcG1GKNN,
+			want:   []common.GKNN{svcG1GKNN, g1GKNN, gatewayGKNN, grantGKNN},
+		},
+		{
+			name:   "from ReferenceGrant reaches routes of all granted Services",
+			source: grantGKNN,
+			want:   []common.GKNN{grantGKNN, svcH1GKNN, svcG1GKNN, h1GKNN, g1GKNN, gatewayGKNN},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := map[common.GKNN]bool{}
+			for gknn := range graph.RelatedNodes(nodes[tc.source]) {
+				got[gknn] = true
+			}
+			want := map[common.GKNN]bool{}
+			for _, gknn := range tc.want {
+				want[gknn] = true
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("RelatedNodes(%v): Unexpected diff (-want, +got)\n%v", tc.source, diff)
+			}
+		})
+	}
+}
+
+func TestGraph_RelatedNodes_DenseGraph(t *testing.T) {
+	// Every node is connected to all the nodes after it, through a relation
+	// which depends only on the later node. Each node can be reached through
+	// exponentially many distinct sets of relations, all of which are
+	// supersets of the single relation which reaches it directly.
+	const numNodes = 24
+
+	graph := &Graph{}
+	var nodes []*Node
+	var want []common.GKNN
+	for i := 0; i < numNodes; i++ {
+		gknn := common.GKNN{Group: "1", Kind: "node", Namespace: "ns", Name: fmt.Sprintf("node-%d", i)}
+		node := &Node{Object: buildUnstructured(gknn)}
+		graph.AddNode(node)
+		nodes = append(nodes, node)
+		want = append(want, gknn)
+	}
+	for j := 1; j < numNodes; j++ {
+		relation := &Relation{Name: fmt.Sprintf("to_node_%d", j)}
+		for i := 0; i < j; i++ {
+			graph.AddEdge(nodes[i], nodes[j], relation)
+		}
+	}
+
+	got := map[common.GKNN]bool{}
+	for gknn := range graph.RelatedNodes(nodes[0]) {
+		got[gknn] = true
+	}
+	wantSet := map[common.GKNN]bool{}
+	for _, gknn := range want {
+		wantSet[gknn] = true
+	}
+	if diff := cmp.Diff(wantSet, got); diff != "" {
+		t.Fatalf("RelatedNodes(%v): Unexpected diff (-want, +got)\n%v", nodes[0].GKNN(), diff)
+	}
+}
+
 func buildUnstructured(gknn common.GKNN) *unstructured.Unstructured {
 	return &unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -233,3 +509,25 @@ type fakeGroupKindFetcher struct {
 func (f *fakeGroupKindFetcher) Fetch(gk schema.GroupKind) ([]*unstructured.Unstructured, error) {
 	return f.data[gk], nil
 }
+
+// fakeNamespacedGroupKindFetcher is a fakeGroupKindFetcher which can fetch
+// single namespaces, and records the namespaces which were fetched.
+type fakeNamespacedGroupKindFetcher struct {
+	fakeGroupKindFetcher
+	namespaces map[string]bool
+}
+
+func (f *fakeNamespacedGroupKindFetcher) FetchInNamespace(gk schema.GroupKind, namespace string) ([]*unstructured.Unstructured, error) {
+	if f.namespaces == nil {
+		f.namespaces = make(map[string]bool)
+	}
+	f.namespaces[namespace] = true
+
+	var result []*unstructured.Unstructured
+	for _, resource := range f.data[gk] {
+		if resource.GetNamespace() == namespace || resource.GetNamespace() == "" {
+			result = append(result, resource)
+		}
+	}
+	return result, nil
+}
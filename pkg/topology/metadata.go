@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MetadataKey identifies the metadata of type T which an extension stores in
+// the nodes of a graph.
+type MetadataKey[T any] struct {
+	name string
+}
+
+// NewMetadataKey returns a key for metadata stored under the name, which is
+// usually the name of the extension storing it.
+func NewMetadataKey[T any](name string) MetadataKey[T] {
+	return MetadataKey[T]{name: name}
+}
+
+func (k MetadataKey[T]) Name() string {
+	return k.name
+}
+
+// Get returns the metadata stored in the node, or the zero value of T if there
+// is none. An error is returned if the metadata is not of type T.
+func (k MetadataKey[T]) Get(node *Node) (T, error) {
+	var zero T
+	rawData, ok := node.loadMetadata(k.name)
+	if !ok || rawData == nil {
+		return zero, nil
+	}
+	data, ok := rawData.(T)
+	if !ok {
+		return zero, fmt.Errorf("unable to perform type assertion for %v in node %v", k.name, node.GKNN())
+	}
+	return data, nil
+}
+
+// Set stores the metadata in the node, replacing any existing metadata.
+func (k MetadataKey[T]) Set(node *Node, value T) {
+	node.storeMetadata(k.name, value)
+}
+
+// GetOrSet returns the metadata stored in the node. If there is none, the value
+// is stored and returned instead.
+func (k MetadataKey[T]) GetOrSet(node *Node, value T) (T, error) {
+	rawData := node.loadOrStoreMetadata(k.name, value)
+	data, ok := rawData.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("unable to perform type assertion for %v in node %v", k.name, node.GKNN())
+	}
+	return data, nil
+}
+
+// AnalysisResult is implemented by metadata which contains the issues an
+// extension found with a node. The errors of all AnalysisResults in a node are
+// reported when describing or analyzing the node.
+type AnalysisResult interface {
+	AnalysisErrors() []error
+}
+
+// AnalysisResults returns the AnalysisResults stored in the node, keyed by the
+// name they are stored under.
+func (n *Node) AnalysisResults() map[string]AnalysisResult {
+	metadataMu.RLock()
+	defer metadataMu.RUnlock()
+	result := make(map[string]AnalysisResult)
+	for name, value := range n.Metadata {
+		if analysisResult, ok := value.(AnalysisResult); ok {
+			result[name] = analysisResult
+		}
+	}
+	return result
+}
+
+// metadataMu guards the Metadata of all nodes. Accesses are short, so a single
+// lock is simpler than a lock per node, and keeps Node comparable in tests.
+var metadataMu sync.RWMutex
+
+func (n *Node) loadMetadata(name string) (any, bool) {
+	metadataMu.RLock()
+	defer metadataMu.RUnlock()
+	value, ok := n.Metadata[name]
+	return value, ok
+}
+
+func (n *Node) storeMetadata(name string, value any) {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	if n.Metadata == nil {
+		n.Metadata = make(map[string]any)
+	}
+	n.Metadata[name] = value
+}
+
+func (n *Node) loadOrStoreMetadata(name string, value any) any {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	if existing := n.Metadata[name]; existing != nil {
+		return existing
+	}
+	if n.Metadata == nil {
+		n.Metadata = make(map[string]any)
+	}
+	n.Metadata[name] = value
+	return value
+}
+
+func (n *Node) deleteMetadata(name string) {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	delete(n.Metadata, name)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type testAnalysisResult struct {
+	errs []error
+}
+
+func (r *testAnalysisResult) AnalysisErrors() []error { return r.errs }
+
+func TestMetadataKey(t *testing.T) {
+	node := &Node{Object: testObject("Foo", "ns", "foo")}
+	countKey := NewMetadataKey[*int]("count")
+
+	got, err := countKey.Get(node)
+	if err != nil || got != nil {
+		t.Errorf("Get() = (%v, %v), want (nil, nil)", got, err)
+	}
+
+	one, two := 1, 2
+	got, err = countKey.GetOrSet(node, &one)
+	if err != nil || got != &one {
+		t.Errorf("GetOrSet() = (%v, %v), want (%v, nil)", got, err, &one)
+	}
+	got, err = countKey.GetOrSet(node, &two)
+	if err != nil || got != &one {
+		t.Errorf("GetOrSet() = (%v, %v), want existing value (%v, nil)", got, err, &one)
+	}
+
+	countKey.Set(node, &two)
+	got, err = countKey.Get(node)
+	if err != nil || got != &two {
+		t.Errorf("Get() = (%v, %v), want (%v, nil)", got, err, &two)
+	}
+
+	// A key of a different type stored under the same name fails the type
+	// assertion.
+	if _, err := NewMetadataKey[string]("count").Get(node); err == nil {
+		t.Errorf("Get() with mismatched type succeeded, want error")
+	}
+
+	// Only metadata implementing AnalysisResult is returned.
+	analysisErr := errors.New("analysis error")
+	analysisKey := NewMetadataKey[*testAnalysisResult]("analysis")
+	analysisKey.Set(node, &testAnalysisResult{errs: []error{analysisErr}})
+	want := map[string]AnalysisResult{"analysis": &testAnalysisResult{errs: []error{analysisErr}}}
+	if diff := cmp.Diff(want, node.AnalysisResults(), cmp.AllowUnexported(testAnalysisResult{}), cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("AnalysisResults() returned unexpected diff (-want, +got):\n%v", diff)
+	}
+}
+
+func testObject(kind, namespace, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+// Registry contains the Relations which are used to build Graphs. Besides the
+// built-in Relations, additional ones can be registered for resources which
+// are not known to gwctl, like custom backends. Relations are expected to be
+// registered during initialization, before any Graph is built.
+type Registry struct {
+	relations []*Relation
+}
+
+func NewRegistry(relations ...*Relation) *Registry {
+	r := &Registry{}
+	r.Register(relations...)
+	return r
+}
+
+// Register adds the relations to the registry. Relations which are already
+// registered are ignored.
+func (r *Registry) Register(relations ...*Relation) {
+	for _, relation := range relations {
+		if !slices.Contains(r.relations, relation) {
+			r.relations = append(r.relations, relation)
+		}
+	}
+}
+
+// Relations returns all registered relations, in the order in which they were
+// registered.
+func (r *Registry) Relations() []*Relation {
+	return slices.Clone(r.relations)
+}
+
+// JSONPathRelationSpec describes a Relation whose neighbors are found by
+// evaluating JSONPath expressions against the From object.
+type JSONPathRelationSpec struct {
+	Name string
+	From schema.GroupKind
+	To   schema.GroupKind
+	// NamePath produces the names of the neighbors, like
+	// "{.spec.backendRefs[*].name}".
+	NamePath string
+	// NamespacePath optionally produces the namespaces of the neighbors, in the
+	// same order as NamePath. If empty, or if it produces no result for some
+	// name, the neighbor is assumed to be in the namespace of the From object.
+	NamespacePath string
+	// ClusterScoped is true if objects of the To kind are cluster scoped.
+	ClusterScoped bool
+	// ToParent is the ToParent of the Relation.
+	ToParent bool
+}
+
+// NewJSONPathRelation returns the Relation described by the spec.
+func NewJSONPathRelation(spec JSONPathRelationSpec) (*Relation, error) {
+	nameJSONPath, err := parseJSONPath(spec.NamePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name JSONPath %q for relation %v: %w", spec.NamePath, spec.Name, err)
+	}
+	var namespaceJSONPath *jsonpath.JSONPath
+	if spec.NamespacePath != "" {
+		namespaceJSONPath, err = parseJSONPath(spec.NamespacePath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace JSONPath %q for relation %v: %w", spec.NamespacePath, spec.Name, err)
+		}
+	}
+
+	return &Relation{
+		From:     spec.From,
+		To:       spec.To,
+		Name:     spec.Name,
+		ToParent: spec.ToParent,
+		NeighborFunc: func(u *unstructured.Unstructured) []common.GKNN {
+			names := evaluateJSONPath(nameJSONPath, u)
+			var namespaces []string
+			if namespaceJSONPath != nil {
+				namespaces = evaluateJSONPath(namespaceJSONPath, u)
+			}
+
+			var result []common.GKNN
+			for i, name := range names {
+				if name == "" {
+					continue
+				}
+				gknn := common.GKNN{Group: spec.To.Group, Kind: spec.To.Kind, Name: name}
+				if !spec.ClusterScoped && spec.To != common.NamespaceGK {
+					gknn.Namespace = u.GetNamespace()
+					if i < len(namespaces) && namespaces[i] != "" {
+						gknn.Namespace = namespaces[i]
+					}
+				}
+				result = append(result, gknn)
+			}
+			return result
+		},
+	}, nil
+}
+
+func parseJSONPath(path string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	j := jsonpath.New("").AllowMissingKeys(true)
+	if err := j.Parse(path); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// evaluateJSONPath returns the string values produced by the JSONPath
+// expression. Values which are not strings are ignored.
+func evaluateJSONPath(j *jsonpath.JSONPath, u *unstructured.Unstructured) []string {
+	results, err := j.FindResults(u.UnstructuredContent())
+	if err != nil {
+		return nil
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			if !value.IsValid() || !value.CanInterface() {
+				continue
+			}
+			if s, ok := value.Interface().(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
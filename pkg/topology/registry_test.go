@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+func TestRegistry(t *testing.T) {
+	relation1 := &Relation{Name: "1"}
+	relation2 := &Relation{Name: "2"}
+	relation3 := &Relation{Name: "3"}
+
+	registry := NewRegistry(relation1, relation2)
+	registry.Register(relation3, relation1)
+
+	got := registry.Relations()
+	want := []*Relation{relation1, relation2, relation3}
+	if len(got) != len(want) {
+		t.Fatalf("Relations() returned %v relations, want %v", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Relations()[%v] = %v, want %v", i, got[i].Name, want[i].Name)
+		}
+	}
+}
+
+func TestNewJSONPathRelation(t *testing.T) {
+	poolGK := schema.GroupKind{Group: "inference.networking.k8s.io", Kind: "InferencePool"}
+	pool := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "inference.networking.k8s.io/v1",
+		"kind":       "InferencePool",
+		"metadata": map[string]any{
+			"name":      "pool",
+			"namespace": "ns1",
+		},
+		"spec": map[string]any{
+			"endpointPickerRef": map[string]any{
+				"name": "epp",
+			},
+			"backends": []any{
+				map[string]any{"name": "svc-1"},
+				map[string]any{"name": "svc-2", "namespace": "ns2"},
+			},
+		},
+	}}
+
+	testCases := []struct {
+		name    string
+		spec    JSONPathRelationSpec
+		want    []common.GKNN
+		wantErr bool
+	}{
+		{
+			name: "single name in the same namespace",
+			spec: JSONPathRelationSpec{
+				Name:     "EndpointPicker",
+				From:     poolGK,
+				To:       common.ServiceGK,
+				NamePath: "{.spec.endpointPickerRef.name}",
+			},
+			want: []common.GKNN{
+				{Kind: "Service", Namespace: "ns1", Name: "epp"},
+			},
+		},
+		{
+			name: "multiple names with namespaces",
+			spec: JSONPathRelationSpec{
+				Name:          "Backends",
+				From:          poolGK,
+				To:            common.ServiceGK,
+				NamePath:      ".spec.backends[*].name",
+				NamespacePath: "{.spec.backends[*].namespace}",
+			},
+			// The namespace of the second backend is the first namespace
+			// result, since results are not aligned with missing fields.
+			want: []common.GKNN{
+				{Kind: "Service", Namespace: "ns2", Name: "svc-1"},
+				{Kind: "Service", Namespace: "ns1", Name: "svc-2"},
+			},
+		},
+		{
+			name: "cluster scoped target",
+			spec: JSONPathRelationSpec{
+				Name:          "Class",
+				From:          poolGK,
+				To:            common.GatewayClassGK,
+				NamePath:      "{.spec.endpointPickerRef.name}",
+				ClusterScoped: true,
+			},
+			want: []common.GKNN{
+				{Group: common.GatewayClassGK.Group, Kind: "GatewayClass", Name: "epp"},
+			},
+		},
+		{
+			name: "missing field",
+			spec: JSONPathRelationSpec{
+				Name:     "Missing",
+				From:     poolGK,
+				To:       common.ServiceGK,
+				NamePath: "{.spec.doesNotExist.name}",
+			},
+			want: nil,
+		},
+		{
+			name: "invalid JSONPath",
+			spec: JSONPathRelationSpec{
+				Name:     "Invalid",
+				From:     poolGK,
+				To:       common.ServiceGK,
+				NamePath: "{.spec[}",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			relation, err := NewJSONPathRelation(tc.spec)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewJSONPathRelation() err = %v, wantErr = %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got := relation.Neighbors(pool, nil)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected diff in neighbors: (-want, +got)\n%v", diff)
+			}
+		})
+	}
+}
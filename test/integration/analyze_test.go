@@ -0,0 +1,873 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	cmdanalyze "sigs.k8s.io/gwctl/cmd/analyze"
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+func TestAnalyze(t *testing.T) {
+	// Change the route to use an implicit parentRef, which makes it select the
+	// listener which only allows routes from the same namespace as well.
+	changesFile := filepath.Join(t.TempDir(), "changes.yaml")
+	mustWriteFile(t, changesFile, `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route-not-allowed
+  namespace: other
+spec:
+  parentRefs:
+  - name: shared-gateway
+    namespace: infra
+    sectionName: http-apps
+  - name: shared-gateway
+    namespace: infra
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route-hostname-mismatch
+  namespace: apps
+spec:
+  parentRefs:
+  - name: shared-gateway
+    namespace: infra
+    port: 8080
+  hostnames:
+  - "foo.apps.example.com"
+`)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		wantOut   string
+	}{
+		{
+			name:      "analyze -o yaml",
+			inputArgs: []string{"-f", changesFile, "-o", "yaml"},
+			wantOut: `
+created: []
+fixedIssues:
+- extension: routeattachmentvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-hostname-mismatch" is
+    not allowed to attach to listener "http-apps" of Gateway(.gateway.networking.k8s.io)
+    "infra/shared-gateway" since none of its hostnames match the listener hostname
+    "*.apps.example.com"
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-hostname-mismatch
+    namespace: apps
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-hostname-mismatch
+    namespace: apps
+  severity: error
+  type: RouteHostnamesNotMatching
+newIssues:
+- extension: routeattachmentvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "other/route-not-allowed" is not
+    allowed to attach to listener "http-same" of Gateway(.gateway.networking.k8s.io)
+    "infra/shared-gateway" since its namespace is not allowed by the listener (allowedRoutes.namespaces.from
+    is Same)
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  severity: error
+  type: RouteNamespaceNotAllowed
+unchangedIssues:
+- extension: routeattachmentvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "other/route-not-allowed" is not
+    allowed to attach to listener "grpc-only" of Gateway(.gateway.networking.k8s.io)
+    "infra/shared-gateway" since the listener does not allow the kind HTTPRoute
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  severity: error
+  type: RouteKindNotAllowed
+- extension: routeattachmentvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "other/route-not-allowed" is not
+    allowed to attach to listener "http-apps" of Gateway(.gateway.networking.k8s.io)
+    "infra/shared-gateway" since its namespace is not allowed by the listener (allowedRoutes.namespaces.from
+    is Selector)
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  severity: error
+  type: RouteNamespaceNotAllowed
+updated:
+- group: gateway.networking.k8s.io
+  kind: HTTPRoute
+  name: route-hostname-mismatch
+  namespace: apps
+- group: gateway.networking.k8s.io
+  kind: HTTPRoute
+  name: route-not-allowed
+  namespace: other
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory, err := common.NewLocalFactory([]string{"testdata/route-attachment.yaml"}, "default")
+			if err != nil {
+				t.Fatalf("Failed to create local factory: %v", err)
+			}
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdanalyze.NewCmd(factory, iostreams)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err = cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func TestAnalyzeRemovedListener(t *testing.T) {
+	// Remove the http-apps listener, which breaks the routes attached to it
+	// through their sectionName or port.
+	changesFile := filepath.Join(t.TempDir(), "changes.yaml")
+	mustWriteFile(t, changesFile, `
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: shared-gateway
+  namespace: infra
+spec:
+  gatewayClassName: foo-com-external-gateway-class
+  listeners:
+  - name: http-same
+    protocol: HTTP
+    port: 80
+  - name: grpc-only
+    protocol: HTTP
+    port: 9090
+    allowedRoutes:
+      namespaces:
+        from: All
+      kinds:
+      - kind: GRPCRoute
+`)
+
+	factory, err := common.NewLocalFactory([]string{"testdata/route-attachment.yaml"}, "default")
+	if err != nil {
+		t.Fatalf("Failed to create local factory: %v", err)
+	}
+
+	iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+	cmd := cmdanalyze.NewCmd(factory, iostreams)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"-f", changesFile, "-o", "yaml"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Logf("Failed to execute command: %v", err)
+		t.Logf("Debug: out=\n%v\n", out.String())
+		t.Logf("Debug: errOut=\n%v\n", errOut.String())
+		t.FailNow()
+	}
+
+	wantOut := `
+created: []
+fixedIssues:
+- extension: routeattachmentvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-hostname-mismatch" is
+    not allowed to attach to listener "http-apps" of Gateway(.gateway.networking.k8s.io)
+    "infra/shared-gateway" since none of its hostnames match the listener hostname
+    "*.apps.example.com"
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-hostname-mismatch
+    namespace: apps
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-hostname-mismatch
+    namespace: apps
+  severity: error
+  type: RouteHostnamesNotMatching
+- extension: routeattachmentvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "other/route-not-allowed" is not
+    allowed to attach to listener "http-apps" of Gateway(.gateway.networking.k8s.io)
+    "infra/shared-gateway" since its namespace is not allowed by the listener (allowedRoutes.namespaces.from
+    is Selector)
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  severity: error
+  type: RouteNamespaceNotAllowed
+newIssues:
+- extension: routeattachmentvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-allowed" is not allowed
+    to attach to listener "grpc-only" of Gateway(.gateway.networking.k8s.io) "infra/shared-gateway"
+    since the listener does not allow the kind HTTPRoute
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-allowed
+    namespace: apps
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-allowed
+    namespace: apps
+  severity: error
+  type: RouteKindNotAllowed
+- extension: routeattachmentvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-allowed" is not allowed
+    to attach to listener "http-same" of Gateway(.gateway.networking.k8s.io) "infra/shared-gateway"
+    since its namespace is not allowed by the listener (allowedRoutes.namespaces.from
+    is Same)
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-allowed
+    namespace: apps
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-allowed
+    namespace: apps
+  severity: error
+  type: RouteNamespaceNotAllowed
+- extension: parentrefvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-allowed" references Gateway(.gateway.networking.k8s.io)
+    "infra/shared-gateway" with sectionName "http-apps" which matches no listener
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-allowed
+    namespace: apps
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-allowed
+    namespace: apps
+  severity: error
+  type: ParentRefMatchesNoListener
+- extension: parentrefvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-hostname-mismatch" references
+    Gateway(.gateway.networking.k8s.io) "infra/shared-gateway" with port 8080 which
+    matches no listener
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-hostname-mismatch
+    namespace: apps
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-hostname-mismatch
+    namespace: apps
+  severity: error
+  type: ParentRefMatchesNoListener
+- extension: parentrefvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "other/route-not-allowed" references
+    Gateway(.gateway.networking.k8s.io) "infra/shared-gateway" with sectionName "http-apps"
+    which matches no listener
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  severity: error
+  type: ParentRefMatchesNoListener
+unchangedIssues:
+- extension: routeattachmentvalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "other/route-not-allowed" is not
+    allowed to attach to listener "grpc-only" of Gateway(.gateway.networking.k8s.io)
+    "infra/shared-gateway" since the listener does not allow the kind HTTPRoute
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  referredObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: shared-gateway
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-not-allowed
+    namespace: other
+  severity: error
+  type: RouteKindNotAllowed
+updated:
+- group: gateway.networking.k8s.io
+  kind: Gateway
+  name: shared-gateway
+  namespace: infra
+`
+	got := common.MultiLine(out.String())
+	want := common.MultiLine(strings.TrimPrefix(wantOut, "\n"))
+	if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+		t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+	}
+}
+
+func TestAnalyzeTLS(t *testing.T) {
+	// Remove the reference to the missing CA certificate from the
+	// BackendTLSPolicy.
+	changesFile := filepath.Join(t.TempDir(), "changes.yaml")
+	mustWriteFile(t, changesFile, `
+apiVersion: gateway.networking.k8s.io/v1
+kind: BackendTLSPolicy
+metadata:
+  name: svc-1-tls
+  namespace: infra
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: svc-1
+  validation:
+    caCertificateRefs:
+    - group: ""
+      kind: ConfigMap
+      name: ca-bundle
+    hostname: svc-1.example.com
+`)
+
+	factory, err := common.NewLocalFactory([]string{"testdata/tls.yaml"}, "default")
+	if err != nil {
+		t.Fatalf("Failed to create local factory: %v", err)
+	}
+
+	iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+	cmd := cmdanalyze.NewCmd(factory, iostreams)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"-f", changesFile, "-o", "yaml"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Logf("Failed to execute command: %v", err)
+		t.Logf("Debug: out=\n%v\n", out.String())
+		t.Logf("Debug: errOut=\n%v\n", errOut.String())
+		t.FailNow()
+	}
+
+	wantOut := `
+created: []
+fixedIssues:
+- extension: notfoundrefvalidator
+  message: BackendTLSPolicy(.gateway.networking.k8s.io) "infra/svc-1-tls" references
+    a non-existent ConfigMap "infra/missing-ca-bundle"
+  object:
+    group: gateway.networking.k8s.io
+    kind: BackendTLSPolicy
+    name: svc-1-tls
+    namespace: infra
+  referredObject:
+    kind: ConfigMap
+    name: missing-ca-bundle
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: BackendTLSPolicy
+    name: svc-1-tls
+    namespace: infra
+  severity: error
+  type: ReferenceToNonExistentResource
+newIssues: []
+unchangedIssues:
+- extension: refgrantvalidator
+  message: Gateway(.gateway.networking.k8s.io) "infra/tls-gateway" is not permitted
+    to reference Secret "certs/other-cert"
+  object:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: tls-gateway
+    namespace: infra
+  referredObject:
+    kind: Secret
+    name: other-cert
+    namespace: certs
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: tls-gateway
+    namespace: infra
+  severity: error
+  type: ReferenceNotPermitted
+- extension: notfoundrefvalidator
+  message: Gateway(.gateway.networking.k8s.io) "infra/tls-gateway" references a non-existent
+    Secret "infra/missing-cert"
+  object:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: tls-gateway
+    namespace: infra
+  referredObject:
+    kind: Secret
+    name: missing-cert
+    namespace: infra
+  referringObject:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: tls-gateway
+    namespace: infra
+  severity: error
+  type: ReferenceToNonExistentResource
+updated:
+- group: gateway.networking.k8s.io
+  kind: BackendTLSPolicy
+  name: svc-1-tls
+  namespace: infra
+`
+	got := common.MultiLine(out.String())
+	want := common.MultiLine(strings.TrimPrefix(wantOut, "\n"))
+
+	if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+		t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+	}
+}
+
+func TestAnalyzePolicyConflicts(t *testing.T) {
+	// Add a policy which conflicts with an existing policy targeting the same
+	// Service.
+	changesFile := filepath.Join(t.TempDir(), "changes.yaml")
+	mustWriteFile(t, changesFile, `
+apiVersion: foo.com/v1
+kind: HealthCheckPolicy
+metadata:
+  name: health-check-3
+  namespace: apps
+spec:
+  targetRef:
+    group: ""
+    kind: Service
+    name: svc-1
+  path: /ready
+`)
+
+	factory, err := common.NewLocalFactory([]string{"testdata/policyconflicts.yaml"}, "default")
+	if err != nil {
+		t.Fatalf("Failed to create local factory: %v", err)
+	}
+
+	iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+	cmd := cmdanalyze.NewCmd(factory, iostreams)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"-f", changesFile, "-o", "yaml"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Logf("Failed to execute command: %v", err)
+		t.Logf("Debug: out=\n%v\n", out.String())
+		t.Logf("Debug: errOut=\n%v\n", errOut.String())
+		t.FailNow()
+	}
+
+	wantOut := `
+created:
+- group: foo.com
+  kind: HealthCheckPolicy
+  name: health-check-3
+  namespace: apps
+fixedIssues: []
+newIssues:
+- extension: policyconflictvalidator
+  message: HealthCheckPolicy(.foo.com) "apps/health-check-1" conflicts with "apps/health-check-3"
+    on fields path; "apps/health-check-1" takes precedence since it is older
+  object:
+    kind: Service
+    name: svc-1
+    namespace: apps
+  severity: warning
+  type: PolicyConflict
+unchangedIssues:
+- extension: policyconflictvalidator
+  message: TimeoutPolicy(.foo.com) "apps/route-timeouts" conflicts with inherited
+    "infra/gateway-timeouts" on fields timeout.idle; "apps/route-timeouts" takes precedence
+    since it is more specific
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-1
+    namespace: apps
+  severity: info
+  type: PolicyConflict
+- extension: policyconflictvalidator
+  message: TimeoutPolicy(.foo.com) "apps/route-timeouts" conflicts with inherited
+    "infra/gateway-timeouts" on fields timeout.request; "infra/gateway-timeouts" takes
+    precedence since it overrides them
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-1
+    namespace: apps
+  severity: info
+  type: PolicyConflict
+- extension: policyconflictvalidator
+  message: HealthCheckPolicy(.foo.com) "apps/health-check-1" conflicts with "apps/health-check-2"
+    on fields interval; "apps/health-check-1" takes precedence since it is older
+  object:
+    kind: Service
+    name: svc-1
+    namespace: apps
+  severity: warning
+  type: PolicyConflict
+updated: []
+`
+	got := common.MultiLine(out.String())
+	want := common.MultiLine(strings.TrimPrefix(wantOut, "\n"))
+	if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+		t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+	}
+}
+
+func TestAnalyzeShadowedRules(t *testing.T) {
+	// Add an HTTPRoute with the same hostname and match as rule "api-v1" of the
+	// existing route-b, which takes precedence since it is older.
+	changesFile := filepath.Join(t.TempDir(), "changes.yaml")
+	mustWriteFile(t, changesFile, `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route-d
+  namespace: apps
+spec:
+  parentRefs:
+  - name: gateway-1
+    namespace: infra
+  hostnames:
+  - example.com
+  rules:
+  - matches:
+    - path:
+        type: PathPrefix
+        value: /api/v1
+    backendRefs:
+    - name: svc-1
+      port: 80
+`)
+
+	factory, err := common.NewLocalFactory([]string{"testdata/shadowedrules.yaml"}, "default")
+	if err != nil {
+		t.Fatalf("Failed to create local factory: %v", err)
+	}
+
+	iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+	cmd := cmdanalyze.NewCmd(factory, iostreams)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"-f", changesFile, "-o", "yaml"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Logf("Failed to execute command: %v", err)
+		t.Logf("Debug: out=\n%v\n", out.String())
+		t.Logf("Debug: errOut=\n%v\n", errOut.String())
+		t.FailNow()
+	}
+
+	wantOut := `
+created:
+- group: gateway.networking.k8s.io
+  kind: HTTPRoute
+  name: route-d
+  namespace: apps
+fixedIssues: []
+newIssues:
+- extension: shadowedrulevalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-d" rule "0" duplicates
+    rule "api-v1" of HTTPRoute(.gateway.networking.k8s.io) "apps/route-b" on listener
+    "http" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1"; rule "api-v1"
+    of "apps/route-b" takes precedence since it is older
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-b
+    namespace: apps
+  severity: warning
+  type: ShadowedRouteRule
+- extension: shadowedrulevalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-d" rule "0" duplicates
+    rule "api-v1" of HTTPRoute(.gateway.networking.k8s.io) "apps/route-b" on listener
+    "http" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1"; rule "api-v1"
+    of "apps/route-b" takes precedence since it is older
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-d
+    namespace: apps
+  severity: warning
+  type: ShadowedRouteRule
+unchangedIssues:
+- extension: shadowedrulevalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-b" rule "api" duplicates
+    rule "api" of HTTPRoute(.gateway.networking.k8s.io) "apps/route-a" on listener
+    "http" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1"; rule "api" of
+    "apps/route-a" takes precedence since it is older
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-a
+    namespace: apps
+  severity: warning
+  type: ShadowedRouteRule
+- extension: shadowedrulevalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-b" rule "static" is shadowed
+    by rule "all" of HTTPRoute(.gateway.networking.k8s.io) "apps/route-a" on listener
+    "http" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1", which takes precedence
+    for all the requests it matches
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-a
+    namespace: apps
+  severity: warning
+  type: ShadowedRouteRule
+- extension: shadowedrulevalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-b" rule "api" duplicates
+    rule "api" of HTTPRoute(.gateway.networking.k8s.io) "apps/route-a" on listener
+    "http" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1"; rule "api" of
+    "apps/route-a" takes precedence since it is older
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-b
+    namespace: apps
+  severity: warning
+  type: ShadowedRouteRule
+- extension: shadowedrulevalidator
+  message: HTTPRoute(.gateway.networking.k8s.io) "apps/route-b" rule "static" is shadowed
+    by rule "all" of HTTPRoute(.gateway.networking.k8s.io) "apps/route-a" on listener
+    "http" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1", which takes precedence
+    for all the requests it matches
+  object:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: route-b
+    namespace: apps
+  severity: warning
+  type: ShadowedRouteRule
+updated: []
+`
+	got := common.MultiLine(out.String())
+	want := common.MultiLine(strings.TrimPrefix(wantOut, "\n"))
+	if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+		t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+	}
+}
+
+func TestAnalyzeGatewayConflicts(t *testing.T) {
+	// Move gateway-2 to an address which is not requested by gateway-1, and add
+	// an HTTPS listener on the same port as its HTTP listener.
+	changesFile := filepath.Join(t.TempDir(), "changes.yaml")
+	mustWriteFile(t, changesFile, `
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: gateway-2
+  namespace: infra
+spec:
+  gatewayClassName: foo-com-external-gateway-class
+  addresses:
+  - value: 10.0.0.3
+  listeners:
+  - name: http
+    protocol: HTTP
+    port: 80
+  - name: https
+    protocol: HTTPS
+    port: 80
+`)
+
+	factory, err := common.NewLocalFactory([]string{"testdata/gatewayconflicts.yaml"}, "default")
+	if err != nil {
+		t.Fatalf("Failed to create local factory: %v", err)
+	}
+
+	iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+	cmd := cmdanalyze.NewCmd(factory, iostreams)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"-f", changesFile, "-o", "yaml"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Logf("Failed to execute command: %v", err)
+		t.Logf("Debug: out=\n%v\n", out.String())
+		t.Logf("Debug: errOut=\n%v\n", errOut.String())
+		t.FailNow()
+	}
+
+	wantOut := `
+created: []
+fixedIssues:
+- extension: gatewayconflictvalidator
+  message: Gateway(.gateway.networking.k8s.io) "infra/gateway-2" requests address
+    "10.0.0.2", which is also requested by Gateway(.gateway.networking.k8s.io) "infra/gateway-1"
+  object:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: gateway-2
+    namespace: infra
+  severity: error
+  type: GatewayAddressConflict
+newIssues:
+- extension: gatewayconflictvalidator
+  message: Listener "http" of Gateway(.gateway.networking.k8s.io) "infra/gateway-2"
+    is conflicted since its protocol HTTP conflicts with protocol HTTPS of listener
+    "https" on port 80
+  object:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: gateway-2
+    namespace: infra
+  severity: error
+  type: ListenerProtocolConflict
+- extension: gatewayconflictvalidator
+  message: Listener "https" of Gateway(.gateway.networking.k8s.io) "infra/gateway-2"
+    is conflicted since its protocol HTTPS conflicts with protocol HTTP of listener
+    "http" on port 80
+  object:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: gateway-2
+    namespace: infra
+  severity: error
+  type: ListenerProtocolConflict
+unchangedIssues: []
+updated:
+- group: gateway.networking.k8s.io
+  kind: Gateway
+  name: gateway-2
+  namespace: infra
+`
+	got := common.MultiLine(out.String())
+	want := common.MultiLine(strings.TrimPrefix(wantOut, "\n"))
+	if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+		t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+	}
+}
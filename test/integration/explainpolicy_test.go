@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	_ "embed"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	cmdexplainpolicy "sigs.k8s.io/gwctl/cmd/explainpolicy"
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+//go:embed testdata/explainpolicy.yaml
+var testdataExplainPolicy string
+
+func TestExplainPolicy(t *testing.T) {
+	factory := NewTestFactory(t, testdataExplainPolicy)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		wantOut   string
+	}{
+		{
+			name:      "explain-policy gateway/gateway-1 -n infra",
+			inputArgs: []string{"gateway/gateway-1"},
+			namespace: "infra",
+			wantOut: `
+Target: Gateway infra/gateway-1
+Gateway: infra/gateway-1
+  TimeoutPolicy.foo.com:
+    Chain:
+      Level    Target           Policy                  Default                     Override
+      -----    ------           ------                  -------                     --------
+      Gateway  infra/gateway-1  infra/gateway-timeouts  {"timeout":{"idle":"30s"}}  {"timeout":{"connect":"2s"}}
+    Fields:
+      Field            Value  Policy                  Level    From
+      -----            -----  ------                  -----    ----
+      timeout.connect  2s     infra/gateway-timeouts  Gateway  override
+      timeout.idle     30s    infra/gateway-timeouts  Gateway  default
+`,
+		},
+		{
+			name:      "explain-policy httproute/apps/route-1/rule-a",
+			inputArgs: []string{"httproute/apps/route-1/rule-a"},
+			namespace: "default",
+			wantOut: `
+Target: HTTPRoute apps/route-1 (rule rule-a)
+Gateway: infra/gateway-1
+  TimeoutPolicy.foo.com:
+    Chain:
+      Level      Target           Policy                  Default                                     Override
+      -----      ------           ------                  -------                                     --------
+      Gateway    infra/gateway-1  infra/gateway-timeouts  {"timeout":{"idle":"30s"}}                  {"timeout":{"connect":"2s"}}
+      HTTPRoute  apps/route-1     apps/route-timeouts     {"timeout":{"idle":"60s","request":"15s"}}  
+    Fields:
+      Field            Value  Policy                  Level      From
+      -----            -----  ------                  -----      ----
+      timeout.connect  2s     infra/gateway-timeouts  Gateway    override
+      timeout.idle     60s    apps/route-timeouts     HTTPRoute  default
+      timeout.request  15s    apps/route-timeouts     HTTPRoute  default
+`,
+		},
+		{
+			name:      "explain-policy service/apps/svc-1",
+			inputArgs: []string{"service/apps/svc-1"},
+			namespace: "default",
+			wantOut: `
+Target: Service apps/svc-1
+Gateway: infra/gateway-1
+  TimeoutPolicy.foo.com:
+    Chain:
+      Level      Target           Policy                  Default                                     Override
+      -----      ------           ------                  -------                                     --------
+      Gateway    infra/gateway-1  infra/gateway-timeouts  {"timeout":{"idle":"30s"}}                  {"timeout":{"connect":"2s"}}
+      HTTPRoute  apps/route-1     apps/route-timeouts     {"timeout":{"idle":"60s","request":"15s"}}  
+      HTTPRoute  apps/route-1     apps/rule-b-timeouts    {"timeout":{"request":"60s"}}               
+    Fields:
+      Field            Value  Policy                  Level      From
+      -----            -----  ------                  -----      ----
+      timeout.connect  2s     infra/gateway-timeouts  Gateway    override
+      timeout.idle     60s    apps/route-timeouts     HTTPRoute  default
+      timeout.request  15s    apps/route-timeouts     HTTPRoute  default
+`,
+		},
+		{
+			name:      "explain-policy httproute/apps/route-1/rule-b -o json",
+			inputArgs: []string{"httproute/apps/route-1/rule-b", "-o", "json"},
+			namespace: "default",
+			wantOut: `
+{
+  "target": {
+    "group": "gateway.networking.k8s.io",
+    "kind": "HTTPRoute",
+    "namespace": "apps",
+    "name": "route-1"
+  },
+  "rule": "rule-b",
+  "gateways": [
+    {
+      "gateway": {
+        "group": "gateway.networking.k8s.io",
+        "kind": "Gateway",
+        "namespace": "infra",
+        "name": "gateway-1"
+      },
+      "policies": [
+        {
+          "type": "TimeoutPolicy.foo.com",
+          "chain": [
+            {
+              "policy": {
+                "group": "foo.com",
+                "kind": "TimeoutPolicy",
+                "namespace": "infra",
+                "name": "gateway-timeouts"
+              },
+              "target": {
+                "group": "gateway.networking.k8s.io",
+                "kind": "Gateway",
+                "namespace": "infra",
+                "name": "gateway-1"
+              },
+              "default": {
+                "timeout": {
+                  "idle": "30s"
+                }
+              },
+              "override": {
+                "timeout": {
+                  "connect": "2s"
+                }
+              }
+            },
+            {
+              "policy": {
+                "group": "foo.com",
+                "kind": "TimeoutPolicy",
+                "namespace": "apps",
+                "name": "route-timeouts"
+              },
+              "target": {
+                "group": "gateway.networking.k8s.io",
+                "kind": "HTTPRoute",
+                "namespace": "apps",
+                "name": "route-1"
+              },
+              "default": {
+                "timeout": {
+                  "idle": "60s",
+                  "request": "15s"
+                }
+              }
+            },
+            {
+              "policy": {
+                "group": "foo.com",
+                "kind": "TimeoutPolicy",
+                "namespace": "apps",
+                "name": "rule-b-timeouts"
+              },
+              "target": {
+                "group": "gateway.networking.k8s.io",
+                "kind": "HTTPRoute",
+                "namespace": "apps",
+                "name": "route-1"
+              },
+              "default": {
+                "timeout": {
+                  "request": "60s"
+                }
+              }
+            }
+          ],
+          "spec": {
+            "timeout": {
+              "connect": "2s",
+              "idle": "60s",
+              "request": "60s"
+            }
+          },
+          "fields": [
+            {
+              "field": "timeout.connect",
+              "value": "2s",
+              "policy": {
+                "group": "foo.com",
+                "kind": "TimeoutPolicy",
+                "namespace": "infra",
+                "name": "gateway-timeouts"
+              },
+              "level": "Gateway",
+              "from": "override"
+            },
+            {
+              "field": "timeout.idle",
+              "value": "60s",
+              "policy": {
+                "group": "foo.com",
+                "kind": "TimeoutPolicy",
+                "namespace": "apps",
+                "name": "route-timeouts"
+              },
+              "level": "HTTPRoute",
+              "from": "default"
+            },
+            {
+              "field": "timeout.request",
+              "value": "60s",
+              "policy": {
+                "group": "foo.com",
+                "kind": "TimeoutPolicy",
+                "namespace": "apps",
+                "name": "rule-b-timeouts"
+              },
+              "level": "HTTPRoute",
+              "from": "default"
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdexplainpolicy.NewCmd(factory, iostreams)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
@@ -31,6 +31,24 @@ import (
 //go:embed testdata/sample1.yaml
 var testdataSample1 string
 
+//go:embed testdata/route-attachment.yaml
+var testdataRouteAttachment string
+
+//go:embed testdata/tls.yaml
+var testdataTLS string
+
+//go:embed testdata/referencegrants.yaml
+var testdataReferenceGrants string
+
+//go:embed testdata/policyconflicts.yaml
+var testdataPolicyConflicts string
+
+//go:embed testdata/shadowedrules.yaml
+var testdataShadowedRules string
+
+//go:embed testdata/gatewayconflicts.yaml
+var testdataGatewayConflicts string
+
 func TestGet(t *testing.T) {
 	factory := NewTestFactory(t, testdataSample1)
 
@@ -192,6 +210,11 @@ Backends:
   ----     ----
   Service  test/svc-1
   Service  test/svc-2
+RoutesByListener:
+  Listener  Port  Protocol  Kind       Name
+  --------  ----  --------  ----       ----
+  http      80    HTTP      HTTPRoute  test/httproute-1
+  http      80    HTTP      HTTPRoute  test/httproute-2
 DirectlyAttachedPolicies: <none>
 InheritedPolicies: <none>
 Events:
@@ -222,6 +245,10 @@ Backends:
   Kind     Name
   ----     ----
   Service  test/svc-2
+RoutesByListener:
+  Listener  Port  Protocol  Kind       Name
+  --------  ----  --------  ----       ----
+  https     443   HTTPS     HTTPRoute  test/httproute-2
 DirectlyAttachedPolicies: <none>
 InheritedPolicies: <none>
 Events: <none>
@@ -258,6 +285,11 @@ Backends:
   ----     ----
   Service  test/svc-1
   Service  test/svc-2
+RoutesByListener:
+  Listener  Port  Protocol  Kind       Name
+  --------  ----  --------  ----       ----
+  http      80    HTTP      HTTPRoute  test/httproute-1
+  http      80    HTTP      HTTPRoute  test/httproute-2
 DirectlyAttachedPolicies: <none>
 InheritedPolicies: <none>
 Events:
@@ -396,6 +428,331 @@ policy-1  BackendTLSPolicy.gateway.networking.k8s.io  Service/test/svc-1, Servic
 NAME       CLASS                           ADDRESSES  PORTS  PROGRAMMED  AGE
 gateway-1  foo-com-external-gateway-class             80     Unknown     <unknown>
 gateway-2  bar-com-internal-gateway-class             443    Unknown     <unknown>
+`,
+		},
+		{
+			name:      "get httproutes --for gateway/test/gateway-2 -A",
+			inputArgs: []string{"httproutes", "--for", "gateway/test/gateway-2", "-A"},
+			namespace: "",
+			wantOut: `
+NAMESPACE  NAME         HOSTNAMES                          PARENT REFS  ACCEPTED  RESOLVED  AGE
+test       httproute-2  example.com,example2.com + 1 more  2            Unknown   Unknown   <unknown>
+`,
+		},
+		{
+			name:      "get gateways --for service/test/svc-1 -A",
+			inputArgs: []string{"gateways", "--for", "service/test/svc-1", "-A"},
+			namespace: "",
+			wantOut: `
+NAMESPACE  NAME       CLASS                           ADDRESSES  PORTS  PROGRAMMED  AGE
+test       gateway-1  foo-com-external-gateway-class             80     Unknown     <unknown>
+`,
+		},
+		{
+			name:      "get services --for gatewayclass/foo-com-external-gateway-class -A",
+			inputArgs: []string{"services", "--for", "gatewayclass/foo-com-external-gateway-class", "-A"},
+			namespace: "",
+			wantOut: `
+NAMESPACE  NAME   TYPE     AGE
+default    svc-3  Service  <unknown>
+test       svc-1  Service  <unknown>
+test       svc-2  Service  <unknown>
+`,
+		},
+		{
+			name:      "get httproutes --for httproute/test/httproute-1 -A",
+			inputArgs: []string{"httproutes", "--for", "httproute/test/httproute-1", "-A"},
+			namespace: "",
+			wantOut: `
+NAMESPACE  NAME         HOSTNAMES  PARENT REFS  ACCEPTED  RESOLVED  AGE
+test       httproute-1  demo.com   1            Unknown   Unknown   <unknown>
+`,
+		},
+		{
+			name:      "get policies,policycrds --for gateway/default/gateway-3 -A",
+			inputArgs: []string{"policies,policycrds", "--for", "gateway/default/gateway-3", "-A"},
+			namespace: "",
+			wantOut: `
+NAMESPACE  NAME      KIND                                        TARGET(S)              POLICY TYPE  ACCEPTED  AGE
+default    policy-2  BackendTLSPolicy.gateway.networking.k8s.io  Service/default/svc-3  Direct       Partial   <unknown>
+
+NAME                                          POLICY TYPE  SCOPE       AGE
+backendtlspolicies.gateway.networking.k8s.io  Direct       Namespaced  <unknown>
+`,
+		},
+		{
+			name:      "get policycrds --for gatewayclass/bar-com-internal-gateway-class",
+			inputArgs: []string{"policycrds", "--for", "gatewayclass/bar-com-internal-gateway-class"},
+			namespace: "",
+			wantOut: `
+NAME                                          POLICY TYPE  SCOPE       AGE
+backendtlspolicies.gateway.networking.k8s.io  Direct       Namespaced  <unknown>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdget.NewCmd(factory, iostreams, tc.describe)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func TestGetL4Routes(t *testing.T) {
+	factory := NewTestFactory(t, testdataGraphL4Routes)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		describe  bool
+		wantOut   string
+	}{
+		{
+			name:      "describe gateways gateway-l4",
+			inputArgs: []string{"gateways", "gateway-l4"},
+			namespace: "default",
+			describe:  true,
+			wantOut: `
+Name: gateway-l4
+Namespace: default
+Labels: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: Gateway
+Metadata: {}
+Spec:
+  gatewayClassName: foo-com-external-gateway-class
+  listeners:
+  - hostname: secure.example.com
+    name: tls
+    port: 443
+    protocol: TLS
+    tls:
+      mode: Passthrough
+  - name: tcp
+    port: 9000
+    protocol: TCP
+  - name: udp
+    port: 5353
+    protocol: UDP
+Status: {}
+AttachedRoutes:
+  Kind      Name
+  ----      ----
+  TCPRoute  default/tcproute-1
+  TLSRoute  default/tlsroute-1
+  UDPRoute  default/udproute-1
+Backends:
+  Kind     Name
+  ----     ----
+  Service  backends/svc-tcp
+  Service  default/svc-tls
+  Service  backends/svc-udp
+RoutesByListener:
+  Listener  Port  Protocol  Kind      Name
+  --------  ----  --------  ----      ----
+  tls       443   TLS       TLSRoute  default/tlsroute-1
+  tcp       9000  TCP       TCPRoute  default/tcproute-1
+  udp       5353  UDP       UDPRoute  default/udproute-1
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+Events: <none>
+`,
+		},
+		{
+			name:      "get tlsroutes,tcproutes,udproutes -A",
+			inputArgs: []string{"tlsroutes,tcproutes,udproutes", "-A"},
+			wantOut: `
+NAMESPACE  NAME        PARENT REFS  ACCEPTED  RESOLVED  AGE
+default    tcproute-1  1            Unknown   Unknown   <unknown>
+
+NAMESPACE  NAME        HOSTNAMES           PARENT REFS  ACCEPTED  RESOLVED  AGE
+default    tlsroute-1  secure.example.com  1            Unknown   Unknown   <unknown>
+
+NAMESPACE  NAME        PARENT REFS  ACCEPTED  RESOLVED  AGE
+default    udproute-1  1            Unknown   Unknown   <unknown>
+`,
+		},
+		{
+			name:      "describe tcproutes tcproute-1",
+			inputArgs: []string{"tcproutes", "tcproute-1"},
+			namespace: "default",
+			describe:  true,
+			wantOut: `
+Name: tcproute-1
+Namespace: default
+Label: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: TCPRoute
+Metadata: {}
+Spec:
+  parentRefs:
+  - name: gateway-l4
+    sectionName: tcp
+  rules:
+  - backendRefs:
+    - name: svc-tcp
+      namespace: backends
+      port: 9000
+Status:
+  parents: null
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+EffectivePolicies:
+  Gateway.gateway.networking.k8s.io/default/gateway-l4: {}
+Analysis:
+- TCPRoute(.gateway.networking.k8s.io) "default/tcproute-1" is not permitted to reference
+  Service "backends/svc-tcp"
+Events: <none>
+`,
+		},
+		{
+			name:      "get services --for tcproute/default/tcproute-1 -A",
+			inputArgs: []string{"services", "--for", "tcproute/default/tcproute-1", "-A"},
+			wantOut: `
+NAMESPACE  NAME     TYPE     AGE
+backends   svc-tcp  Service  <unknown>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdget.NewCmd(factory, iostreams, tc.describe)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func TestGetRoutingTable(t *testing.T) {
+	factory := NewTestFactory(t, testdataTrace)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		describe  bool
+		wantOut   string
+	}{
+		{
+			name:      "describe gateways gateway-1 -n infra --routing-table",
+			inputArgs: []string{"gateways", "gateway-1", "--routing-table"},
+			namespace: "infra",
+			describe:  true,
+			wantOut: `
+Name: gateway-1
+Namespace: infra
+Labels: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: Gateway
+Metadata: {}
+Spec:
+  gatewayClassName: foo-com-external-gateway-class
+  listeners:
+  - allowedRoutes:
+      namespaces:
+        from: All
+    name: http
+    port: 80
+    protocol: HTTP
+  - allowedRoutes:
+      namespaces:
+        from: All
+    hostname: foo.example.com
+    name: http-foo
+    port: 80
+    protocol: HTTP
+Status: {}
+AttachedRoutes:
+  Kind       Name
+  ----       ----
+  HTTPRoute  apps/route-api
+  HTTPRoute  apps/route-catchall
+  HTTPRoute  apps/route-foo
+Backends:
+  Kind     Name
+  ----     ----
+  Service  apps/svc-api
+  Service  apps/svc-api-canary
+  Service  apps/svc-login
+  Service  apps/svc-default
+  Service  apps/svc-foo
+RoutesByListener:
+  Listener  Port  Protocol  Kind       Name
+  --------  ----  --------  ----       ----
+  http      80    HTTP      HTTPRoute  apps/route-api
+  http      80    HTTP      HTTPRoute  apps/route-catchall
+  http-foo  80    HTTP      HTTPRoute  apps/route-api
+  http-foo  80    HTTP      HTTPRoute  apps/route-foo
+RoutingTable:
+  Listener  Hostname         Order  Route                Rule    Match                                 Backends
+  --------  --------         -----  -----                ----    -----                                 --------
+  http      *.example.com    1      apps/route-api       login   Exact=/api/login Method=POST          apps/svc-login:8080
+  http      *.example.com    2      apps/route-api       canary  PathPrefix=/api Header[X-Env]=canary  apps/svc-api-canary:8080
+  http      *.example.com    3      apps/route-api       api     PathPrefix=/api                       apps/svc-api:8080 (90), apps/svc-api-canary:8080 (10)
+  http      *                1      apps/route-catchall  0       PathPrefix=/                          apps/svc-default:80
+  http-foo  foo.example.com  1      apps/route-api       login   Exact=/api/login Method=POST          apps/svc-login:8080
+  http-foo  foo.example.com  2      apps/route-api       canary  PathPrefix=/api Header[X-Env]=canary  apps/svc-api-canary:8080
+  http-foo  foo.example.com  3      apps/route-api       api     PathPrefix=/api                       apps/svc-api:8080 (90), apps/svc-api-canary:8080 (10)
+  http-foo  foo.example.com  4      apps/route-foo       0       PathPrefix=/api                       apps/svc-foo:80
+DirectlyAttachedPolicies:
+  Type                   Name
+  ----                   ----
+  TimeoutPolicy.foo.com  infra/gateway-timeouts
+InheritedPolicies: <none>
+EffectivePolicies:
+  TimeoutPolicy.foo.com:
+    timeout:
+      idle: 30s
+EffectivePolicySources:
+  Type                   Field         Value  Policy                  Level    From
+  ----                   -----         -----  ------                  -----    ----
+  TimeoutPolicy.foo.com  timeout.idle  30s    infra/gateway-timeouts  Gateway  default
+Events: <none>
 `,
 		},
 	}
@@ -427,3 +784,988 @@ gateway-2  bar-com-internal-gateway-class             443    Unknown     <unknow
 		})
 	}
 }
+
+func TestGetHTTPRouteRules(t *testing.T) {
+	factory := NewTestFactory(t, testdataGraphWeights)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		wantOut   string
+	}{
+		{
+			name:      "describe httproutes httproute-1",
+			inputArgs: []string{"httproutes", "httproute-1"},
+			namespace: "default",
+			wantOut: `
+Name: httproute-1
+Namespace: default
+Label: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: HTTPRoute
+Metadata: {}
+Spec:
+  parentRefs:
+  - name: gateway-1
+  rules:
+  - backendRefs:
+    - name: svc-stable
+      port: 80
+      weight: 90
+    - name: svc-canary
+      port: 80
+      weight: 10
+    filters:
+    - requestMirror:
+        backendRef:
+          name: svc-shadow
+          port: 80
+        percent: 25
+      type: RequestMirror
+    matches:
+    - method: GET
+      path:
+        type: PathPrefix
+        value: /api
+    - path:
+        type: Exact
+        value: /health
+    name: api
+  - backendRefs:
+    - name: svc-stable
+      port: 80
+      weight: 0
+    matches:
+    - path:
+        type: PathPrefix
+        value: /legacy
+    name: legacy
+  - backendRefs:
+    - name: svc-stable
+      port: 80
+Status:
+  parents: null
+Rules:
+  Rule    Matches                                    Filters        Backends
+  ----    -------                                    -------        --------
+  api     PathPrefix=/api Method=GET, Exact=/health  RequestMirror  default/svc-stable:80 (90%), default/svc-canary:80 (10%), default/svc-shadow:80 (mirror 25%)
+  legacy  PathPrefix=/legacy                         <none>         default/svc-stable:80 (0%)
+  2       PathPrefix=/                               <none>         default/svc-stable:80 (100%)
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+EffectivePolicies:
+  Gateway.gateway.networking.k8s.io/default/gateway-1: {}
+Analysis:
+- HTTPRoute(.gateway.networking.k8s.io) "default/httproute-1" rule "legacy" has a
+  total backend weight of 0, so requests matching it are rejected with a 500 status
+  code
+Events: <none>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdget.NewCmd(factory, iostreams, true)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func TestGetRouteAttachment(t *testing.T) {
+	factory := NewTestFactory(t, testdataRouteAttachment)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		wantOut   string
+	}{
+		{
+			name:      "describe httproutes route-allowed -n apps",
+			inputArgs: []string{"httproutes", "route-allowed"},
+			namespace: "apps",
+			wantOut: `
+Name: route-allowed
+Namespace: apps
+Label: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: HTTPRoute
+Metadata: {}
+Spec:
+  hostnames:
+  - foo.apps.example.com
+  parentRefs:
+  - name: shared-gateway
+    namespace: infra
+    sectionName: http-apps
+  - name: shared-gateway
+    namespace: infra
+Status:
+  parents: null
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+EffectivePolicies:
+  Gateway.gateway.networking.k8s.io/infra/shared-gateway: {}
+Events: <none>
+`,
+		},
+		{
+			name:      "describe httproutes route-not-allowed -n other",
+			inputArgs: []string{"httproutes", "route-not-allowed"},
+			namespace: "other",
+			wantOut: `
+Name: route-not-allowed
+Namespace: other
+Label: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: HTTPRoute
+Metadata: {}
+Spec:
+  parentRefs:
+  - name: shared-gateway
+    namespace: infra
+    sectionName: http-apps
+  - name: shared-gateway
+    namespace: infra
+    sectionName: grpc-only
+Status:
+  parents: null
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+EffectivePolicies:
+  Gateway.gateway.networking.k8s.io/infra/shared-gateway: {}
+Analysis:
+- HTTPRoute(.gateway.networking.k8s.io) "other/route-not-allowed" is not allowed to
+  attach to listener "http-apps" of Gateway(.gateway.networking.k8s.io) "infra/shared-gateway"
+  since its namespace is not allowed by the listener (allowedRoutes.namespaces.from
+  is Selector)
+- HTTPRoute(.gateway.networking.k8s.io) "other/route-not-allowed" is not allowed to
+  attach to listener "grpc-only" of Gateway(.gateway.networking.k8s.io) "infra/shared-gateway"
+  since the listener does not allow the kind HTTPRoute
+Events: <none>
+`,
+		},
+		{
+			name:      "describe httproutes route-hostname-mismatch -n apps",
+			inputArgs: []string{"httproutes", "route-hostname-mismatch"},
+			namespace: "apps",
+			wantOut: `
+Name: route-hostname-mismatch
+Namespace: apps
+Label: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: HTTPRoute
+Metadata: {}
+Spec:
+  hostnames:
+  - foo.example.com
+  parentRefs:
+  - name: shared-gateway
+    namespace: infra
+    port: 8080
+Status:
+  parents: null
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+EffectivePolicies:
+  Gateway.gateway.networking.k8s.io/infra/shared-gateway: {}
+Analysis:
+- HTTPRoute(.gateway.networking.k8s.io) "apps/route-hostname-mismatch" is not allowed
+  to attach to listener "http-apps" of Gateway(.gateway.networking.k8s.io) "infra/shared-gateway"
+  since none of its hostnames match the listener hostname "*.apps.example.com"
+Events: <none>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdget.NewCmd(factory, iostreams, true)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func TestGetTLS(t *testing.T) {
+	factory := NewTestFactory(t, testdataTLS)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		describe  bool
+		wantOut   string
+	}{
+		{
+			name:      "describe gateways tls-gateway -n infra",
+			inputArgs: []string{"gateways", "tls-gateway"},
+			namespace: "infra",
+			describe:  true,
+			wantOut: `
+Name: tls-gateway
+Namespace: infra
+Labels: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: Gateway
+Metadata: {}
+Spec:
+  gatewayClassName: foo-com-external-gateway-class
+  listeners:
+  - name: https
+    port: 443
+    protocol: HTTPS
+    tls:
+      certificateRefs:
+      - name: local-cert
+      - name: missing-cert
+      mode: Terminate
+  - name: https-shared
+    port: 8443
+    protocol: HTTPS
+    tls:
+      certificateRefs:
+      - name: shared-cert
+        namespace: shared-certs
+      mode: Terminate
+  - name: https-other
+    port: 9443
+    protocol: HTTPS
+    tls:
+      certificateRefs:
+      - name: other-cert
+        namespace: certs
+      mode: Terminate
+Status: {}
+AttachedRoutes:
+  Kind       Name
+  ----       ----
+  HTTPRoute  infra/route-1
+Backends:
+  Kind     Name
+  ----     ----
+  Service  infra/svc-1
+RoutesByListener:
+  Listener      Port  Protocol  Kind       Name
+  --------      ----  --------  ----       ----
+  https         443   HTTPS     HTTPRoute  infra/route-1
+  https-shared  8443  HTTPS     <none>     
+  https-other   9443  HTTPS     <none>     
+CertificateRefs:
+  Listener      Kind    Name                      Found
+  --------      ----    ----                      -----
+  https         Secret  infra/local-cert          true
+  https         Secret  infra/missing-cert        false
+  https-shared  Secret  shared-certs/shared-cert  true
+  https-other   Secret  certs/other-cert          true
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+Analysis:
+- Gateway(.gateway.networking.k8s.io) "infra/tls-gateway" references a non-existent
+  Secret "infra/missing-cert"
+- Gateway(.gateway.networking.k8s.io) "infra/tls-gateway" is not permitted to reference
+  Secret "certs/other-cert"
+Events: <none>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdget.NewCmd(factory, iostreams, tc.describe)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func TestGetReferenceGrants(t *testing.T) {
+	factory := NewTestFactory(t, testdataReferenceGrants)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		describe  bool
+		wantOut   string
+	}{
+		{
+			name:      "get referencegrants -n backends -o wide",
+			inputArgs: []string{"referencegrants", "-o", "wide"},
+			namespace: "backends",
+			wantOut: `
+NAME                       FROM                TO             AGE        REFERENCES
+allow-frontend-grpcroutes  GRPCRoute/frontend  Service/svc-2  <unknown>  0
+allow-frontend-routes      HTTPRoute/frontend  Service        <unknown>  1
+`,
+		},
+		{
+			name:      "describe referencegrants -n backends",
+			inputArgs: []string{"referencegrants"},
+			namespace: "backends",
+			describe:  true,
+			wantOut: `
+Name: allow-frontend-grpcroutes
+Namespace: backends
+Labels: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: ReferenceGrant
+Metadata: {}
+Spec:
+  from:
+  - group: gateway.networking.k8s.io
+    kind: GRPCRoute
+    namespace: frontend
+  to:
+  - group: ""
+    kind: Service
+    name: svc-2
+References: <none>
+Analysis:
+- ReferenceGrant "backends/allow-frontend-grpcroutes" does not permit any existing
+  reference
+Events: <none>
+
+
+Name: allow-frontend-routes
+Namespace: backends
+Labels: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: ReferenceGrant
+Metadata: {}
+Spec:
+  from:
+  - group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    namespace: frontend
+  to:
+  - group: ""
+    kind: Service
+References:
+  From Kind  From Name         To Kind  To Name
+  ---------  ---------         -------  -------
+  HTTPRoute  frontend/route-1  Service  backends/svc-1
+Analysis:
+- ReferenceGrant "backends/allow-frontend-routes" permits references to all Service
+  objects in namespace "backends", but only 1 of 3 are referenced; consider restricting
+  it by name
+Events: <none>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdget.NewCmd(factory, iostreams, tc.describe)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func TestGetPolicyConflicts(t *testing.T) {
+	factory := NewTestFactory(t, testdataPolicyConflicts)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		wantOut   string
+	}{
+		{
+			name:      "describe httproutes -n apps",
+			inputArgs: []string{"httproutes"},
+			namespace: "apps",
+			wantOut: `
+Name: route-1
+Namespace: apps
+Label: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: HTTPRoute
+Metadata: {}
+Spec:
+  parentRefs:
+  - name: gateway-1
+    namespace: infra
+  rules:
+  - backendRefs:
+    - name: svc-1
+      port: 80
+Status:
+  parents: null
+Rules:
+  Rule  Matches       Filters  Backends
+  ----  -------       -------  --------
+  0     PathPrefix=/  <none>   apps/svc-1:80 (100%)
+DirectlyAttachedPolicies:
+  Type                   Name
+  ----                   ----
+  TimeoutPolicy.foo.com  apps/route-timeouts
+InheritedPolicies:
+  Type                   Name                    Target(s)
+  ----                   ----                    ---------
+  TimeoutPolicy.foo.com  infra/gateway-timeouts  Gateway.gateway.networking.k8s.io/infra/gateway-1
+EffectivePolicies:
+  Gateway.gateway.networking.k8s.io/infra/gateway-1:
+    TimeoutPolicy.foo.com:
+      timeout:
+        idle: 60s
+        request: 10s
+EffectivePolicySources:
+  Gateway          Type                   Field            Value  Policy                  Level      From
+  -------          ----                   -----            -----  ------                  -----      ----
+  infra/gateway-1  TimeoutPolicy.foo.com  timeout.idle     60s    apps/route-timeouts     HTTPRoute  default
+  infra/gateway-1  TimeoutPolicy.foo.com  timeout.request  10s    infra/gateway-timeouts  Gateway    override
+Analysis:
+- TimeoutPolicy(.foo.com) "apps/route-timeouts" conflicts with inherited "infra/gateway-timeouts"
+  on fields timeout.request; "infra/gateway-timeouts" takes precedence since it overrides
+  them
+- TimeoutPolicy(.foo.com) "apps/route-timeouts" conflicts with inherited "infra/gateway-timeouts"
+  on fields timeout.idle; "apps/route-timeouts" takes precedence since it is more
+  specific
+Events: <none>
+`,
+		},
+		{
+			name:      "describe services -n apps",
+			inputArgs: []string{"services"},
+			namespace: "apps",
+			wantOut: `
+Name: svc-1
+Namespace: apps
+Labels: null
+Annotations: null
+Backend:
+  apiVersion: v1
+  kind: Service
+  metadata:
+    name: svc-1
+    namespace: apps
+  spec:
+    ports:
+    - port: 80
+ReferencedByRoutes:
+  Kind       Name
+  ----       ----
+  HTTPRoute  apps/route-1
+DirectlyAttachedPolicies:
+  Type                       Name
+  ----                       ----
+  HealthCheckPolicy.foo.com  apps/health-check-1
+  HealthCheckPolicy.foo.com  apps/health-check-2
+InheritedPolicies:
+  Type                   Name                    Target(s)
+  ----                   ----                    ---------
+  TimeoutPolicy.foo.com  apps/route-timeouts     HTTPRoute.gateway.networking.k8s.io/apps/route-1
+  TimeoutPolicy.foo.com  infra/gateway-timeouts  Gateway.gateway.networking.k8s.io/infra/gateway-1
+EffectivePolicies:
+  Gateway.gateway.networking.k8s.io/infra/gateway-1:
+    TimeoutPolicy.foo.com:
+      timeout:
+        idle: 60s
+        request: 10s
+EffectivePolicySources:
+  Gateway          Type                   Field            Value  Policy                  Level      From
+  -------          ----                   -----            -----  ------                  -----      ----
+  infra/gateway-1  TimeoutPolicy.foo.com  timeout.idle     60s    apps/route-timeouts     HTTPRoute  default
+  infra/gateway-1  TimeoutPolicy.foo.com  timeout.request  10s    infra/gateway-timeouts  Gateway    override
+Analysis:
+- HealthCheckPolicy(.foo.com) "apps/health-check-1" conflicts with "apps/health-check-2"
+  on fields interval; "apps/health-check-1" takes precedence since it is older
+Events: <none>
+`,
+		},
+		{
+			name:      "describe gateways -n infra -o json",
+			inputArgs: []string{"gateways", "-o", "json"},
+			namespace: "infra",
+			wantOut: `
+[
+    {
+        "Name": "gateway-1",
+        "Namespace": "infra",
+        "Labels": null,
+        "Annotations": null,
+        "APIVersion": "gateway.networking.k8s.io/v1",
+        "Kind": "Gateway",
+        "Metadata": {},
+        "Spec": {
+            "gatewayClassName": "foo-com-external-gateway-class",
+            "listeners": [
+                {
+                    "name": "http",
+                    "port": 80,
+                    "protocol": "HTTP",
+                    "allowedRoutes": {
+                        "namespaces": {
+                            "from": "All"
+                        }
+                    }
+                }
+            ]
+        },
+        "Status": {},
+        "AttachedRoutes": [
+            {
+                "Kind": "HTTPRoute",
+                "Name": "apps/route-1"
+            }
+        ],
+        "Backends": [
+            {
+                "Kind": "Service",
+                "Name": "apps/svc-1"
+            }
+        ],
+        "RoutesByListener": [
+            {
+                "Kind": "HTTPRoute",
+                "Listener": "http",
+                "Name": "apps/route-1",
+                "Port": "80",
+                "Protocol": "HTTP"
+            }
+        ],
+        "DirectlyAttachedPolicies": [
+            {
+                "Name": "infra/gateway-timeouts",
+                "Type": "TimeoutPolicy.foo.com"
+            }
+        ],
+        "InheritedPolicies": [],
+        "EffectivePolicies": {
+            "TimeoutPolicy.foo.com": {
+                "timeout": {
+                    "idle": "30s",
+                    "request": "10s"
+                }
+            }
+        },
+        "EffectivePolicySources": [
+            {
+                "Field": "timeout.idle",
+                "From": "default",
+                "Level": "Gateway",
+                "Policy": "infra/gateway-timeouts",
+                "Type": "TimeoutPolicy.foo.com",
+                "Value": "30s"
+            },
+            {
+                "Field": "timeout.request",
+                "From": "override",
+                "Level": "Gateway",
+                "Policy": "infra/gateway-timeouts",
+                "Type": "TimeoutPolicy.foo.com",
+                "Value": "10s"
+            }
+        ],
+        "Events": []
+    }
+]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdget.NewCmd(factory, iostreams, true)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func TestGetShadowedRules(t *testing.T) {
+	factory := NewTestFactory(t, testdataShadowedRules)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		wantOut   string
+	}{
+		{
+			name:      "describe httproutes route-b -n apps",
+			inputArgs: []string{"httproutes", "route-b"},
+			namespace: "apps",
+			wantOut: `
+Name: route-b
+Namespace: apps
+Label: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: HTTPRoute
+Metadata:
+  creationTimestamp: "2024-01-02T00:00:00Z"
+Spec:
+  hostnames:
+  - example.com
+  parentRefs:
+  - name: gateway-1
+    namespace: infra
+  rules:
+  - backendRefs:
+    - name: svc-2
+      port: 80
+    matches:
+    - path:
+        type: PathPrefix
+        value: /api/
+    name: api
+  - backendRefs:
+    - name: svc-2
+      port: 80
+    matches:
+    - path:
+        type: PathPrefix
+        value: /api/v1
+    name: api-v1
+  - backendRefs:
+    - name: svc-2
+      port: 80
+    matches:
+    - path:
+        type: RegularExpression
+        value: /static/.*
+    name: static
+Status:
+  parents: null
+Rules:
+  Rule    Matches                       Filters  Backends
+  ----    -------                       -------  --------
+  api     PathPrefix=/api/              <none>   apps/svc-2:80 (100%)
+  api-v1  PathPrefix=/api/v1            <none>   apps/svc-2:80 (100%)
+  static  RegularExpression=/static/.*  <none>   apps/svc-2:80 (100%)
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+EffectivePolicies:
+  Gateway.gateway.networking.k8s.io/infra/gateway-1: {}
+Analysis:
+- HTTPRoute(.gateway.networking.k8s.io) "apps/route-b" rule "api" duplicates rule
+  "api" of HTTPRoute(.gateway.networking.k8s.io) "apps/route-a" on listener "http"
+  of Gateway(.gateway.networking.k8s.io) "infra/gateway-1"; rule "api" of "apps/route-a"
+  takes precedence since it is older
+- HTTPRoute(.gateway.networking.k8s.io) "apps/route-b" rule "static" is shadowed by
+  rule "all" of HTTPRoute(.gateway.networking.k8s.io) "apps/route-a" on listener "http"
+  of Gateway(.gateway.networking.k8s.io) "infra/gateway-1", which takes precedence
+  for all the requests it matches
+Events: <none>
+`,
+		},
+		{
+			// route-c uses the same path as rule "api" of route-a, but for a
+			// different hostname, so it is not shadowed.
+			name:      "describe httproutes route-c -n apps",
+			inputArgs: []string{"httproutes", "route-c"},
+			namespace: "apps",
+			wantOut: `
+Name: route-c
+Namespace: apps
+Label: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: HTTPRoute
+Metadata:
+  creationTimestamp: "2024-01-03T00:00:00Z"
+Spec:
+  hostnames:
+  - other.example.com
+  parentRefs:
+  - name: gateway-1
+    namespace: infra
+  rules:
+  - backendRefs:
+    - name: svc-2
+      port: 80
+    matches:
+    - path:
+        type: PathPrefix
+        value: /api
+    name: api
+Status:
+  parents: null
+Rules:
+  Rule  Matches          Filters  Backends
+  ----  -------          -------  --------
+  api   PathPrefix=/api  <none>   apps/svc-2:80 (100%)
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+EffectivePolicies:
+  Gateway.gateway.networking.k8s.io/infra/gateway-1: {}
+Events: <none>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdget.NewCmd(factory, iostreams, true)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func TestGetGatewayConflicts(t *testing.T) {
+	factory := NewTestFactory(t, testdataGatewayConflicts)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		wantOut   string
+	}{
+		{
+			name:      "describe gateways gateway-1 -n infra",
+			inputArgs: []string{"gateways", "gateway-1"},
+			namespace: "infra",
+			wantOut: `
+Name: gateway-1
+Namespace: infra
+Labels: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: Gateway
+Metadata: {}
+Spec:
+  addresses:
+  - value: 10.0.0.1
+  - value: 10.0.0.2
+  gatewayClassName: foo-com-external-gateway-class
+  listeners:
+  - name: http
+    port: 80
+    protocol: HTTP
+  - hostname: foo.example.com
+    name: http-foo
+    port: 80
+    protocol: HTTP
+  - hostname: foo.example.com
+    name: http-foo-2
+    port: 80
+    protocol: HTTP
+  - hostname: foo.example.com
+    name: https
+    port: 443
+    protocol: HTTPS
+  - hostname: bar.example.com
+    name: tls
+    port: 443
+    protocol: TLS
+  - name: http-443
+    port: 443
+    protocol: HTTP
+  - name: dns-tcp
+    port: 53
+    protocol: TCP
+  - name: dns-udp
+    port: 53
+    protocol: UDP
+  - name: tcp
+    port: 8080
+    protocol: TCP
+  - name: tcp
+    port: 8081
+    protocol: TCP
+Status: {}
+AttachedRoutes: <none>
+Backends: <none>
+RoutesByListener:
+  Listener    Port  Protocol  Kind    Name
+  --------    ----  --------  ----    ----
+  http        80    HTTP      <none>  
+  http-foo    80    HTTP      <none>  
+  http-foo-2  80    HTTP      <none>  
+  https       443   HTTPS     <none>  
+  tls         443   TLS       <none>  
+  http-443    443   HTTP      <none>  
+  dns-tcp     53    TCP       <none>  
+  dns-udp     53    UDP       <none>  
+  tcp         8080  TCP       <none>  
+  tcp         8081  TCP       <none>  
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+ListenerConflicts:
+  Listener    Reason
+  --------    ------
+  http-foo    listener "http-foo-2" also uses port 80 with hostname "foo.example.com"
+  http-foo-2  listener "http-foo" also uses port 80 with hostname "foo.example.com"
+  https       its protocol HTTPS conflicts with protocol HTTP of listener "http-443" on port 443
+  tls         its protocol TLS conflicts with protocol HTTP of listener "http-443" on port 443
+  http-443    its protocol HTTP conflicts with protocol HTTPS of listener "https" on port 443
+  tcp         its name is used by 2 listeners
+Analysis:
+- Listener "tcp" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1" is conflicted
+  since its name is used by 2 listeners
+- Listener "http-foo" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1" is
+  conflicted since listener "http-foo-2" also uses port 80 with hostname "foo.example.com"
+- Listener "http-foo-2" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1" is
+  conflicted since listener "http-foo" also uses port 80 with hostname "foo.example.com"
+- Listener "https" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1" is conflicted
+  since its protocol HTTPS conflicts with protocol HTTP of listener "http-443" on
+  port 443
+- Listener "tls" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1" is conflicted
+  since its protocol TLS conflicts with protocol HTTP of listener "http-443" on port
+  443
+- Listener "http-443" of Gateway(.gateway.networking.k8s.io) "infra/gateway-1" is
+  conflicted since its protocol HTTP conflicts with protocol HTTPS of listener "https"
+  on port 443
+- Gateway(.gateway.networking.k8s.io) "infra/gateway-1" requests address "10.0.0.2",
+  which is also requested by Gateway(.gateway.networking.k8s.io) "infra/gateway-2"
+Events: <none>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdget.NewCmd(factory, iostreams, true)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
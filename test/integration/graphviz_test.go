@@ -24,6 +24,42 @@ var testdataGraphMultiNamespace string
 //go:embed testdata/graphviz/graph-multi-namespace.gv
 var testdataGraphMultiNamespaceDot string
 
+//go:embed testdata/graphviz/graph-grpcroute.yaml
+var testdataGraphGRPCRoute string
+
+//go:embed testdata/graphviz/graph-grpcroute.gv
+var testdataGraphGRPCRouteDot string
+
+//go:embed testdata/graphviz/graph-l4-routes.yaml
+var testdataGraphL4Routes string
+
+//go:embed testdata/graphviz/graph-l4-routes.gv
+var testdataGraphL4RoutesDot string
+
+//go:embed testdata/graphviz/graph-weights.yaml
+var testdataGraphWeights string
+
+//go:embed testdata/graphviz/graph-weights.gv
+var testdataGraphWeightsDot string
+
+//go:embed testdata/graphviz/graph-policies.yaml
+var testdataGraphPolicies string
+
+//go:embed testdata/graphviz/graph-policies.gv
+var testdataGraphPoliciesDot string
+
+//go:embed testdata/graphviz/graph-policies-inheritance.gv
+var testdataGraphPoliciesInheritanceDot string
+
+//go:embed testdata/graphviz/graph-policies.mmd
+var testdataGraphPoliciesMermaid string
+
+//go:embed testdata/graphviz/graph-policies.d2
+var testdataGraphPoliciesD2 string
+
+//go:embed testdata/graphviz/graph-policies.json
+var testdataGraphPoliciesJSON string
+
 func TestGraphviz(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -49,6 +85,70 @@ func TestGraphviz(t *testing.T) {
 			yaml:      testdataGraphMultiNamespace,
 			wantOut:   testdataGraphMultiNamespaceDot,
 		},
+		{
+			name:      "get gateways -o graph with grpcroutes",
+			inputArgs: []string{"gateways", "-o", "graph"},
+			namespace: "default",
+			describe:  false,
+			yaml:      testdataGraphGRPCRoute,
+			wantOut:   testdataGraphGRPCRouteDot,
+		},
+		{
+			name:      "get gateways -o graph with tlsroutes, tcproutes and udproutes",
+			inputArgs: []string{"gateways", "-o", "graph"},
+			namespace: "default",
+			describe:  false,
+			yaml:      testdataGraphL4Routes,
+			wantOut:   testdataGraphL4RoutesDot,
+		},
+		{
+			name:      "get gateways -o graph with weighted and mirrored backends",
+			inputArgs: []string{"gateways", "-o", "graph"},
+			namespace: "default",
+			describe:  false,
+			yaml:      testdataGraphWeights,
+			wantOut:   testdataGraphWeightsDot,
+		},
+		{
+			name:      "get gateways -o graph with policies",
+			inputArgs: []string{"gateways", "-o", "graph"},
+			namespace: "default",
+			describe:  false,
+			yaml:      testdataGraphPolicies,
+			wantOut:   testdataGraphPoliciesDot,
+		},
+		{
+			name:      "get gateways -o graph --show-policy-inheritance",
+			inputArgs: []string{"gateways", "-o", "graph", "--show-policy-inheritance"},
+			namespace: "default",
+			describe:  false,
+			yaml:      testdataGraphPolicies,
+			wantOut:   testdataGraphPoliciesInheritanceDot,
+		},
+		{
+			name:      "get gateways -o mermaid --show-policy-inheritance",
+			inputArgs: []string{"gateways", "-o", "mermaid", "--show-policy-inheritance"},
+			namespace: "default",
+			describe:  false,
+			yaml:      testdataGraphPolicies,
+			wantOut:   testdataGraphPoliciesMermaid,
+		},
+		{
+			name:      "get gateways -o d2 --show-policy-inheritance",
+			inputArgs: []string{"gateways", "-o", "d2", "--show-policy-inheritance"},
+			namespace: "default",
+			describe:  false,
+			yaml:      testdataGraphPolicies,
+			wantOut:   testdataGraphPoliciesD2,
+		},
+		{
+			name:      "get gateways -o graph-json --show-policy-inheritance",
+			inputArgs: []string{"gateways", "-o", "graph-json", "--show-policy-inheritance"},
+			namespace: "default",
+			describe:  false,
+			yaml:      testdataGraphPolicies,
+			wantOut:   testdataGraphPoliciesJSON,
+		},
 	}
 
 	for _, tc := range testCases {
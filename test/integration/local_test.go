@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	cmdget "sigs.k8s.io/gwctl/cmd/get"
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+func TestGetLocal(t *testing.T) {
+	// Manifests without namespaces, split across nested directories, like the
+	// output of a rendered Helm chart.
+	chartDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(chartDir, "gateway.yaml"), `
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: chart-gateway
+spec:
+  gatewayClassName: foo-com-external-gateway-class
+  listeners:
+  - name: http
+    protocol: HTTP
+    port: 80
+`)
+	mustWriteFile(t, filepath.Join(chartDir, "templates", "routes.yaml"), `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: chart-route
+spec:
+  parentRefs:
+  - name: chart-gateway
+  rules:
+  - backendRefs:
+    - name: chart-svc
+      port: 8080
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: chart-svc
+spec:
+  ports:
+  - port: 8080
+`)
+
+	testCases := []struct {
+		name      string
+		filenames []string
+		namespace string
+		inputArgs []string
+		describe  bool
+		wantOut   string
+	}{
+		{
+			name:      "get gateways -A",
+			filenames: []string{"testdata/sample1.yaml"},
+			namespace: "default",
+			inputArgs: []string{"gateways", "-A"},
+			wantOut: `
+NAMESPACE  NAME       CLASS                           ADDRESSES  PORTS  PROGRAMMED  AGE
+default    gateway-3  foo-com-external-gateway-class             80     Unknown     <unknown>
+test       gateway-1  foo-com-external-gateway-class             80     Unknown     <unknown>
+test       gateway-2  bar-com-internal-gateway-class             443    Unknown     <unknown>
+`,
+		},
+		{
+			name:      "get policycrds",
+			filenames: []string{"testdata/sample1.yaml"},
+			namespace: "default",
+			inputArgs: []string{"policycrds"},
+			wantOut: `
+NAME                                          POLICY TYPE  SCOPE       AGE
+backendtlspolicies.gateway.networking.k8s.io  Direct       Namespaced  <unknown>
+`,
+		},
+		{
+			name:      "describe httproutes chart-route",
+			filenames: []string{chartDir},
+			namespace: "chart",
+			inputArgs: []string{"httproutes", "chart-route"},
+			describe:  true,
+			wantOut: `
+Name: chart-route
+Namespace: chart
+Label: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: HTTPRoute
+Metadata: {}
+Spec:
+  parentRefs:
+  - name: chart-gateway
+  rules:
+  - backendRefs:
+    - name: chart-svc
+      port: 8080
+Status:
+  parents: null
+Rules:
+  Rule  Matches       Filters  Backends
+  ----  -------       -------  --------
+  0     PathPrefix=/  <none>   chart/chart-svc:8080 (100%)
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+EffectivePolicies:
+  Gateway.gateway.networking.k8s.io/chart/chart-gateway: {}
+Events: <none>
+`,
+		},
+		{
+			name:      "get httproutes without any manifests",
+			namespace: "default",
+			inputArgs: []string{"httproutes", "-A"},
+			wantOut:   ``,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory, err := common.NewLocalFactory(tc.filenames, tc.namespace)
+			if err != nil {
+				t.Fatalf("Failed to create local factory: %v", err)
+			}
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdget.NewCmd(factory, iostreams, tc.describe)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err = cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
+
+func mustWriteFile(t *testing.T, name, content string) {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(strings.TrimPrefix(content, "\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
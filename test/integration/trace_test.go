@@ -0,0 +1,263 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	_ "embed"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	cmdtrace "sigs.k8s.io/gwctl/cmd/trace"
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+//go:embed testdata/trace.yaml
+var testdataTrace string
+
+func TestTrace(t *testing.T) {
+	factory := NewTestFactory(t, testdataTrace)
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		wantOut   string
+	}{
+		{
+			name:      "trace gateway-1 -n infra --host bar.example.com --path /api/users",
+			inputArgs: []string{"gateway-1", "--host", "bar.example.com", "--path", "/api/users"},
+			namespace: "infra",
+			wantOut: `
+Gateway: infra/gateway-1
+Request:
+  host: bar.example.com
+  method: GET
+  path: /api/users
+
+Listener: http (port 80)
+Route: apps/route-api
+Rule: api
+Match: Host=*.example.com PathPrefix=/api
+Filters: <none>
+Backends:
+  Kind     Name                 Port  Weight  Share  Filters
+  ----     ----                 ----  ------  -----  -------
+  Service  apps/svc-api         8080  90      90%    
+  Service  apps/svc-api-canary  8080  10      10%    
+EffectivePolicies:
+  TimeoutPolicy.foo.com:
+    timeout:
+      idle: 30s
+Candidates:
+  Route                Rule  Match
+  -----                ----  -----
+  apps/route-api       api   Host=*.example.com PathPrefix=/api
+  apps/route-catchall  0     PathPrefix=/
+`,
+		},
+		{
+			name:      "trace gateway-1 -n infra with header and query params",
+			inputArgs: []string{"gateway-1", "--host", "bar.example.com:8080", "--path", "/api/users?x=1", "-H", "x-env: canary"},
+			namespace: "infra",
+			wantOut: `
+Gateway: infra/gateway-1
+Request:
+  headers:
+    X-Env: canary
+  host: bar.example.com
+  method: GET
+  path: /api/users
+  queryParams:
+    x: "1"
+
+Listener: http (port 80)
+Route: apps/route-api
+Rule: canary
+Match: Host=*.example.com PathPrefix=/api Header[X-Env]=canary
+Filters:
+  Type                   Config
+  ----                   ------
+  RequestHeaderModifier  {"set":[{"name":"X-Canary","value":"true"}]}
+Backends:
+  Kind     Name                 Port  Weight  Share  Filters
+  ----     ----                 ----  ------  -----  -------
+  Service  apps/svc-api-canary  8080  1       100%   
+EffectivePolicies:
+  TimeoutPolicy.foo.com:
+    timeout:
+      idle: 30s
+      request: 5s
+Candidates:
+  Route                Rule    Match
+  -----                ----    -----
+  apps/route-api       canary  Host=*.example.com PathPrefix=/api Header[X-Env]=canary
+  apps/route-api       api     Host=*.example.com PathPrefix=/api
+  apps/route-catchall  0       PathPrefix=/
+`,
+		},
+		{
+			name:      "trace gateway-1 -n infra --host foo.example.com --path /api",
+			inputArgs: []string{"gateway-1", "--host", "foo.example.com", "--path", "/api"},
+			namespace: "infra",
+			wantOut: `
+Gateway: infra/gateway-1
+Request:
+  host: foo.example.com
+  method: GET
+  path: /api
+
+Listener: http-foo (port 80)
+Route: apps/route-api
+Rule: api
+Match: Host=foo.example.com PathPrefix=/api
+Filters: <none>
+Backends:
+  Kind     Name                 Port  Weight  Share  Filters
+  ----     ----                 ----  ------  -----  -------
+  Service  apps/svc-api         8080  90      90%    
+  Service  apps/svc-api-canary  8080  10      10%    
+EffectivePolicies:
+  TimeoutPolicy.foo.com:
+    timeout:
+      idle: 30s
+Candidates:
+  Route           Rule  Match
+  -----           ----  -----
+  apps/route-api  api   Host=foo.example.com PathPrefix=/api
+  apps/route-foo  0     Host=foo.example.com PathPrefix=/api
+`,
+		},
+		{
+			name:      "trace gateway-1 -n infra --listener http-foo with no matching rule",
+			inputArgs: []string{"gateway-1", "--listener", "http-foo", "--host", "foo.example.com", "--path", "/other"},
+			namespace: "infra",
+			wantOut: `
+Gateway: infra/gateway-1
+Request:
+  host: foo.example.com
+  method: GET
+  path: /other
+
+Listener: http-foo (port 80)
+Route: <none>
+`,
+		},
+		{
+			name:      "trace gateway-1 -n infra --listener http --method POST -o json",
+			inputArgs: []string{"gateway-1", "--listener", "http", "--host", "foo.example.com", "--method", "POST", "--path", "/api/login", "-o", "json"},
+			namespace: "infra",
+			wantOut: `
+{
+  "gateway": {
+    "namespace": "infra",
+    "name": "gateway-1"
+  },
+  "request": {
+    "host": "foo.example.com",
+    "path": "/api/login",
+    "method": "POST"
+  },
+  "listeners": [
+    {
+      "listener": "http",
+      "port": 80,
+      "route": {
+        "namespace": "apps",
+        "name": "route-api"
+      },
+      "rule": "login",
+      "match": "Host=*.example.com Exact=/api/login Method=POST",
+      "backends": [
+        {
+          "kind": "Service",
+          "namespace": "apps",
+          "name": "svc-login",
+          "port": 8080,
+          "weight": 1,
+          "share": 100
+        }
+      ],
+      "effectivePolicies": {
+        "TimeoutPolicy.foo.com": {
+          "timeout": {
+            "idle": "30s"
+          }
+        }
+      },
+      "candidates": [
+        {
+          "route": {
+            "namespace": "apps",
+            "name": "route-api"
+          },
+          "rule": "login",
+          "match": "Host=*.example.com Exact=/api/login Method=POST"
+        },
+        {
+          "route": {
+            "namespace": "apps",
+            "name": "route-api"
+          },
+          "rule": "api",
+          "match": "Host=*.example.com PathPrefix=/api"
+        },
+        {
+          "route": {
+            "namespace": "apps",
+            "name": "route-catchall"
+          },
+          "rule": "0",
+          "match": "PathPrefix=/"
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.namespace = tc.namespace
+
+			iostreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := cmdtrace.NewCmd(factory, iostreams)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			err := cmd.Execute()
+			if err != nil {
+				t.Logf("Failed to execute command: %v", err)
+				t.Logf("Debug: out=\n%v\n", out.String())
+				t.Logf("Debug: errOut=\n%v\n", errOut.String())
+				t.FailNow()
+			}
+
+			got := common.MultiLine(out.String())
+			want := common.MultiLine(strings.TrimPrefix(tc.wantOut, "\n"))
+
+			if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+				t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+			}
+		})
+	}
+}
@@ -18,12 +18,16 @@ package integration
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"slices"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -32,6 +36,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery/cached/memory"
 	fakediscovery "k8s.io/client-go/discovery/fake"
@@ -48,8 +54,9 @@ import (
 
 type TestFactory struct {
 	namespace          string
-	unstructuredClient resource.RESTClient
+	unstructuredClient *fake.RESTClient
 	restMapper         meta.RESTMapper
+	watches            *fakeWatches
 }
 
 func NewTestFactory(t *testing.T, yamls ...string) *TestFactory {
@@ -67,18 +74,59 @@ func NewTestFactory(t *testing.T, yamls ...string) *TestFactory {
 	}
 
 	restMapper := mustRestMapper(t, infos)
+	watches := &fakeWatches{writers: make(map[string][]*io.PipeWriter)}
 
 	f := &TestFactory{
-		unstructuredClient: mustFakeRestClient(t, infos, restMapper),
+		unstructuredClient: mustFakeRestClient(t, infos, restMapper, watches),
 		restMapper:         restMapper,
+		watches:            watches,
 	}
 	return f
 }
 
+// SendWatchEvent sends an event for the object to all watches of the resource
+// path (for example "/services"). It waits for the resource to be watched.
+func (f *TestFactory) SendWatchEvent(t *testing.T, path string, eventType watch.EventType, obj *unstructured.Unstructured) {
+	objJSON, err := obj.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := json.Marshal(&metav1.WatchEvent{Type: string(eventType), Object: runtime.RawExtension{Raw: objJSON}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var writers []*io.PipeWriter
+	err = wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 10*time.Second, true, func(context.Context) (bool, error) {
+		f.watches.mu.Lock()
+		defer f.watches.mu.Unlock()
+		writers = f.watches.writers[path]
+		return len(writers) != 0, nil
+	})
+	if err != nil {
+		t.Fatalf("Resource %v is not being watched: %v", path, err)
+	}
+	for _, w := range writers {
+		if _, err := w.Write(append(event, '\n')); err != nil {
+			t.Logf("Failed to send watch event to %v: %v", path, err)
+		}
+	}
+}
+
+// fakeWatches contains the streams of the watches made to the fake server,
+// keyed by the path of the watched resource.
+type fakeWatches struct {
+	mu      sync.Mutex
+	writers map[string][]*io.PipeWriter
+}
+
 func (f *TestFactory) NewBuilder() *resource.Builder {
 	return resource.NewFakeBuilder(
 		func(_ schema.GroupVersion) (resource.RESTClient, error) {
-			return f.unstructuredClient, nil
+			// The fake client records the last request, so each builder gets
+			// its own copy to allow concurrent requests.
+			client := *f.unstructuredClient
+			return &client, nil
 		},
 		func() (meta.RESTMapper, error) {
 			return f.restMapper, nil
@@ -93,6 +141,10 @@ func (f *TestFactory) KubeConfigNamespace() (string, bool, error) {
 	return f.namespace, false, nil
 }
 
+func (f *TestFactory) ToRESTMapper() (meta.RESTMapper, error) {
+	return f.restMapper, nil
+}
+
 // mustRestMapper maintains a set of all resources recognized by the fake server.
 func mustRestMapper(t *testing.T, infos []*resource.Info) meta.RESTMapper {
 	resourceList := []*metav1.APIResourceList{
@@ -102,6 +154,10 @@ func mustRestMapper(t *testing.T, infos []*resource.Info) meta.RESTMapper {
 				{Name: "gatewayclasses", Namespaced: false, Kind: common.GatewayClassGK.Kind},
 				{Name: "gateways", Namespaced: true, Kind: common.GatewayGK.Kind},
 				{Name: "httproutes", Namespaced: true, Kind: common.HTTPRouteGK.Kind},
+				{Name: "grpcroutes", Namespaced: true, Kind: common.GRPCRouteGK.Kind},
+				{Name: "tlsroutes", Namespaced: true, Kind: common.TLSRouteGK.Kind},
+				{Name: "tcproutes", Namespaced: true, Kind: common.TCPRouteGK.Kind},
+				{Name: "udproutes", Namespaced: true, Kind: common.UDPRouteGK.Kind},
 				{Name: "referencegrants", Namespaced: true, Kind: common.ReferenceGrantGK.Kind},
 			},
 		},
@@ -111,6 +167,7 @@ func mustRestMapper(t *testing.T, infos []*resource.Info) meta.RESTMapper {
 				{Name: "pods", Namespaced: true, Kind: "Pod"},
 				{Name: "services", Namespaced: true, Kind: "Service"},
 				{Name: "secrets", Namespaced: true, Kind: "Secret"},
+				{Name: "configmaps", Namespaced: true, Kind: "ConfigMap"},
 				{Name: "namespaces", Namespaced: false, Kind: "Namespace"},
 				{Name: "events", Namespaced: true, Kind: "Event"},
 			},
@@ -177,7 +234,7 @@ func mustRestMapper(t *testing.T, infos []*resource.Info) meta.RESTMapper {
 	return restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscoveryClient)
 }
 
-func mustFakeRestClient(t *testing.T, infos []*resource.Info, restMapper meta.RESTMapper) *fake.RESTClient {
+func mustFakeRestClient(t *testing.T, infos []*resource.Info, restMapper meta.RESTMapper, watches *fakeWatches) *fake.RESTClient {
 	resourcesByPath := loadResourcesByPath(t, infos, restMapper)
 
 	codec := unstructured.NewJSONFallbackEncoder(scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...))
@@ -193,6 +250,18 @@ func mustFakeRestClient(t *testing.T, infos []*resource.Info, restMapper meta.RE
 			return nil, nil
 		}
 
+		if req.URL.Query().Get("watch") == "true" {
+			pr, pw := io.Pipe()
+			watches.mu.Lock()
+			watches.writers[path] = append(watches.writers[path], pw)
+			watches.mu.Unlock()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     cmdtesting.DefaultHeader(),
+				Body:       pr,
+			}, nil
+		}
+
 		responseBody := resourcesByPath[pathAndQuery]
 		if responseBody == nil {
 			t.Logf("No resources found, request url: %+v, and request: %+v", req.URL, req)
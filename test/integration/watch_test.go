@@ -0,0 +1,512 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/yaml"
+
+	cmdget "sigs.k8s.io/gwctl/cmd/get"
+	"sigs.k8s.io/gwctl/pkg/common"
+)
+
+func TestWatch(t *testing.T) {
+	service1 := mustUnstructured(t, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc-1
+  namespace: backends
+spec:
+  ports:
+  - port: 80
+`)
+	route2 := mustUnstructured(t, `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route-2
+  namespace: frontend
+spec:
+  parentRefs:
+  - name: gateway-1
+`)
+	route3 := mustUnstructured(t, `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route-3
+  namespace: frontend
+spec:
+  parentRefs:
+  - name: gateway-1
+  rules:
+  - backendRefs:
+    - name: svc-2
+      namespace: backends
+      port: 80
+`)
+
+	type watchStep struct {
+		path      string
+		eventType watch.EventType
+		object    *unstructured.Unstructured
+		// wantOut is the output expected after the event.
+		wantOut string
+	}
+
+	testCases := []struct {
+		name      string
+		inputArgs []string
+		namespace string
+		describe  bool
+		wantOut   string
+		steps     []watchStep
+	}{
+		{
+			name:      "get httproutes -n frontend -w -o json",
+			inputArgs: []string{"httproutes", "-w", "-o", "json"},
+			namespace: "frontend",
+			wantOut: `
+{
+    "type": "ADDED",
+    "object": "HTTPRoute.gateway.networking.k8s.io/frontend/route-1",
+    "state": {
+        "object": {
+            "apiVersion": "gateway.networking.k8s.io/v1",
+            "kind": "HTTPRoute",
+            "metadata": {
+                "name": "route-1",
+                "namespace": "frontend"
+            },
+            "spec": {
+                "parentRefs": [
+                    {
+                        "name": "gateway-1"
+                    }
+                ],
+                "rules": [
+                    {
+                        "backendRefs": [
+                            {
+                                "name": "svc-1",
+                                "namespace": "backends",
+                                "port": 80
+                            }
+                        ]
+                    }
+                ]
+            }
+        }
+    }
+}
+`,
+			steps: []watchStep{
+				{
+					path:      "/services",
+					eventType: watch.Deleted,
+					object:    service1,
+					wantOut: `
+{
+    "type": "ADDED",
+    "object": "HTTPRoute.gateway.networking.k8s.io/frontend/route-1",
+    "state": {
+        "object": {
+            "apiVersion": "gateway.networking.k8s.io/v1",
+            "kind": "HTTPRoute",
+            "metadata": {
+                "name": "route-1",
+                "namespace": "frontend"
+            },
+            "spec": {
+                "parentRefs": [
+                    {
+                        "name": "gateway-1"
+                    }
+                ],
+                "rules": [
+                    {
+                        "backendRefs": [
+                            {
+                                "name": "svc-1",
+                                "namespace": "backends",
+                                "port": 80
+                            }
+                        ]
+                    }
+                ]
+            }
+        }
+    }
+}
+{
+    "type": "MODIFIED",
+    "object": "HTTPRoute.gateway.networking.k8s.io/frontend/route-1",
+    "causes": [
+        "Service/backends/svc-1"
+    ],
+    "patch": {
+        "analysis": [
+            "HTTPRoute(.gateway.networking.k8s.io) \"frontend/route-1\" references a non-existent Service \"backends/svc-1\""
+        ]
+    }
+}
+`,
+				},
+				{
+					path:      "/httproutes",
+					eventType: watch.Added,
+					object:    route2,
+					wantOut: `
+{
+    "type": "ADDED",
+    "object": "HTTPRoute.gateway.networking.k8s.io/frontend/route-1",
+    "state": {
+        "object": {
+            "apiVersion": "gateway.networking.k8s.io/v1",
+            "kind": "HTTPRoute",
+            "metadata": {
+                "name": "route-1",
+                "namespace": "frontend"
+            },
+            "spec": {
+                "parentRefs": [
+                    {
+                        "name": "gateway-1"
+                    }
+                ],
+                "rules": [
+                    {
+                        "backendRefs": [
+                            {
+                                "name": "svc-1",
+                                "namespace": "backends",
+                                "port": 80
+                            }
+                        ]
+                    }
+                ]
+            }
+        }
+    }
+}
+{
+    "type": "MODIFIED",
+    "object": "HTTPRoute.gateway.networking.k8s.io/frontend/route-1",
+    "causes": [
+        "Service/backends/svc-1"
+    ],
+    "patch": {
+        "analysis": [
+            "HTTPRoute(.gateway.networking.k8s.io) \"frontend/route-1\" references a non-existent Service \"backends/svc-1\""
+        ]
+    }
+}
+{
+    "type": "ADDED",
+    "object": "HTTPRoute.gateway.networking.k8s.io/frontend/route-2",
+    "causes": [
+        "HTTPRoute.gateway.networking.k8s.io/frontend/route-2"
+    ],
+    "state": {
+        "object": {
+            "apiVersion": "gateway.networking.k8s.io/v1",
+            "kind": "HTTPRoute",
+            "metadata": {
+                "name": "route-2",
+                "namespace": "frontend"
+            },
+            "spec": {
+                "parentRefs": [
+                    {
+                        "name": "gateway-1"
+                    }
+                ]
+            }
+        }
+    }
+}
+`,
+				},
+			},
+		},
+		{
+			name:      "get httproutes -A --for gateway/frontend/gateway-1 -w -o json",
+			inputArgs: []string{"httproutes", "-A", "--for", "gateway/frontend/gateway-1", "-w", "-o", "json"},
+			wantOut: `
+{
+    "type": "ADDED",
+    "object": "HTTPRoute.gateway.networking.k8s.io/frontend/route-1",
+    "state": {
+        "object": {
+            "apiVersion": "gateway.networking.k8s.io/v1",
+            "kind": "HTTPRoute",
+            "metadata": {
+                "name": "route-1",
+                "namespace": "frontend"
+            },
+            "spec": {
+                "parentRefs": [
+                    {
+                        "name": "gateway-1"
+                    }
+                ],
+                "rules": [
+                    {
+                        "backendRefs": [
+                            {
+                                "name": "svc-1",
+                                "namespace": "backends",
+                                "port": 80
+                            }
+                        ]
+                    }
+                ]
+            }
+        }
+    }
+}
+`,
+			steps: []watchStep{
+				{
+					path:      "/httproutes",
+					eventType: watch.Added,
+					object:    route2,
+					wantOut: `
+{
+    "type": "ADDED",
+    "object": "HTTPRoute.gateway.networking.k8s.io/frontend/route-1",
+    "state": {
+        "object": {
+            "apiVersion": "gateway.networking.k8s.io/v1",
+            "kind": "HTTPRoute",
+            "metadata": {
+                "name": "route-1",
+                "namespace": "frontend"
+            },
+            "spec": {
+                "parentRefs": [
+                    {
+                        "name": "gateway-1"
+                    }
+                ],
+                "rules": [
+                    {
+                        "backendRefs": [
+                            {
+                                "name": "svc-1",
+                                "namespace": "backends",
+                                "port": 80
+                            }
+                        ]
+                    }
+                ]
+            }
+        }
+    }
+}
+{
+    "type": "ADDED",
+    "object": "HTTPRoute.gateway.networking.k8s.io/frontend/route-2",
+    "causes": [
+        "HTTPRoute.gateway.networking.k8s.io/frontend/route-2"
+    ],
+    "state": {
+        "object": {
+            "apiVersion": "gateway.networking.k8s.io/v1",
+            "kind": "HTTPRoute",
+            "metadata": {
+                "name": "route-2",
+                "namespace": "frontend"
+            },
+            "spec": {
+                "parentRefs": [
+                    {
+                        "name": "gateway-1"
+                    }
+                ]
+            }
+        }
+    }
+}
+`,
+				},
+			},
+		},
+		{
+			name:      "get services -A --for gateway/frontend/gateway-1 -w -o json",
+			inputArgs: []string{"services", "-A", "--for", "gateway/frontend/gateway-1", "-w", "-o", "json"},
+			wantOut: `
+{
+    "type": "ADDED",
+    "object": "Service/backends/svc-1",
+    "state": {
+        "object": {
+            "apiVersion": "v1",
+            "kind": "Service",
+            "metadata": {
+                "name": "svc-1",
+                "namespace": "backends"
+            },
+            "spec": {
+                "ports": [
+                    {
+                        "port": 80
+                    }
+                ]
+            }
+        }
+    }
+}
+`,
+			steps: []watchStep{
+				{
+					path:      "/httproutes",
+					eventType: watch.Added,
+					object:    route3,
+					wantOut: `
+{
+    "type": "ADDED",
+    "object": "Service/backends/svc-1",
+    "state": {
+        "object": {
+            "apiVersion": "v1",
+            "kind": "Service",
+            "metadata": {
+                "name": "svc-1",
+                "namespace": "backends"
+            },
+            "spec": {
+                "ports": [
+                    {
+                        "port": 80
+                    }
+                ]
+            }
+        }
+    }
+}
+{
+    "type": "ADDED",
+    "object": "Service/backends/svc-2",
+    "causes": [
+        "HTTPRoute.gateway.networking.k8s.io/frontend/route-3"
+    ],
+    "state": {
+        "object": {
+            "apiVersion": "v1",
+            "kind": "Service",
+            "metadata": {
+                "name": "svc-2",
+                "namespace": "backends"
+            },
+            "spec": {
+                "ports": [
+                    {
+                        "port": 80
+                    }
+                ]
+            }
+        }
+    }
+}
+`,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory := NewTestFactory(t, testdataReferenceGrants)
+			factory.namespace = tc.namespace
+
+			out := &syncBuffer{}
+			iostreams := genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: out, ErrOut: out}
+			cmd := cmdget.NewCmd(factory, iostreams, tc.describe)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.inputArgs)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() { done <- cmd.ExecuteContext(ctx) }()
+			defer func() {
+				cancel()
+				if err := <-done; err != nil {
+					t.Errorf("Failed to execute command: %v", err)
+				}
+			}()
+
+			waitForOutput(t, out, tc.wantOut)
+			for _, step := range tc.steps {
+				factory.SendWatchEvent(t, step.path, step.eventType, step.object)
+				waitForOutput(t, out, step.wantOut)
+			}
+		})
+	}
+}
+
+// waitForOutput waits until the output matches the wanted output.
+func waitForOutput(t *testing.T, out *syncBuffer, wantOut string) {
+	want := common.MultiLine(strings.TrimPrefix(wantOut, "\n"))
+	var got common.MultiLine
+	_ = wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 10*time.Second, true, func(context.Context) (bool, error) {
+		got = common.MultiLine(out.String())
+		return cmp.Equal(want, got, common.MultiLineTransformer), nil
+	})
+	if diff := cmp.Diff(want, got, common.MultiLineTransformer); diff != "" {
+		t.Fatalf("Unexpected diff:\n\ngot =\n\n%v\n\nwant =\n\n%v\n\ndiff (-want, +got) =\n\n%v", got, want, common.MultiLine(diff))
+	}
+}
+
+func mustUnstructured(t *testing.T, s string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(s), &u.Object); err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+// syncBuffer is a bytes.Buffer which can be written and read concurrently.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}